@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package equivalence benchmarks the allocation of a large job of homogeneous tasks with and without
+// the equivalence cache of the session.
+//
+//	go test ./benchmark/equivalence/ -run none -bench . -benchtime 5x
+package equivalence
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	_ "volcano.sh/volcano/pkg/scheduler/plugins"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+const (
+	replicas    = 512
+	podsPerNode = 5
+)
+
+// buildCache builds a cache with the nodes, each running podsPerNode pods, and a pending job of replicas tasks
+// created from the same pod template.
+func buildCache(nodes int) *schedcache.SchedulerCache {
+	sc := schedcache.NewCustomMockSchedulerCache("volcano", util.NewFakeBinder(replicas), util.NewFakeEvictor(0),
+		&util.FakeStatusUpdater{}, nil, nil, nil)
+	sc.AddQueueV1beta1(util.BuildQueue("default", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("running", "bench", "default", 0, nil, schedulingv1beta1.PodGroupRunning))
+	for i := 0; i < nodes; i++ {
+		node := util.BuildNode(fmt.Sprintf("node-%d", i),
+			api.BuildResourceList("32", "128Gi", []api.ScalarResource{{Name: "pods", Value: "110"}}...), nil)
+		sc.AddOrUpdateNode(node)
+		for j := 0; j < podsPerNode; j++ {
+			sc.AddPod(util.BuildPod("bench", fmt.Sprintf("running-%d-%d", i, j), node.Name, v1.PodRunning,
+				api.BuildResourceList(fmt.Sprint(1+j%4), "2Gi"), "running", nil, nil))
+		}
+	}
+
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("large", "bench", "default", replicas, nil, schedulingv1beta1.PodGroupInqueue))
+	for i := 0; i < replicas; i++ {
+		sc.AddPod(util.BuildPod("bench", fmt.Sprintf("large-%d", i), "", v1.PodPending,
+			api.BuildResourceList("2", "4Gi"), "large", map[string]string{batch.TaskIndex: fmt.Sprint(i)}, nil))
+	}
+	return sc
+}
+
+func BenchmarkAllocateLargeJob(b *testing.B) {
+	options.Default()
+	klog.SetOutput(nil)
+	klog.LogToStderr(false)
+
+	trueValue := true
+	plugin := func(name string) conf.PluginOption {
+		return conf.PluginOption{Name: name, EnabledPredicate: &trueValue, EnabledNodeOrder: &trueValue,
+			EnabledJobOrder: &trueValue, EnabledJobReady: &trueValue, EnabledJobPipelined: &trueValue,
+			EnabledTaskOrder: &trueValue, EnabledQueueOrder: &trueValue, EnabledAllocatable: &trueValue}
+	}
+	tiers := []conf.Tier{
+		{Plugins: []conf.PluginOption{plugin("priority"), plugin("gang")}},
+		{Plugins: []conf.PluginOption{plugin("predicates"), plugin("proportion"), plugin("nodeorder"), plugin("binpack")}},
+	}
+	conf.EnabledActionMap = map[string]bool{"allocate": true}
+
+	for _, nodes := range []int{1000, 5000} {
+		for _, enabled := range []bool{false, true} {
+			name := "Disabled"
+			if enabled {
+				name = "Enabled"
+			}
+			b.Run(fmt.Sprintf("%s/%d", name, nodes), func(b *testing.B) {
+				framework.SetEquivalenceCacheEnabled(enabled)
+				defer framework.SetEquivalenceCacheEnabled(true)
+
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					ssn := framework.OpenSession(buildCache(nodes), tiers, nil)
+					action := allocate.New()
+					b.StartTimer()
+
+					action.Execute(ssn)
+
+					b.StopTimer()
+					allocated := 0
+					for _, task := range ssn.Jobs["bench/large"].Tasks {
+						if task.NodeName != "" {
+							allocated++
+						}
+					}
+					if allocated != replicas {
+						b.Fatalf("expected %d tasks allocated, got %d", replicas, allocated)
+					}
+					framework.CloseSession(ssn)
+					b.StartTimer()
+				}
+			})
+		}
+	}
+}
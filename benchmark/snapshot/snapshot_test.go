@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot benchmarks the latency of the snapshot of the scheduler cache and of opening a scheduling session on it.
+//
+//	go test ./benchmark/snapshot/ -run none -bench . -benchtime 20x
+package snapshot
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+const podsPerNode = 5
+
+// deepSnapshotCache opens the sessions on a deep copy of the cache, as the scheduler did before the snapshots were incremental.
+type deepSnapshotCache struct {
+	schedcache.Cache
+}
+
+func (c deepSnapshotCache) Snapshot() *api.ClusterInfo {
+	return c.DeepSnapshot()
+}
+
+func buildNode(i int, labels map[string]string) *v1.Node {
+	return util.BuildNode(fmt.Sprintf("node-%d", i),
+		api.BuildResourceList("32", "128Gi", []api.ScalarResource{{Name: "pods", Value: "110"}}...), labels)
+}
+
+// buildCache builds a cache with the nodes, each running a job of podsPerNode pods.
+func buildCache(nodes int) *schedcache.SchedulerCache {
+	sc := schedcache.NewCustomMockSchedulerCache("volcano", util.NewFakeBinder(0), util.NewFakeEvictor(0),
+		&util.FakeStatusUpdater{}, nil, nil, nil)
+	sc.AddQueueV1beta1(util.BuildQueue("default", 1, nil))
+	for i := 0; i < nodes; i++ {
+		node := buildNode(i, nil)
+		sc.AddOrUpdateNode(node)
+		pg := fmt.Sprintf("pg-%d", i)
+		sc.AddPodGroupV1beta1(util.BuildPodGroup(pg, "bench", "default", podsPerNode, nil, schedulingv1beta1.PodGroupRunning))
+		for j := 0; j < podsPerNode; j++ {
+			sc.AddPod(util.BuildPod("bench", fmt.Sprintf("%s-%d", pg, j), node.Name, v1.PodRunning,
+				api.BuildResourceList("1", "2Gi"), pg, nil, nil))
+		}
+	}
+	return sc
+}
+
+func BenchmarkOpenSession(b *testing.B) {
+	options.Default()
+	klog.SetOutput(nil)
+	klog.LogToStderr(false)
+
+	for _, nodes := range []int{1000, 5000, 10000} {
+		sc := buildCache(nodes)
+
+		b.Run(fmt.Sprintf("Deep/%d", nodes), func(b *testing.B) {
+			benchmarkOpenSession(b, deepSnapshotCache{sc}, nil)
+		})
+		b.Run(fmt.Sprintf("Incremental/%d", nodes), func(b *testing.B) {
+			benchmarkOpenSession(b, sc, nil)
+		})
+		// The labels of 1% of the nodes change between the sessions.
+		b.Run(fmt.Sprintf("Incremental1PercentChanged/%d", nodes), func(b *testing.B) {
+			benchmarkOpenSession(b, sc, func(i int) {
+				for j := 0; j < nodes/100; j++ {
+					sc.AddOrUpdateNode(buildNode((i*nodes/100+j)%nodes, map[string]string{"iteration": fmt.Sprint(i)}))
+				}
+			})
+		})
+	}
+}
+
+func benchmarkOpenSession(b *testing.B, cache schedcache.Cache, change func(i int)) {
+	// The first session clones the whole cache.
+	framework.CloseSession(framework.OpenSession(cache, nil, nil))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if change != nil {
+			b.StopTimer()
+			change(i)
+			b.StartTimer()
+		}
+		ssn := framework.OpenSession(cache, nil, nil)
+		b.StopTimer()
+		framework.CloseSession(ssn)
+		b.StartTimer()
+	}
+}
+
+// BenchmarkSnapshot measures the snapshot of the cache alone, without the plugins the session opens.
+func BenchmarkSnapshot(b *testing.B) {
+	klog.SetOutput(nil)
+	klog.LogToStderr(false)
+
+	for _, nodes := range []int{1000, 5000, 10000} {
+		sc := buildCache(nodes)
+
+		b.Run(fmt.Sprintf("Deep/%d", nodes), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sc.DeepSnapshot()
+			}
+		})
+		b.Run(fmt.Sprintf("Incremental/%d", nodes), func(b *testing.B) {
+			sc.Snapshot()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				sc.Snapshot()
+			}
+		})
+	}
+}
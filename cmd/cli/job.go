@@ -69,6 +69,13 @@ func buildJobCmd() *cobra.Command {
 			},
 			InitFlags: job.InitResumeFlags,
 		},
+		"why": {
+			Short: "explain why a job is pending",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, job.WhyJob(cmd.Context(), args))
+			},
+			InitFlags: job.InitWhyFlags,
+		},
 		"delete": {
 			Short: "delete a job",
 			RunFunction: func(cmd *cobra.Command, args []string) {
@@ -19,6 +19,7 @@ package options
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -27,15 +28,19 @@ import (
 	componentbaseconfigvalidation "k8s.io/component-base/config/validation"
 
 	"volcano.sh/volcano/pkg/kube"
+	"volcano.sh/volcano/pkg/util/tracing"
 )
 
 const (
-	defaultSchedulerName   = "volcano"
-	defaultSchedulerPeriod = time.Second
-	defaultQueue           = "default"
-	defaultListenAddress   = ":8080"
-	defaultHealthzAddress  = ":11251"
-	defaultPluginsDir      = ""
+	defaultSchedulerName     = "volcano"
+	defaultSchedulerPeriod   = time.Second
+	defaultScheduleMinPeriod = 100 * time.Millisecond
+	defaultQueue             = "default"
+	defaultListenAddress     = ":8080"
+	defaultHealthzAddress    = ":11251"
+	defaultPluginsDir        = ""
+
+	defaultSchedulerConfConfigMapKey = "volcano-scheduler.conf"
 
 	defaultQPS   = 2000.0
 	defaultBurst = 2000
@@ -46,6 +51,9 @@ const (
 	defaultPercentageOfNodesToFind    = 0
 	defaultLockObjectNamespace        = "volcano-system"
 	defaultNodeWorkers                = 20
+	defaultDecisionTraceSessions      = 10
+	defaultTracingEndpoint            = "localhost:4317"
+	defaultTracingSamplingRatio       = 1.0
 )
 
 // ServerOption is the main context object for the controller manager.
@@ -59,13 +67,29 @@ type ServerOption struct {
 	CaCertData        []byte
 	SchedulerNames    []string
 	SchedulerConf     string
-	SchedulePeriod    time.Duration
+	// ShadowSchedulerConf is the path of a candidate scheduler configuration evaluated in a dry run
+	// session alongside every scheduling session, empty disables the shadow session.
+	ShadowSchedulerConf string
+	// SchedulerConfConfigMap is the <namespace>/<name> of a ConfigMap holding the scheduler configuration,
+	// it is watched through the API server and takes precedence over SchedulerConf while it exists.
+	SchedulerConfConfigMap string
+	// SchedulerConfConfigMapKey is the key of the scheduler configuration in the ConfigMap.
+	SchedulerConfConfigMapKey string
+	SchedulePeriod            time.Duration
+	// EnableEventTrigger starts a scheduling cycle early on the events of the cache, e.g. a new PodGroup,
+	// SchedulePeriod remains the longest time between two cycles.
+	EnableEventTrigger bool
+	// ScheduleMinPeriod is the shortest time between the starts of two cycles triggered by events,
+	// the events meanwhile are coalesced into one cycle.
+	ScheduleMinPeriod time.Duration
 	// leaderElection defines the configuration of leader election.
 	LeaderElection config.LeaderElectionConfiguration
 	// Deprecated: use ResourceNamespace instead.
 	LockObjectNamespace string
 	DefaultQueue        string
 	PrintVersion        bool
+	// ValidateConfig validates the scheduler configuration and quits instead of running the scheduler.
+	ValidateConfig      bool
 	EnableMetrics       bool
 	ListenAddress       string
 	EnablePriorityClass bool
@@ -85,6 +109,12 @@ type ServerOption struct {
 	CacheDumpFileDir  string
 	EnableCacheDumper bool
 	NodeWorkerThreads uint32
+	// DecisionTraceSessions is the number of sessions whose decision trace is kept, 0 disables the trace.
+	DecisionTraceSessions int
+	// EnableEquivalenceCache shares the predicate and node order results between the tasks of a job with the same pod template.
+	EnableEquivalenceCache bool
+	// Tracing configures the export of the OpenTelemetry spans of the scheduling sessions and binds.
+	Tracing tracing.Options
 
 	// IgnoredCSIProvisioners contains a list of provisioners, and pod request pvc with these provisioners will
 	// not be counted in pod pvc resource request and node.Allocatable, because the spec.drivers of csinode resource
@@ -117,9 +147,20 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	// volcano scheduler will ignore pods with scheduler names other than specified with the option
 	fs.StringArrayVar(&s.SchedulerNames, "scheduler-name", []string{defaultSchedulerName}, "vc-scheduler will handle pods whose .spec.SchedulerName is same as scheduler-name")
 	fs.StringVar(&s.SchedulerConf, "scheduler-conf", "", "The absolute path of scheduler configuration file")
+	fs.StringVar(&s.ShadowSchedulerConf, "shadow-scheduler-conf", "", "The absolute path of a candidate scheduler configuration file, "+
+		"it is run in a dry run session on the snapshot of every scheduling cycle and compared with the live configuration in the metrics")
+	fs.StringVar(&s.SchedulerConfConfigMap, "scheduler-conf-configmap", "", "The <namespace>/<name> of a ConfigMap holding the scheduler configuration; "+
+		"it is watched directly and takes precedence over --scheduler-conf, which is used as fallback while the ConfigMap does not exist")
+	fs.StringVar(&s.SchedulerConfConfigMapKey, "scheduler-conf-configmap-key", defaultSchedulerConfConfigMapKey, "The key of the scheduler configuration in the ConfigMap of --scheduler-conf-configmap")
 	fs.DurationVar(&s.SchedulePeriod, "schedule-period", defaultSchedulerPeriod, "The period between each scheduling cycle")
+	fs.BoolVar(&s.EnableEventTrigger, "event-trigger", false, "Start a scheduling cycle early on the events worth it, like a new PodGroup, a deleted pod, "+
+		"a new node or a queue change; --schedule-period remains the longest time between two cycles")
+	fs.DurationVar(&s.ScheduleMinPeriod, "schedule-min-period", defaultScheduleMinPeriod, "The shortest time between the starts of two scheduling cycles triggered by events "+
+		"of --event-trigger, the events meanwhile are coalesced into one cycle")
 	fs.StringVar(&s.DefaultQueue, "default-queue", defaultQueue, "The default queue name of the job")
 	fs.BoolVar(&s.PrintVersion, "version", false, "Show version and quit")
+	fs.BoolVar(&s.ValidateConfig, "validate-config", false, "Validate the configuration of --scheduler-conf and --shadow-scheduler-conf, "+
+		"including the arguments of the plugins, and quit")
 	fs.StringVar(&s.ListenAddress, "listen-address", defaultListenAddress, "The address to listen on for HTTP requests.")
 	fs.StringVar(&s.HealthzBindAddress, "healthz-address", defaultHealthzAddress, "The address to listen on for the health check server.")
 	fs.BoolVar(&s.EnablePriorityClass, "priority-class", true,
@@ -145,12 +186,44 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&s.EnableCacheDumper, "cache-dumper", true, "Enable the cache dumper, it's true by default")
 	fs.StringVar(&s.CacheDumpFileDir, "cache-dump-dir", "/tmp", "The target dir where the json file put at when dump cache info to json file")
 	fs.Uint32Var(&s.NodeWorkerThreads, "node-worker-threads", defaultNodeWorkers, "The number of threads syncing node operations.")
+	fs.IntVar(&s.DecisionTraceSessions, "decision-trace-sessions", defaultDecisionTraceSessions, "The number of the latest sessions whose scheduling decisions are kept "+
+		"and served on /debug/decisions with the metrics; 0 disables the decision trace")
+	fs.BoolVar(&s.EnableEquivalenceCache, "equivalence-cache", true, "Share the predicate and node order results of a node between the tasks of a job "+
+		"with the same pod template until the node changes; it is true by default")
+	fs.StringVar(&s.Tracing.Exporter, "tracing-exporter", tracing.ExporterNone, "The exporter of the OpenTelemetry spans of the scheduling sessions and binds: "+
+		"none, otlp, stdout or file; it is none by default")
+	fs.StringVar(&s.Tracing.Endpoint, "tracing-endpoint", defaultTracingEndpoint, "The host:port of the OpenTelemetry collector the otlp exporter sends the spans to")
+	fs.BoolVar(&s.Tracing.Insecure, "tracing-insecure", false, "Connect to the OpenTelemetry collector without TLS")
+	fs.StringVar(&s.Tracing.File, "tracing-file", "", "The file the file exporter appends the spans to")
+	fs.Float64Var(&s.Tracing.SamplingRatio, "tracing-sampling-ratio", defaultTracingSamplingRatio, "The ratio of the sampled scheduling sessions, between 0 and 1")
 	fs.StringSliceVar(&s.IgnoredCSIProvisioners, "ignored-provisioners", nil, "The provisioners that will be ignored during pod pvc request computation and preemption.")
 }
 
 // CheckOptionOrDie check leader election flag when LeaderElection is enabled.
 func (s *ServerOption) CheckOptionOrDie() error {
-	return componentbaseconfigvalidation.ValidateLeaderElectionConfiguration(&s.LeaderElection, field.NewPath("leaderElection")).ToAggregate()
+	errs := componentbaseconfigvalidation.ValidateLeaderElectionConfiguration(&s.LeaderElection, field.NewPath("leaderElection"))
+	if s.SchedulerConfConfigMap != "" {
+		if parts := strings.Split(s.SchedulerConfConfigMap, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			errs = append(errs, field.Invalid(field.NewPath("schedulerConfConfigMap"), s.SchedulerConfConfigMap, "must be <namespace>/<name>"))
+		}
+	}
+	if s.EnableEventTrigger && (s.ScheduleMinPeriod <= 0 || s.ScheduleMinPeriod > s.SchedulePeriod) {
+		errs = append(errs, field.Invalid(field.NewPath("scheduleMinPeriod"), s.ScheduleMinPeriod.String(), "must be positive and not greater than the schedule period"))
+	}
+	switch s.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+	case tracing.ExporterFile:
+		if s.Tracing.File == "" {
+			errs = append(errs, field.Required(field.NewPath("tracingFile"), "must be set with the file exporter"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("tracingExporter"), s.Tracing.Exporter,
+			[]string{tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout, tracing.ExporterFile}))
+	}
+	if s.Tracing.SamplingRatio < 0 || s.Tracing.SamplingRatio > 1 {
+		errs = append(errs, field.Invalid(field.NewPath("tracingSamplingRatio"), s.Tracing.SamplingRatio, "must be between 0 and 1"))
+	}
+	return errs.ToAggregate()
 }
 
 // RegisterOptions registers options.
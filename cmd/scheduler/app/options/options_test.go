@@ -33,6 +33,7 @@ import (
 	"volcano.sh/volcano/pkg/features"
 	"volcano.sh/volcano/pkg/kube"
 	commonutil "volcano.sh/volcano/pkg/util"
+	"volcano.sh/volcano/pkg/util/tracing"
 )
 
 func TestAddFlags(t *testing.T) {
@@ -56,8 +57,9 @@ func TestAddFlags(t *testing.T) {
 
 	// This is a snapshot of expected options parsed by args.
 	expected := &ServerOption{
-		SchedulerNames: []string{defaultSchedulerName},
-		SchedulePeriod: 5 * time.Minute,
+		SchedulerNames:    []string{defaultSchedulerName},
+		SchedulePeriod:    5 * time.Minute,
+		ScheduleMinPeriod: defaultScheduleMinPeriod,
 		LeaderElection: config.LeaderElectionConfiguration{
 			LeaderElect:       true,
 			LeaseDuration:     metav1.Duration{Duration: 60 * time.Second},
@@ -76,12 +78,20 @@ func TestAddFlags(t *testing.T) {
 			Burst:      defaultBurst,
 		},
 		PluginsDir:                 defaultPluginsDir,
+		SchedulerConfConfigMapKey:  defaultSchedulerConfConfigMapKey,
 		HealthzBindAddress:         ":11251",
 		MinNodesToFind:             defaultMinNodesToFind,
 		MinPercentageOfNodesToFind: defaultMinPercentageOfNodesToFind,
 		PercentageOfNodesToFind:    defaultPercentageOfNodesToFind,
 		NodeWorkerThreads:          defaultNodeWorkers,
+		DecisionTraceSessions:      defaultDecisionTraceSessions,
+		EnableEquivalenceCache:     true,
 		CacheDumpFileDir:           "/tmp",
+		Tracing: tracing.Options{
+			Exporter:      tracing.ExporterNone,
+			Endpoint:      defaultTracingEndpoint,
+			SamplingRatio: defaultTracingSamplingRatio,
+		},
 	}
 	expectedFeatureGates := map[featuregate.Feature]bool{
 		features.PodDisruptionBudgetsSupport: false,
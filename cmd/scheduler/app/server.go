@@ -30,6 +30,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/signals"
 	commonutil "volcano.sh/volcano/pkg/util"
+	"volcano.sh/volcano/pkg/util/tracing"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -64,12 +65,26 @@ func Run(opt *options.ServerOption) error {
 		}
 	}
 
+	opt.Tracing.ServiceName = "volcano-scheduler"
+	shutdownTracing, err := tracing.Init(context.Background(), opt.Tracing)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			klog.Errorf("Failed to flush the spans: %v", err)
+		}
+	}()
+
 	sched, err := scheduler.NewScheduler(config, opt)
 	if err != nil {
 		panic(err)
 	}
 
 	if opt.EnableMetrics {
+		if err := registerDebugHandlers(config, sched); err != nil {
+			return err
+		}
 		go func() {
 			http.Handle("/metrics", commonutil.PromHandler())
 			klog.Fatalf("Prometheus Http Server failed %s", http.ListenAndServe(opt.ListenAddress, nil))
@@ -142,3 +157,28 @@ func Run(opt *options.ServerOption) error {
 	})
 	return fmt.Errorf("lost lease")
 }
+
+// registerDebugHandlers serves the debug endpoints of the scheduler next to the metrics,
+// callers are authenticated and authorized by the kube-apiserver. The metrics listener does
+// not serve TLS, so the bearer tokens of the callers and the served snapshots, which hold
+// the pods of the whole cluster, are sent in plaintext: the listen address must only be
+// reachable from trusted networks, or be fronted by a TLS terminating proxy.
+func registerDebugHandlers(config *restclient.Config, sched *scheduler.Scheduler) error {
+	handlers := sched.DebugHandlers()
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	kubeClient, err := clientset.NewForConfig(restclient.AddUserAgent(config, "debug-auth"))
+	if err != nil {
+		return err
+	}
+	for path, handler := range handlers {
+		authHandler, err := commonutil.WithDelegatedAuth(kubeClient, handler)
+		if err != nil {
+			return err
+		}
+		http.Handle(path, authHandler)
+	}
+	return nil
+}
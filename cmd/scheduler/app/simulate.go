@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	goflag "flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/simulator"
+)
+
+// SimulateCommand is the sub command of vc-scheduler which replays a cluster state offline.
+const SimulateCommand = "simulate"
+
+// RunSimulate runs `vc-scheduler simulate`: it loads a cluster state and a scheduler configuration,
+// executes one scheduling session against an in-memory cache and prints the report.
+func RunSimulate(args []string) error {
+	var clusterFile, output, outputFormat string
+
+	fs := pflag.NewFlagSet(SimulateCommand, pflag.ContinueOnError)
+	fs.AddGoFlagSet(goflag.CommandLine)
+	// The scheduler options are registered as well, so that tuning flags such as
+	// --percentage-nodes-to-find behave the same as in the live scheduler.
+	s := options.NewServerOption()
+	s.AddFlags(fs)
+	fs.StringVar(&clusterFile, "cluster", "", "The json or yaml file of the cluster state to replay, or a cluster snapshot dumped by the scheduler")
+	fs.StringVar(&output, "output", "", "The file the report is written to; it is written to stdout by default")
+	fs.StringVar(&outputFormat, "output-format", "json", "The format of the report, json or yaml")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	s.RegisterOptions()
+
+	if clusterFile == "" {
+		return fmt.Errorf("--cluster is required")
+	}
+	if outputFormat != "json" && outputFormat != "yaml" {
+		return fmt.Errorf("unsupported output format %q", outputFormat)
+	}
+
+	cluster, err := simulator.LoadCluster(clusterFile)
+	if err != nil {
+		return err
+	}
+
+	var schedulerConf string
+	if s.SchedulerConf != "" {
+		data, err := os.ReadFile(s.SchedulerConf)
+		if err != nil {
+			return fmt.Errorf("failed to read scheduler conf %s: %v", s.SchedulerConf, err)
+		}
+		schedulerConf = string(data)
+	}
+
+	sim, err := simulator.New(cluster, schedulerConf)
+	if err != nil {
+		return err
+	}
+	report, err := sim.Run()
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return writeReport(w, report, outputFormat)
+}
+
+func writeReport(w io.Writer, report *simulator.Report, format string) error {
+	var data []byte
+	var err error
+	if format == "yaml" {
+		data, err = yaml.Marshal(report)
+	} else {
+		data, err = json.MarshalIndent(report, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %v", err)
+	}
+
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
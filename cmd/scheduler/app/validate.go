@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// ValidateConfig runs `vc-scheduler --validate-config`: it validates the scheduler configuration
+// and the shadow configuration the same way the scheduler does on reload, without connecting to
+// the cluster, and writes the result of every file to out.
+func ValidateConfig(opt *options.ServerOption, out io.Writer) error {
+	if opt.PluginsDir != "" {
+		if err := framework.LoadCustomPlugins(opt.PluginsDir); err != nil {
+			return fmt.Errorf("failed to load custom plugins: %v", err)
+		}
+	}
+
+	var errs []error
+	for _, path := range []string{opt.SchedulerConf, opt.ShadowSchedulerConf} {
+		if path == "" {
+			continue
+		}
+		if err := validateSchedulerConf(path); err != nil {
+			fmt.Fprintf(out, "%s: invalid\n", path)
+			errs = append(errs, fmt.Errorf("%s: %v", path, err))
+			continue
+		}
+		fmt.Fprintf(out, "%s: valid\n", path)
+	}
+	if opt.SchedulerConf == "" {
+		fmt.Fprintf(out, "--scheduler-conf is not set, the default configuration is used\n")
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateSchedulerConf(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	_, _, _, _, err = scheduler.UnmarshalSchedulerConf(strings.TrimSpace(string(data)))
+	return err
+}
@@ -52,6 +52,15 @@ func main() {
 
 	klog.InitFlags(nil)
 
+	// `vc-scheduler simulate` replays a cluster state offline instead of running the scheduler.
+	if len(os.Args) > 1 && os.Args[1] == app.SimulateCommand {
+		if err := app.RunSimulate(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	fs := pflag.CommandLine
 	s := options.NewServerOption()
 
@@ -70,6 +79,14 @@ func main() {
 		return
 	}
 
+	if s.ValidateConfig {
+		if err := app.ValidateConfig(s, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := s.CheckOptionOrDie(); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
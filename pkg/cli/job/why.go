@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+	"volcano.sh/volcano/pkg/scheduler/api/trace"
+)
+
+const decisionTracePath = "/debug/decisions"
+
+type whyFlags struct {
+	util.CommonFlags
+
+	Namespace        string
+	JobName          string
+	SchedulerAddress string
+	Sessions         int
+}
+
+var whyJobFlags = &whyFlags{}
+
+// InitWhyFlags init the why command flags.
+func InitWhyFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &whyJobFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&whyJobFlags.Namespace, "namespace", "n", "default", "the namespace of job")
+	cmd.Flags().StringVarP(&whyJobFlags.JobName, "name", "N", "", "the name of job, it may also be given as argument")
+	cmd.Flags().StringVar(&whyJobFlags.SchedulerAddress, "scheduler-address", "http://127.0.0.1:8080",
+		"the address the scheduler serves its metrics on, e.g. forwarded by `kubectl port-forward -n volcano-system svc/volcano-scheduler-service 8080`")
+	cmd.Flags().IntVar(&whyJobFlags.Sessions, "sessions", 1, "the number of the latest sessions to show")
+}
+
+// WhyJob explains why the job is pending with the decision trace of the latest scheduling sessions.
+func WhyJob(ctx context.Context, args []string) error {
+	if len(args) > 0 {
+		whyJobFlags.JobName = args[0]
+	}
+	if whyJobFlags.JobName == "" {
+		return fmt.Errorf("job name (specified by --name or -N or as argument) is mandatory to explain a job")
+	}
+
+	config, err := util.BuildConfig(whyJobFlags.Master, whyJobFlags.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	pgName, err := podGroupName(ctx, config, whyJobFlags.Namespace, whyJobFlags.JobName)
+	if err != nil {
+		return err
+	}
+
+	traces, err := getJobTraces(config, whyJobFlags.SchedulerAddress, whyJobFlags.Namespace, pgName)
+	if err != nil {
+		return err
+	}
+	if len(traces) == 0 {
+		fmt.Printf("Job %s/%s was not seen in the latest scheduling sessions\n", whyJobFlags.Namespace, whyJobFlags.JobName)
+		return nil
+	}
+	if whyJobFlags.Sessions > 0 && len(traces) > whyJobFlags.Sessions {
+		traces = traces[len(traces)-whyJobFlags.Sessions:]
+	}
+
+	PrintJobTraces(traces, os.Stdout)
+	return nil
+}
+
+// podGroupName returns the name of the podgroup of the vcjob, the name is taken
+// as a podgroup name if there is no such vcjob.
+func podGroupName(ctx context.Context, config *rest.Config, namespace, name string) (string, error) {
+	jobClient := versioned.NewForConfigOrDie(config)
+	job, err := jobClient.BatchV1alpha1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return name, nil
+		}
+		return "", err
+	}
+	return fmt.Sprintf("%s-%s", job.Name, job.UID), nil
+}
+
+// getJobTraces queries the decision trace of the podgroup from the scheduler,
+// the request is authenticated with the credentials of the kubeconfig.
+func getJobTraces(config *rest.Config, address, namespace, name string) ([]*trace.SessionTrace, error) {
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("namespace", namespace)
+	query.Set("name", name)
+	resp, err := client.Get(strings.TrimSuffix(address, "/") + decisionTracePath + "?" + query.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the scheduler: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to query the scheduler: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var traces []*trace.SessionTrace
+	if err := json.NewDecoder(resp.Body).Decode(&traces); err != nil {
+		return nil, fmt.Errorf("failed to decode the decision trace: %v", err)
+	}
+	return traces, nil
+}
+
+// PrintJobTraces prints the decision trace of a job in every given session.
+func PrintJobTraces(traces []*trace.SessionTrace, writer io.Writer) {
+	for _, st := range traces {
+		for _, job := range st.Jobs {
+			WriteLine(writer, Level0, "Session %s at %s:\n", st.Session, st.EndTime.Format("2006-01-02T15:04:05Z07:00"))
+			WriteLine(writer, Level1, "Job:     \t%s/%s\n", job.Namespace, job.Name)
+			WriteLine(writer, Level1, "Queue:   \t%s\n", job.Queue)
+			if len(job.Actions) > 0 {
+				WriteLine(writer, Level1, "Actions: \t%s\n", strings.Join(job.Actions, ", "))
+			} else {
+				WriteLine(writer, Level1, "Actions: \t<none>\n")
+			}
+			if len(job.Vetoes) > 0 {
+				WriteLine(writer, Level1, "Vetoes:\n")
+				for _, veto := range job.Vetoes {
+					WriteLine(writer, Level2, "%s/%s\tby %s", veto.Action, veto.ExtensionPoint, veto.Plugin)
+					if veto.Reason != "" {
+						fmt.Fprintf(writer, ": %s", veto.Reason)
+					}
+					fmt.Fprintln(writer)
+				}
+			}
+			if len(job.Tasks) > 0 {
+				WriteLine(writer, Level1, "Predicate Failures:\n")
+				for _, task := range job.Tasks {
+					WriteLine(writer, Level2, "%s: %s\n", task.Name, reasonsHistogram(task.Reasons))
+					nodes := make([]string, 0, len(task.NodeErrors))
+					for node := range task.NodeErrors {
+						nodes = append(nodes, node)
+					}
+					sort.Strings(nodes)
+					for _, node := range nodes {
+						WriteLine(writer, Level2+1, "%s:\t%s\n", node, strings.Join(task.NodeErrors[node], ", "))
+					}
+				}
+			}
+			if job.Message != "" {
+				WriteLine(writer, Level1, "Message: \t%s\n", job.Message)
+			}
+		}
+	}
+}
+
+func reasonsHistogram(reasons map[string]int) string {
+	histogram := make([]string, 0, len(reasons))
+	for reason, count := range reasons {
+		histogram = append(histogram, fmt.Sprintf("%d %s", count, reason))
+	}
+	sort.Strings(histogram)
+	return strings.Join(histogram, ", ")
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/scheduler/api/trace"
+)
+
+func TestWhyJob(t *testing.T) {
+	job := v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "test", UID: "uid1"},
+	}
+	traces := []*trace.SessionTrace{
+		{
+			Session: "s1",
+			Jobs: []*trace.JobTrace{{
+				Namespace: "test",
+				Name:      "job1-uid1",
+				Queue:     "q1",
+				Actions:   []string{"enqueue", "allocate"},
+				Vetoes:    []*trace.Veto{{Action: "allocate", Plugin: "proportion", ExtensionPoint: trace.Overused, Reason: "queue <q1> is overused"}},
+			}},
+		},
+	}
+
+	var query string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var val []byte
+		if strings.HasPrefix(r.URL.Path, decisionTracePath) {
+			query = r.URL.RawQuery
+			val, _ = json.Marshal(traces)
+		} else {
+			val, _ = json.Marshal(job)
+		}
+		w.Write(val)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	whyJobFlags.Master = server.URL
+	whyJobFlags.SchedulerAddress = server.URL
+	whyJobFlags.Namespace = "test"
+
+	if err := WhyJob(context.TODO(), []string{"job1"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if query != "name=job1-uid1&namespace=test" {
+		t.Errorf("expected the podgroup of the job to be queried, got %q", query)
+	}
+}
+
+func TestPrintJobTraces(t *testing.T) {
+	traces := []*trace.SessionTrace{
+		{
+			Session: "s1",
+			Jobs: []*trace.JobTrace{{
+				Namespace: "test",
+				Name:      "pg1",
+				Queue:     "q1",
+				Actions:   []string{"allocate"},
+				Vetoes:    []*trace.Veto{{Action: "allocate", Plugin: "gang", ExtensionPoint: trace.JobValid, Reason: "NotEnoughPods"}},
+				Tasks: []*trace.TaskTrace{{
+					Name:       "p1",
+					Reasons:    map[string]int{"Insufficient cpu": 2},
+					NodeErrors: map[string][]string{"n1": {"Insufficient cpu"}, "n2": {"Insufficient cpu"}},
+				}},
+				Message: "pod group is not ready",
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	PrintJobTraces(traces, &buf)
+	for _, expected := range []string{"Session s1", "test/pg1", "allocate/JobValid\tby gang: NotEnoughPods", "p1: 2 Insufficient cpu", "n2:\tInsufficient cpu", "pod group is not ready"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected %q in output:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestInitWhyFlags(t *testing.T) {
+	var cmd cobra.Command
+	InitWhyFlags(&cmd)
+
+	for _, name := range []string{"namespace", "name", "scheduler-address", "sessions"} {
+		if cmd.Flag(name) == nil {
+			t.Errorf("Could not find the flag %s", name)
+		}
+	}
+}
@@ -215,8 +215,11 @@ func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *a
 			break
 		}
 
-		predicateNodes, fitErrors := ph.PredicateNodes(task, allNodes, alloc.predicate, alloc.enablePredicateErrorCache)
+		// The nodes without enough future idle resource or failing the node selector or taints are pruned with the node index.
+		indexedNodes := alloc.session.CandidateNodes(task, api.FutureIdleFilter)
+		predicateNodes, fitErrors := ph.PredicateNodes(task, indexedNodes, alloc.predicate, alloc.enablePredicateErrorCache)
 		if len(predicateNodes) == 0 {
+			alloc.session.SetPrunedNodeErrors(task, api.FutureIdleFilter, fitErrors)
 			job.NodesFitErrors[task.UID] = fitErrors
 			// Assume that all left tasks are allocatable, but can not meet gang-scheduling min member,
 			// so we should break from continuously allocating.
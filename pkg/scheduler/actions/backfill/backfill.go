@@ -74,8 +74,9 @@ func (backfill *Action) Execute(ssn *framework.Session) {
 			break
 		}
 
-		predicateNodes, fitErrors := ph.PredicateNodes(task, ssn.NodeList, predicateFunc, backfill.enablePredicateErrorCache)
+		predicateNodes, fitErrors := ph.PredicateNodes(task, ssn.CandidateNodes(task, 0), predicateFunc, backfill.enablePredicateErrorCache)
 		if len(predicateNodes) == 0 {
+			ssn.SetPrunedNodeErrors(task, 0, fitErrors)
 			job.NodesFitErrors[task.UID] = fitErrors
 			break
 		}
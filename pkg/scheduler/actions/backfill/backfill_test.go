@@ -1,21 +1,27 @@
 package backfill
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1alpha3"
 	schedulingapi "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
+	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/capabilities/dynamicresources"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/drf"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
 	"volcano.sh/volcano/pkg/scheduler/plugins/priority"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
@@ -160,3 +166,103 @@ func TestPickUpPendingTasks(t *testing.T) {
 		}
 	}
 }
+
+// fakeClaimBinder allocates the resource claims of the pods without a cluster and records them.
+type fakeClaimBinder struct {
+	allocated []string
+	reverted  []string
+	bound     []string
+}
+
+func (fcb *fakeClaimBinder) GetPodClaims(task *api.TaskInfo, node *v1.Node) (*dynamicresources.PodClaims, error) {
+	podClaims := &dynamicresources.PodClaims{}
+	for _, claim := range task.Pod.Spec.ResourceClaims {
+		podClaims.Claims = append(podClaims.Claims, &resourceapi.ResourceClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: task.Namespace, Name: task.Name + "-" + claim.Name},
+		})
+	}
+	return podClaims, nil
+}
+
+func (fcb *fakeClaimBinder) AllocateClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) error {
+	fcb.allocated = append(fcb.allocated, task.Name)
+	return nil
+}
+
+func (fcb *fakeClaimBinder) RevertClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) {
+	fcb.reverted = append(fcb.reverted, task.Name)
+	task.PodClaims = nil
+}
+
+func (fcb *fakeClaimBinder) BindClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) error {
+	fcb.bound = append(fcb.bound, task.Name)
+	return nil
+}
+
+func TestBackfillResourceClaims(t *testing.T) {
+	options.Default()
+	framework.RegisterPluginBuilder("gang", gang.New)
+	defer framework.CleanupPluginBuilders()
+	trueValue := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{Name: "gang", EnabledJobReady: &trueValue}}}}
+	tests := []struct {
+		name        string
+		minMember   int32
+		expectBound bool
+	}{
+		{
+			name:        "the claims of a dispatched task are allocated",
+			minMember:   1,
+			expectBound: true,
+		},
+		{
+			name:      "the claims of a task whose job is not ready are reverted",
+			minMember: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			binder := util.NewFakeBinder(10)
+			schedulerCache := cache.NewCustomMockSchedulerCache("volcano", binder, nil, nil, nil, nil, nil)
+			claimBinder := &fakeClaimBinder{}
+			schedulerCache.ClaimBinder = claimBinder
+			schedulerCache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+			schedulerCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+			schedulerCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", test.minMember, nil, schedulingv1beta1.PodGroupInqueue))
+			pod := util.BuildPod("c1", "p1", "", v1.PodPending, nil, "pg1", nil, nil)
+			pod.Spec.ResourceClaims = []v1.PodResourceClaim{{Name: "gpu"}}
+			schedulerCache.AddPod(pod)
+			// The job is ready with the pods not backfilled only, which request more than the node has.
+			for i := int32(1); i < test.minMember; i++ {
+				schedulerCache.AddPod(util.BuildPod("c1", fmt.Sprintf("p%d", i+1), "", v1.PodPending, api.BuildResourceList("8", "16Gi"), "pg1", nil, nil))
+			}
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			schedulerCache.Run(stopCh)
+
+			ssn := framework.OpenSession(schedulerCache, tiers, nil)
+			defer framework.CloseSession(ssn)
+			New().Execute(ssn)
+
+			assert.Equal(t, []string{"p1"}, claimBinder.allocated)
+			task := ssn.Jobs["c1/pg1"].Tasks[api.TaskID(pod.UID)]
+			if test.expectBound {
+				select {
+				case <-binder.Channel:
+				case <-time.After(time.Second):
+					t.Fatalf("the task should be bound")
+				}
+				assert.Empty(t, claimBinder.reverted)
+				assert.Equal(t, []string{"p1"}, claimBinder.bound)
+				if assert.NotNil(t, task.PodClaims) {
+					assert.Equal(t, "p1-gpu", task.PodClaims.Claims[0].Name)
+				}
+			} else {
+				assert.Equal(t, []string{"p1"}, claimBinder.reverted)
+				assert.Empty(t, claimBinder.bound)
+				assert.Nil(t, task.PodClaims)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elastic
+
+import (
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+/*
+   The elastic action runs after allocate and backfill, so that only the resources left idle are given to elastic pods:
+
+   actions: "enqueue, allocate, backfill, elastic"
+*/
+
+// Action scales the running elastic jobs back up between their minAvailable and their replicas with the idle
+// resources, including the jobs of the overused queues the allocate action skips: the elastic pods are evicted
+// first when the resources are needed again. The jobs with the lowest share of their elastic pods running
+// are scaled up first, one pod at a time.
+type Action struct {
+	enablePredicateErrorCache bool
+}
+
+func New() *Action {
+	return &Action{
+		enablePredicateErrorCache: true,
+	}
+}
+
+func (ea *Action) Name() string {
+	return "elastic"
+}
+
+func (ea *Action) Initialize() {}
+
+func (ea *Action) parseArguments(ssn *framework.Session) {
+	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, ea.Name())
+	arguments.GetBool(&ea.enablePredicateErrorCache, conf.EnablePredicateErrCacheKey)
+}
+
+func (ea *Action) Execute(ssn *framework.Session) {
+	klog.V(5).Infof("Enter Elastic ...")
+	defer klog.V(5).Infof("Leaving Elastic ...")
+
+	ea.parseArguments(ssn)
+
+	jobs := util.NewPriorityQueue(func(l, r interface{}) bool {
+		lv := l.(*api.JobInfo)
+		rv := r.(*api.JobInfo)
+		if lr, rr := elasticRatio(lv), elasticRatio(rv); lr != rr {
+			return lr < rr
+		}
+		return ssn.JobOrderFn(l, r)
+	})
+	tasks := map[api.JobID]*util.PriorityQueue{}
+	for _, job := range ssn.Jobs {
+		// The jobs not running yet are gang scheduled by the allocate action.
+		if job.IsPending() || job.ReadyTaskNum() < job.MinAvailable {
+			continue
+		}
+		if vr := ssn.JobValid(job); vr != nil && !vr.Pass {
+			continue
+		}
+		pending := util.NewPriorityQueue(ssn.TaskOrderFn)
+		for _, task := range job.TaskStatusIndex[api.Pending] {
+			if task.SchGated || task.Resreq.IsEmpty() {
+				continue
+			}
+			pending.Push(task)
+		}
+		if pending.Empty() {
+			continue
+		}
+		tasks[job.UID] = pending
+		jobs.Push(job)
+	}
+
+	predicateFunc := ssn.PredicateForAllocateAction
+	for !jobs.Empty() {
+		job := jobs.Pop().(*api.JobInfo)
+		task := tasks[job.UID].Pop().(*api.TaskInfo)
+
+		if queue, found := ssn.Queues[job.Queue]; !found || !ssn.Allocatable(queue, task) {
+			klog.V(3).Infof("Queue <%s> can not allocate task <%s/%s>, skip scaling up job <%s/%s>",
+				job.Queue, task.Namespace, task.Name, job.Namespace, job.Name)
+			continue
+		}
+		if ea.allocate(ssn, job, task, predicateFunc) && !tasks[job.UID].Empty() {
+			jobs.Push(job)
+		}
+	}
+}
+
+func (ea *Action) UnInitialize() {}
+
+// allocate allocates the task on the best node with enough idle resources, and returns whether it did.
+func (ea *Action) allocate(ssn *framework.Session, job *api.JobInfo, task *api.TaskInfo, predicateFunc api.PredicateFn) bool {
+	if err := ssn.PrePredicateFn(task); err != nil {
+		klog.V(3).Infof("PrePredicate for task %s/%s failed in elastic for: %v", task.Namespace, task.Name, err)
+		return false
+	}
+
+	ph := util.NewPredicateHelper()
+	var idleNodes []*api.NodeInfo
+	predicateNodes, _ := ph.PredicateNodes(task, ssn.CandidateNodes(task, api.FutureIdleFilter), predicateFunc, ea.enablePredicateErrorCache)
+	for _, node := range predicateNodes {
+		if task.InitResreq.LessEqual(node.Idle, api.Zero) {
+			idleNodes = append(idleNodes, node)
+		}
+	}
+	if len(idleNodes) == 0 {
+		klog.V(4).Infof("No node with enough idle resources for elastic task <%s/%s>", task.Namespace, task.Name)
+		return false
+	}
+
+	node := idleNodes[0]
+	if len(idleNodes) > 1 {
+		nodeScores := util.PrioritizeNodes(task, idleNodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
+		node = ssn.BestNodeFn(task, nodeScores)
+		if node == nil {
+			node = util.SelectBestNode(nodeScores)
+		}
+	}
+
+	klog.V(3).Infof("Scale up job <%s/%s>: binding elastic task <%s/%s> to node <%s>",
+		job.Namespace, job.Name, task.Namespace, task.Name, node.Name)
+	stmt := framework.NewStatement(ssn)
+	if err := stmt.Allocate(task, node); err != nil {
+		klog.Errorf("Failed to bind elastic task %v on %v in Session %v: %v", task.UID, node.Name, ssn.UID, err)
+		stmt.Discard()
+		return false
+	}
+	stmt.Commit()
+	return true
+}
+
+// elasticRatio returns the share of the elastic pods of the job which are running.
+func elasticRatio(job *api.JobInfo) float64 {
+	elastic := int32(len(job.Tasks)) - job.MinAvailable
+	if elastic <= 0 {
+		return 1
+	}
+	return float64(job.ReadyTaskNum()-job.MinAvailable) / float64(elastic)
+}
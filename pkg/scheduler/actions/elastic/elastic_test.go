@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elastic
+
+import (
+	"os"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+func buildNode(name, cpu string) *v1.Node {
+	return util.BuildNode(name, api.BuildResourceList(cpu, "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+}
+
+func TestElastic(t *testing.T) {
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:            gang.PluginName,
+					EnabledJobOrder: &trueValue,
+					EnabledJobReady: &trueValue,
+				},
+				{
+					Name:             predicates.PluginName,
+					EnabledPredicate: &trueValue,
+				},
+			},
+		},
+	}
+	plugins := map[string]framework.PluginBuilder{
+		gang.PluginName:       gang.New,
+		predicates.PluginName: predicates.New,
+	}
+
+	// Both jobs run their minAvailable pod and have 2 elastic pods pending.
+	buildPods := func() []*v1.Pod {
+		return []*v1.Pod{
+			util.BuildPod("c1", "a-0", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg-a", nil, nil),
+			util.BuildPod("c1", "a-1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg-a", nil, nil),
+			util.BuildPod("c1", "a-2", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg-a", nil, nil),
+			util.BuildPod("c1", "b-0", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg-b", nil, nil),
+			util.BuildPod("c1", "b-1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg-b", nil, nil),
+			util.BuildPod("c1", "b-2", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg-b", nil, nil),
+		}
+	}
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name: "the idle resources are shared between the elastic jobs",
+			PodGroups: []*schedulingv1.PodGroup{
+				util.BuildPodGroup("pg-a", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning),
+				util.BuildPodGroup("pg-b", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning),
+			},
+			Pods:           buildPods(),
+			Nodes:          []*v1.Node{buildNode("n1", "4")},
+			Queues:         []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap:  map[string]string{"c1/a-1": "n1", "c1/b-1": "n1"},
+			ExpectBindsNum: 2,
+		},
+		{
+			Name: "the elastic jobs are scaled up to their replicas",
+			PodGroups: []*schedulingv1.PodGroup{
+				util.BuildPodGroup("pg-a", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning),
+				util.BuildPodGroup("pg-b", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning),
+			},
+			Pods:           buildPods(),
+			Nodes:          []*v1.Node{buildNode("n1", "8")},
+			Queues:         []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap:  map[string]string{"c1/a-1": "n1", "c1/a-2": "n1", "c1/b-1": "n1", "c1/b-2": "n1"},
+			ExpectBindsNum: 4,
+		},
+		{
+			Name: "the jobs below minAvailable are left to the allocate action",
+			PodGroups: []*schedulingv1.PodGroup{
+				util.BuildPodGroup("pg-a", "c1", "c1", 2, nil, schedulingv1.PodGroupRunning),
+				util.BuildPodGroup("pg-b", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning),
+			},
+			Pods:           buildPods(),
+			Nodes:          []*v1.Node{buildNode("n1", "8")},
+			Queues:         []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap:  map[string]string{"c1/b-1": "n1", "c1/b-2": "n1"},
+			ExpectBindsNum: 2,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = plugins
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -19,9 +19,11 @@ package actions
 import (
 	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
 	"volcano.sh/volcano/pkg/scheduler/actions/backfill"
+	"volcano.sh/volcano/pkg/scheduler/actions/elastic"
 	"volcano.sh/volcano/pkg/scheduler/actions/enqueue"
 	"volcano.sh/volcano/pkg/scheduler/actions/preempt"
 	"volcano.sh/volcano/pkg/scheduler/actions/reclaim"
+	"volcano.sh/volcano/pkg/scheduler/actions/reserve"
 	"volcano.sh/volcano/pkg/scheduler/actions/shuffle"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 )
@@ -33,4 +35,6 @@ func init() {
 	framework.RegisterAction(preempt.New())
 	framework.RegisterAction(enqueue.New())
 	framework.RegisterAction(shuffle.New())
+	framework.RegisterAction(reserve.New())
+	framework.RegisterAction(elastic.New())
 }
@@ -246,6 +246,10 @@ func (pmpt *Action) preempt(
 	predicateFn := ssn.PredicateForPreemptAction
 	// we should filter out those nodes that are UnschedulableAndUnresolvable status got in allocate action
 	allNodes := ssn.GetUnschedulableAndUnresolvableNodesForTask(preemptor)
+	// Evicting tasks does not help on the nodes failing the node selector or taints, they are pruned with the node index.
+	if candidateNodes := ssn.CandidateNodes(preemptor, 0); len(candidateNodes) < len(ssn.NodeList) {
+		allNodes = util.IntersectNodes(allNodes, candidateNodes)
+	}
 	predicateNodes, _ := predicateHelper.PredicateNodes(preemptor, allNodes, predicateFn, pmpt.enablePredicateErrorCache)
 
 	nodeScores := util.PrioritizeNodes(preemptor, predicateNodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
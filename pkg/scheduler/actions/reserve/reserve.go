@@ -0,0 +1,411 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reserve
+
+import (
+	"sort"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+const (
+	// TimeoutKey is the argument of the time after which a reservation not satisfied is released
+	TimeoutKey = "timeout"
+	// StarvingThresholdKey is the argument of the time a starving job waits before nodes are reserved for it
+	StarvingThresholdKey = "starvingThreshold"
+
+	defaultTimeout           = 30 * time.Minute
+	defaultStarvingThreshold = 5 * time.Minute
+)
+
+/*
+   The reserve action must run before allocate and backfill, which then keep the other jobs off the reserved nodes:
+
+   actions: "enqueue, reserve, allocate, backfill"
+   configurations:
+   - name: reserve
+     arguments:
+       timeout: 30m
+       starvingThreshold: 5m
+*/
+
+// Action reserves nodes for the highest priority starving job, so that it is not starved by streams of
+// smaller jobs. The jobs declaring their expected runtime may still use the reserved nodes if they finish
+// before the reservation is expected to be satisfied, like EASY backfilling.
+type Action struct {
+	timeout           time.Duration
+	starvingThreshold time.Duration
+
+	// reservation is kept across the sessions until it is satisfied, cancelled or expires.
+	reservation *api.Reservation
+	// seenTasks are the tasks seen on the reserved nodes, the new ones of other jobs are backfilled.
+	seenTasks map[api.TaskID]bool
+	// expired holds until when the jobs whose reservation expired are not reserved for again.
+	expired map[api.JobID]time.Time
+}
+
+func New() *Action {
+	return &Action{
+		timeout:           defaultTimeout,
+		starvingThreshold: defaultStarvingThreshold,
+		expired:           map[api.JobID]time.Time{},
+	}
+}
+
+func (ra *Action) Name() string {
+	return "reserve"
+}
+
+func (ra *Action) Initialize() {}
+
+func (ra *Action) parseArguments(ssn *framework.Session) {
+	ra.timeout = defaultTimeout
+	ra.starvingThreshold = defaultStarvingThreshold
+	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, ra.Name())
+	parseDuration(arguments, TimeoutKey, &ra.timeout)
+	parseDuration(arguments, StarvingThresholdKey, &ra.starvingThreshold)
+}
+
+func parseDuration(arguments framework.Arguments, key string, ptr *time.Duration) {
+	value, ok := arguments[key].(string)
+	if !ok {
+		return
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		klog.Warningf("Could not parse argument: %s for key %s, with err %v", value, key, err)
+		return
+	}
+	*ptr = duration
+}
+
+func (ra *Action) Execute(ssn *framework.Session) {
+	klog.V(5).Infof("Enter Reserve ...")
+	defer klog.V(5).Infof("Leaving Reserve ...")
+
+	ra.parseArguments(ssn)
+	now := time.Now()
+
+	// The dry run sessions enforce the reservation of the live sessions without changing it.
+	if !ssn.DryRun() {
+		ra.releaseReservation(ssn, now)
+		if ra.reservation == nil {
+			ra.createReservation(ssn, now)
+		}
+	}
+	if ra.reservation == nil {
+		return
+	}
+
+	ra.reservation.ShadowTime = shadowTime(ssn, ra.reservation, now)
+	if !ssn.DryRun() {
+		ra.countBackfilledTasks(ssn)
+	}
+	klog.V(3).Infof("Nodes %v are reserved for job <%s> until %v, expected to be satisfied at %v",
+		nodeNames(ra.reservation), ra.reservation.Job, ra.reservation.Deadline, ra.reservation.ShadowTime)
+	ssn.SetReservation(ra.reservation)
+}
+
+func (ra *Action) UnInitialize() {}
+
+// releaseReservation releases the reservation if its job is gone, ready or if it expired.
+func (ra *Action) releaseReservation(ssn *framework.Session, now time.Time) {
+	r := ra.reservation
+	if r == nil {
+		return
+	}
+
+	var result string
+	job, found := ssn.Jobs[r.Job]
+	switch {
+	case !found || job.IsPending():
+		result = metrics.ReservationCancelled
+	case job.ReadyTaskNum() >= job.MinAvailable:
+		result = metrics.ReservationSatisfied
+	case now.After(r.Deadline):
+		result = metrics.ReservationExpired
+		// Give the other starving jobs a chance before reserving for the job again.
+		ra.expired[r.Job] = now.Add(ra.timeout)
+	default:
+		return
+	}
+
+	klog.V(3).Infof("Release the nodes reserved for job <%s>: %s", r.Job, result)
+	metrics.RegisterReservationReleased(result, now.Sub(r.CreationTime))
+	ra.reservation = nil
+	ra.seenTasks = nil
+}
+
+// createReservation reserves nodes for the highest priority starving job.
+func (ra *Action) createReservation(ssn *framework.Session, now time.Time) {
+	for jobID, until := range ra.expired {
+		if now.After(until) {
+			delete(ra.expired, jobID)
+		}
+	}
+
+	job := ra.targetJob(ssn, now)
+	if job == nil {
+		return
+	}
+	r := planReservation(ssn, job, now)
+	if r == nil {
+		return
+	}
+	r.Deadline = now.Add(ra.timeout)
+
+	// The plugins may change the reserved nodes of the session reservation.
+	ssn.SetReservation(r)
+	ssn.ReservedNodes()
+	if len(r.Nodes) == 0 {
+		ssn.SetReservation(nil)
+		return
+	}
+
+	klog.V(3).Infof("Reserve nodes %v for starving job <%s/%s>", nodeNames(r), job.Namespace, job.Name)
+	metrics.RegisterReservationCreated(len(r.Nodes))
+	ra.reservation = r
+	ra.seenTasks = map[api.TaskID]bool{}
+	for name := range r.Nodes {
+		if node, found := ssn.Nodes[name]; found {
+			for id := range node.Tasks {
+				ra.seenTasks[id] = true
+			}
+		}
+	}
+}
+
+// targetJob returns the highest priority job starving for longer than the threshold.
+func (ra *Action) targetJob(ssn *framework.Session, now time.Time) *api.JobInfo {
+	var starving []*api.JobInfo
+	for _, job := range ssn.Jobs {
+		if job.IsPending() || !job.HasPendingTasks() || job.ReadyTaskNum() >= job.MinAvailable {
+			continue
+		}
+		if vr := ssn.JobValid(job); vr != nil && !vr.Pass {
+			continue
+		}
+		if _, found := ra.expired[job.UID]; found {
+			continue
+		}
+		since := job.ScheduleStartTimestamp.Time
+		if since.IsZero() {
+			since = job.CreationTimestamp.Time
+		}
+		if now.Sub(since) < ra.starvingThreshold || !ssn.JobStarving(job) {
+			continue
+		}
+		starving = append(starving, job)
+	}
+	if len(starving) == 0 {
+		return nil
+	}
+
+	if job := ssn.TargetJob(starving); job != nil {
+		return job
+	}
+	jobs := util.NewPriorityQueue(ssn.JobOrderFn)
+	for _, job := range starving {
+		jobs.Push(job)
+	}
+	return jobs.Pop().(*api.JobInfo)
+}
+
+// planReservation selects the nodes the pending tasks the job needs to be ready fit on once the tasks
+// of the other jobs finish, the nodes with the most idle resources first. The pods not managed by
+// the scheduler, like the DaemonSet ones, are not expected to finish.
+func planReservation(ssn *framework.Session, job *api.JobInfo, now time.Time) *api.Reservation {
+	needed := int(job.MinAvailable - job.ReadyTaskNum())
+	tasks := util.NewPriorityQueue(ssn.TaskOrderFn)
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		if !task.SchGated {
+			tasks.Push(task)
+		}
+	}
+	if tasks.Len() < needed {
+		klog.V(4).Infof("Job <%s/%s> has %d pending tasks but needs %d, no node is reserved", job.Namespace, job.Name, tasks.Len(), needed)
+		return nil
+	}
+
+	nodes := make([]*api.NodeInfo, 0, len(ssn.NodeList))
+	planned := map[string]*api.Resource{}
+	for _, node := range ssn.NodeList {
+		if !node.Ready() {
+			continue
+		}
+		nodes = append(nodes, node)
+		planned[node.Name] = api.EmptyResource()
+		for _, task := range node.Tasks {
+			if _, found := ssn.Jobs[task.Job]; !found {
+				planned[node.Name].Add(task.Resreq)
+			}
+		}
+	}
+	idle := make(map[string]*api.Resource, len(nodes))
+	for _, node := range nodes {
+		idle[node.Name] = node.FutureIdle()
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if idle[nodes[i].Name].MilliCPU != idle[nodes[j].Name].MilliCPU {
+			return idle[nodes[i].Name].MilliCPU > idle[nodes[j].Name].MilliCPU
+		}
+		return idle[nodes[i].Name].Memory > idle[nodes[j].Name].Memory
+	})
+
+	r := &api.Reservation{
+		Job:          job.UID,
+		Nodes:        map[string]bool{},
+		Requests:     map[string]*api.Resource{},
+		CreationTime: now,
+	}
+	for i := 0; i < needed; i++ {
+		task := tasks.Pop().(*api.TaskInfo)
+		if err := ssn.PrePredicateFn(task); err != nil {
+			klog.V(4).Infof("PrePredicate for task <%s/%s> failed: %v, no node is reserved", task.Namespace, task.Name, err)
+			return nil
+		}
+		placed := false
+		for _, node := range nodes {
+			if !planned[node.Name].Clone().Add(task.InitResreq).LessEqual(node.Allocatable, api.Zero) {
+				continue
+			}
+			if err := ssn.PredicateFn(task, node); err != nil {
+				continue
+			}
+			planned[node.Name].Add(task.InitResreq)
+			if !r.Nodes[node.Name] {
+				r.Nodes[node.Name] = true
+				r.Requests[node.Name] = api.EmptyResource()
+			}
+			r.Requests[node.Name].Add(task.InitResreq)
+			placed = true
+			break
+		}
+		if !placed {
+			klog.V(4).Infof("Task <%s/%s> of job <%s/%s> does not fit any node, no node is reserved", task.Namespace, task.Name, job.Namespace, job.Name)
+			return nil
+		}
+	}
+	return r
+}
+
+// shadowTime returns when every reserved node is expected to have released the resources planned for the job,
+// assuming the running tasks finish after the expected runtime of their job. It is zero if unknown.
+func shadowTime(ssn *framework.Session, r *api.Reservation, now time.Time) time.Time {
+	shadow := now
+	for name := range r.Nodes {
+		node, found := ssn.Nodes[name]
+		if !found {
+			continue
+		}
+		available := node.FutureIdle()
+		type release struct {
+			end    time.Time
+			resreq *api.Resource
+		}
+		var releases []release
+		for _, task := range node.Tasks {
+			if task.Job == r.Job {
+				available.Add(task.Resreq)
+				continue
+			}
+			if !api.AllocatedStatus(task.Status) {
+				continue
+			}
+			if end, known := expectedEndTime(ssn, task, now); known {
+				releases = append(releases, release{end: end, resreq: task.Resreq})
+			}
+		}
+		sort.Slice(releases, func(i, j int) bool { return releases[i].end.Before(releases[j].end) })
+
+		satisfied := r.Requests[name].LessEqual(available, api.Zero)
+		nodeTime := now
+		for i := 0; i < len(releases) && !satisfied; i++ {
+			available.Add(releases[i].resreq)
+			nodeTime = releases[i].end
+			satisfied = r.Requests[name].LessEqual(available, api.Zero)
+		}
+		if !satisfied {
+			return time.Time{}
+		}
+		if nodeTime.After(shadow) {
+			shadow = nodeTime
+		}
+	}
+	return shadow
+}
+
+// expectedEndTime returns when the task is expected to finish given the expected runtime of its job,
+// a task running longer than expected is expected to finish now.
+func expectedEndTime(ssn *framework.Session, task *api.TaskInfo, now time.Time) (time.Time, bool) {
+	job, found := ssn.Jobs[task.Job]
+	if !found {
+		return time.Time{}, false
+	}
+	runtime, found := job.ExpectedRuntime()
+	if !found {
+		return time.Time{}, false
+	}
+	start := now
+	if task.Pod != nil && task.Pod.Status.StartTime != nil {
+		start = task.Pod.Status.StartTime.Time
+	}
+	end := start.Add(runtime)
+	if end.Before(now) {
+		end = now
+	}
+	return end, true
+}
+
+// nodeNames returns the sorted names of the reserved nodes.
+func nodeNames(r *api.Reservation) []string {
+	names := make([]string, 0, len(r.Nodes))
+	for name := range r.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// countBackfilledTasks records the tasks of the other jobs that were placed on the reserved nodes.
+func (ra *Action) countBackfilledTasks(ssn *framework.Session) {
+	backfilled := 0
+	for name := range ra.reservation.Nodes {
+		node, found := ssn.Nodes[name]
+		if !found {
+			continue
+		}
+		for id, task := range node.Tasks {
+			if ra.seenTasks[id] {
+				continue
+			}
+			ra.seenTasks[id] = true
+			if task.Job != ra.reservation.Job {
+				backfilled++
+			}
+		}
+	}
+	if backfilled > 0 {
+		metrics.RegisterReservationBackfilledTasks(backfilled)
+	}
+}
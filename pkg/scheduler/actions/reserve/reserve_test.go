@@ -0,0 +1,289 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reserve
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
+	"volcano.sh/volcano/pkg/scheduler/plugins/priority"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+func buildPodGroup(name string, minMember int32, phase schedulingv1.PodGroupPhase, runtime string) *schedulingv1.PodGroup {
+	pg := util.BuildPodGroup(name, "c1", "c1", minMember, nil, phase)
+	if runtime != "" {
+		pg.Annotations = map[string]string{api.JobExpectedRuntime: runtime}
+	}
+	return pg
+}
+
+func buildNode(name string) *v1.Node {
+	return util.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+}
+
+var trueValue = true
+
+var tiers = []conf.Tier{
+	{
+		Plugins: []conf.PluginOption{
+			{
+				Name:               gang.PluginName,
+				EnabledJobOrder:    &trueValue,
+				EnabledJobReady:    &trueValue,
+				EnabledJobStarving: &trueValue,
+			},
+			{
+				Name:            priority.PluginName,
+				EnabledJobOrder: &trueValue,
+			},
+			{
+				Name:             predicates.PluginName,
+				EnabledPredicate: &trueValue,
+			},
+		},
+	},
+}
+
+var plugins = map[string]framework.PluginBuilder{
+	gang.PluginName:       gang.New,
+	priority.PluginName:   priority.New,
+	predicates.PluginName: predicates.New,
+}
+
+func TestReserve(t *testing.T) {
+	// The running job leaves 1 CPU idle on n1 only, the big job needs both nodes entirely.
+	buildPods := func() []*v1.Pod {
+		return []*v1.Pod{
+			util.BuildPod("c1", "running-1", "n1", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg-running", nil, nil),
+			util.BuildPod("c1", "running-2", "n2", v1.PodRunning, api.BuildResourceList("4", "1Gi"), "pg-running", nil, nil),
+			util.BuildPod("c1", "big-1", "", v1.PodPending, api.BuildResourceList("4", "1Gi"), "pg-big", nil, nil),
+			util.BuildPod("c1", "big-2", "", v1.PodPending, api.BuildResourceList("4", "1Gi"), "pg-big", nil, nil),
+			util.BuildPod("c1", "small", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg-small", nil, nil),
+		}
+	}
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		actions       []framework.Action
+		reservedNodes []string
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "without reservation the small job takes the idle resources",
+				PodGroups: []*schedulingv1.PodGroup{
+					buildPodGroup("pg-running", 2, schedulingv1.PodGroupRunning, "1h"),
+					buildPodGroup("pg-big", 2, schedulingv1.PodGroupInqueue, ""),
+					buildPodGroup("pg-small", 1, schedulingv1.PodGroupInqueue, ""),
+				},
+				Pods:           buildPods(),
+				Nodes:          []*v1.Node{buildNode("n1"), buildNode("n2")},
+				Queues:         []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+				ExpectBindMap:  map[string]string{"c1/small": "n1"},
+				ExpectBindsNum: 1,
+			},
+			actions: []framework.Action{allocate.New()},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "the reserved nodes are kept for the big job",
+				PodGroups: []*schedulingv1.PodGroup{
+					buildPodGroup("pg-running", 2, schedulingv1.PodGroupRunning, "1h"),
+					buildPodGroup("pg-big", 2, schedulingv1.PodGroupInqueue, ""),
+					buildPodGroup("pg-small", 1, schedulingv1.PodGroupInqueue, ""),
+				},
+				Pods:   buildPods(),
+				Nodes:  []*v1.Node{buildNode("n1"), buildNode("n2")},
+				Queues: []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			},
+			actions:       []framework.Action{New(), allocate.New()},
+			reservedNodes: []string{"n1", "n2"},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "a job finishing before the reservation is satisfied is backfilled",
+				PodGroups: []*schedulingv1.PodGroup{
+					buildPodGroup("pg-running", 2, schedulingv1.PodGroupRunning, "1h"),
+					buildPodGroup("pg-big", 2, schedulingv1.PodGroupInqueue, ""),
+					buildPodGroup("pg-small", 1, schedulingv1.PodGroupInqueue, "10m"),
+				},
+				Pods:           buildPods(),
+				Nodes:          []*v1.Node{buildNode("n1"), buildNode("n2")},
+				Queues:         []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+				ExpectBindMap:  map[string]string{"c1/small": "n1"},
+				ExpectBindsNum: 1,
+			},
+			actions:       []framework.Action{New(), allocate.New()},
+			reservedNodes: []string{"n1", "n2"},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "a job finishing after the reservation is satisfied is not backfilled",
+				PodGroups: []*schedulingv1.PodGroup{
+					buildPodGroup("pg-running", 2, schedulingv1.PodGroupRunning, "1h"),
+					buildPodGroup("pg-big", 2, schedulingv1.PodGroupInqueue, ""),
+					buildPodGroup("pg-small", 1, schedulingv1.PodGroupInqueue, "2h"),
+				},
+				Pods:   buildPods(),
+				Nodes:  []*v1.Node{buildNode("n1"), buildNode("n2")},
+				Queues: []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			},
+			actions:       []framework.Action{New(), allocate.New()},
+			reservedNodes: []string{"n1", "n2"},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "the running tasks without expected runtime prevent backfilling",
+				PodGroups: []*schedulingv1.PodGroup{
+					buildPodGroup("pg-running", 2, schedulingv1.PodGroupRunning, ""),
+					buildPodGroup("pg-big", 2, schedulingv1.PodGroupInqueue, ""),
+					buildPodGroup("pg-small", 1, schedulingv1.PodGroupInqueue, "10m"),
+				},
+				Pods:   buildPods(),
+				Nodes:  []*v1.Node{buildNode("n1"), buildNode("n2")},
+				Queues: []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			},
+			actions:       []framework.Action{New(), allocate.New()},
+			reservedNodes: []string{"n1", "n2"},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = plugins
+			ssn := test.RegisterSession(tiers, []conf.Configuration{{Name: "reserve", Arguments: map[string]interface{}{StarvingThresholdKey: "1s"}}})
+			defer test.Close()
+			test.Run(test.actions)
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+			var reserved []string
+			if r := ssn.Reservation(); r != nil {
+				reserved = nodeNames(r)
+				if r.Job != "c1/pg-big" {
+					t.Errorf("expected the nodes reserved for c1/pg-big, got %s", r.Job)
+				}
+			}
+			if len(reserved) != len(test.reservedNodes) || (len(reserved) > 0 && reserved[0] != test.reservedNodes[0]) {
+				t.Errorf("expected reserved nodes %v, got %v", test.reservedNodes, reserved)
+			}
+		})
+	}
+}
+
+func TestReservationRelease(t *testing.T) {
+	test := uthelper.TestCommonStruct{
+		Name: "release",
+		PodGroups: []*schedulingv1.PodGroup{
+			buildPodGroup("pg-big", 1, schedulingv1.PodGroupInqueue, ""),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("c1", "big-1", "", v1.PodPending, api.BuildResourceList("8", "1Gi"), "pg-big", nil, nil),
+		},
+		Nodes:   []*v1.Node{buildNode("n1"), buildNode("n2")},
+		Queues:  []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+		Plugins: plugins,
+	}
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+
+	// The task fits no node, nothing is reserved.
+	action := New()
+	action.Execute(ssn)
+	if ssn.Reservation() != nil {
+		t.Fatalf("expected no reservation, got %v", ssn.Reservation())
+	}
+
+	ssn.Jobs["c1/pg-big"].Tasks[api.TaskID(test.Pods[0].UID)].InitResreq = api.NewResource(api.BuildResourceList("2", "1Gi"))
+	action.Execute(ssn)
+	r := ssn.Reservation()
+	if r == nil || len(r.Nodes) != 1 {
+		t.Fatalf("expected one reserved node, got %v", r)
+	}
+
+	// The reservation expires and the job is not reserved for again before the timeout.
+	r.Deadline = time.Now().Add(-time.Second)
+	ssn.SetReservation(nil)
+	action.Execute(ssn)
+	if action.reservation != nil || ssn.Reservation() != nil {
+		t.Fatalf("expected the reservation to expire, got %v", action.reservation)
+	}
+	if _, found := action.expired["c1/pg-big"]; !found {
+		t.Errorf("expected the job to be cooled down after the expiration")
+	}
+
+	// The reservation is released once the job is ready.
+	delete(action.expired, "c1/pg-big")
+	action.Execute(ssn)
+	if action.reservation == nil {
+		t.Fatalf("expected a new reservation")
+	}
+	ssn.Jobs["c1/pg-big"].MinAvailable = 0
+	action.Execute(ssn)
+	if action.reservation != nil {
+		t.Errorf("expected the satisfied reservation to be released, got %v", action.reservation)
+	}
+}
+
+func TestReservationLocks(t *testing.T) {
+	now := time.Now()
+	short := &api.JobInfo{UID: "c1/short", PodGroup: &api.PodGroup{}}
+	short.PodGroup.Annotations = map[string]string{api.JobExpectedRuntime: "10m"}
+	long := &api.JobInfo{UID: "c1/long", PodGroup: &api.PodGroup{}}
+	long.PodGroup.Annotations = map[string]string{api.JobExpectedRuntime: "2h"}
+	unknown := &api.JobInfo{UID: "c1/unknown"}
+	target := &api.JobInfo{UID: "c1/big"}
+
+	r := &api.Reservation{Job: "c1/big", Nodes: map[string]bool{"n1": true}, ShadowTime: now.Add(time.Hour)}
+	for _, c := range []struct {
+		job    *api.JobInfo
+		node   string
+		locked bool
+	}{
+		{job: target, node: "n1", locked: false},
+		{job: short, node: "n1", locked: false},
+		{job: long, node: "n1", locked: true},
+		{job: unknown, node: "n1", locked: true},
+		{job: unknown, node: "n2", locked: false},
+	} {
+		if locked := r.Locks(c.job, c.node, now); locked != c.locked {
+			t.Errorf("expected job %s locked %v on %s, got %v", c.job.UID, c.locked, c.node, locked)
+		}
+	}
+
+	r.ShadowTime = time.Time{}
+	if !r.Locks(short, "n1", now) {
+		t.Errorf("expected every other job locked with an unknown shadow time")
+	}
+}
@@ -29,6 +29,8 @@ type ClusterInfo struct {
 	RevocableNodes map[string]*NodeInfo
 	NodeList       []string
 	CSINodesStatus map[string]*CSINodeStatusInfo
+	// NodeIndex indexes the nodes of NodeList, it is built by the session if nil.
+	NodeIndex *NodeIndex
 }
 
 func (ci ClusterInfo) String() string {
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides a fake device driver for unit tests. The node gives the number of its devices by the
+// DeviceCountAnnotation annotation and the pod requests the devices by the DeviceResource resource, the devices
+// are allocated and released in memory.
+package fake
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"volcano.sh/volcano/pkg/scheduler/api/devices"
+)
+
+const (
+	// DeviceName used to indicate this device
+	DeviceName = "fake"
+
+	// DeviceCountAnnotation is the annotation of the node giving the number of its devices
+	DeviceCountAnnotation = "volcano.sh/fake-device-count"
+	// DeviceResource is the resource of the containers requesting the devices
+	DeviceResource v1.ResourceName = "volcano.sh/fake-device"
+
+	// BinpackPolicy prefers the nodes with the fewer free devices
+	BinpackPolicy = "binpack"
+	// SpreadPolicy prefers the nodes with the more free devices
+	SpreadPolicy = "spread"
+
+	scoreMultiplier = 100
+)
+
+// Register registers the fake driver, the tests registering it are expected to call Unregister when done.
+func Register() {
+	devices.RegisterDriver(DeviceName, func(nodeName string, node *v1.Node) devices.Devices {
+		return NewDevices(nodeName, node)
+	})
+}
+
+// Unregister unregisters the fake driver.
+func Unregister() {
+	devices.UnregisterDriver(DeviceName)
+}
+
+// Devices are the fake devices of a node.
+type Devices struct {
+	sync.Mutex
+
+	// Name is the name of the node
+	Name string
+	// Total is the number of the devices of the node
+	Total int
+	// Used are the number of the devices used by the pods, by their UID
+	Used map[string]int
+	// Allocated are the number of the devices allocated to the pods by the scheduler, by their UID
+	Allocated map[string]int
+}
+
+// make sure Devices implements devices.Devices interface
+var _ devices.Devices = new(Devices)
+
+// NewDevices returns the fake devices of the node, nil if the node has none.
+func NewDevices(name string, node *v1.Node) *Devices {
+	if node == nil {
+		return nil
+	}
+	count, err := strconv.Atoi(node.Annotations[DeviceCountAnnotation])
+	if err != nil || count <= 0 {
+		return nil
+	}
+	return &Devices{
+		Name:      name,
+		Total:     count,
+		Used:      map[string]int{},
+		Allocated: map[string]int{},
+	}
+}
+
+// GetRequest returns the number of the devices requested by the pod.
+func GetRequest(pod *v1.Pod) int {
+	request := 0
+	for _, c := range pod.Spec.Containers {
+		if q, ok := c.Resources.Limits[DeviceResource]; ok {
+			request += int(q.Value())
+		}
+	}
+	return request
+}
+
+// Free returns the number of the free devices.
+func (d *Devices) Free() int {
+	d.Lock()
+	defer d.Unlock()
+	return d.free()
+}
+
+func (d *Devices) free() int {
+	used := 0
+	for _, n := range d.Used {
+		used += n
+	}
+	return d.Total - used
+}
+
+// AddResource adds the devices used by the pod.
+func (d *Devices) AddResource(pod *v1.Pod) {
+	if d == nil {
+		return
+	}
+	if request := GetRequest(pod); request > 0 {
+		d.Lock()
+		defer d.Unlock()
+		d.Used[string(pod.UID)] = request
+	}
+}
+
+// SubResource subtracts the devices used by the pod.
+func (d *Devices) SubResource(pod *v1.Pod) {
+	if d == nil {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	delete(d.Used, string(pod.UID))
+}
+
+// HasDeviceRequest checks if the pod requests the devices.
+func (d *Devices) HasDeviceRequest(pod *v1.Pod) bool {
+	return GetRequest(pod) > 0
+}
+
+// FilterNode checks if the pod fits in the free devices.
+func (d *Devices) FilterNode(pod *v1.Pod, policy string) (int, string, error) {
+	request := GetRequest(pod)
+	if free := d.Free(); request > free {
+		return devices.Unschedulable, fmt.Sprintf("node %s has %d free fake devices, %d requested", d.Name, free, request), nil
+	}
+	return devices.Success, "", nil
+}
+
+// ScoreNode scores the node by its used devices for the binpack policy and by its free devices for the spread policy.
+func (d *Devices) ScoreNode(pod *v1.Pod, policy string) float64 {
+	if d.Total == 0 {
+		return 0
+	}
+	free := d.Free()
+	switch policy {
+	case BinpackPolicy:
+		return scoreMultiplier * float64(d.Total-free) / float64(d.Total)
+	case SpreadPolicy:
+		return scoreMultiplier * float64(free) / float64(d.Total)
+	}
+	return 0
+}
+
+// Allocate allocates the devices to the pod in memory.
+func (d *Devices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) error {
+	request := GetRequest(pod)
+	d.Lock()
+	defer d.Unlock()
+	d.Allocated[string(pod.UID)] = request
+	return nil
+}
+
+// Release releases the devices allocated to the pod.
+func (d *Devices) Release(kubeClient kubernetes.Interface, pod *v1.Pod) error {
+	d.Lock()
+	defer d.Unlock()
+	delete(d.Allocated, string(pod.UID))
+	return nil
+}
+
+// GetIgnoredDevices returns no devices, the fake devices are not known to kubelet.
+func (d *Devices) GetIgnoredDevices() []string {
+	return []string{}
+}
+
+// GetStatus returns the used devices.
+func (d *Devices) GetStatus() string {
+	return fmt.Sprintf("%d/%d fake devices used", d.Total-d.Free(), d.Total)
+}
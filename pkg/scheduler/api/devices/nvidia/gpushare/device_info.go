@@ -47,6 +47,12 @@ type GPUDevices struct {
 	Device map[int]*GPUDevice
 }
 
+func init() {
+	devices.RegisterDriver(DeviceName, func(nodeName string, node *v1.Node) devices.Devices {
+		return NewGPUDevices(nodeName, node)
+	})
+}
+
 // NewGPUDevice creates a device
 func NewGPUDevice(id int, mem uint) *GPUDevice {
 	return &GPUDevice{
@@ -180,8 +186,7 @@ func (gs *GPUDevices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) err
 	klog.V(4).Infoln("DeviceSharing:Into AllocateToPod", pod.Name)
 	if getGPUMemoryOfPod(pod) > 0 {
 		if NodeLockEnable {
-			nodelock.UseClient(kubeClient)
-			err := nodelock.LockNode(gs.Name, "gpu")
+			err := nodelock.LockNodeWithClient(kubeClient, gs.Name, "gpu")
 			if err != nil {
 				return errors.Errorf("node %s locked for lockname gpushare %s", gs.Name, err.Error())
 			}
@@ -83,6 +83,12 @@ func NewGPUDevice(id int, mem uint) *GPUDevice {
 	}
 }
 
+func init() {
+	devices.RegisterDriver(DeviceName, func(nodeName string, node *v1.Node) devices.Devices {
+		return NewGPUDevices(nodeName, node)
+	})
+}
+
 func NewGPUDevices(name string, node *v1.Node) *GPUDevices {
 	if node == nil {
 		return nil
@@ -233,8 +239,7 @@ func (gs *GPUDevices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) err
 			return err
 		}
 		if NodeLockEnable {
-			nodelock.UseClient(kubeClient)
-			err = nodelock.LockNode(gs.Name, DeviceName)
+			err = nodelock.LockNodeWithClient(kubeClient, gs.Name, DeviceName)
 			if err != nil {
 				return errors.Errorf("node %s locked for %s hamivgpu lockname %s", gs.Name, pod.Name, err.Error())
 			}
@@ -248,7 +253,7 @@ func (gs *GPUDevices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) err
 
 		annotations[DeviceBindPhase] = "allocating"
 		annotations[BindTimeAnnotations] = strconv.FormatInt(time.Now().Unix(), 10)
-		err = patchPodAnnotations(pod, annotations)
+		err = patchPodAnnotations(kubeClient, pod, annotations)
 		if err != nil {
 			return err
 		}
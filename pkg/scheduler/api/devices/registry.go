@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devices
+
+import (
+	"reflect"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// NewDevicesFn parses the device inventory of the node, given by its annotations or its resources, and returns
+// the devices of the node, nil if it has none.
+type NewDevicesFn func(nodeName string, node *v1.Node) Devices
+
+// driver builds the devices of a kind on the nodes.
+type driver struct {
+	name       string
+	newDevices NewDevicesFn
+}
+
+var (
+	driversMutex sync.RWMutex
+	// drivers are the registered drivers in the order of their registration
+	drivers []driver
+)
+
+// RegisterDriver registers the driver of the devices of the name, the devices of the nodes are then kept in their
+// Others by the name and the deviceshare plugin filters, scores, allocates and releases them. Registering a name
+// again replaces its driver. The drivers are expected to register in the init of their package.
+func RegisterDriver(name string, newDevices NewDevicesFn) {
+	driversMutex.Lock()
+	defer driversMutex.Unlock()
+
+	for i := range drivers {
+		if drivers[i].name == name {
+			klog.V(3).Infof("Replace the driver of device %s", name)
+			drivers[i].newDevices = newDevices
+			return
+		}
+	}
+	drivers = append(drivers, driver{name: name, newDevices: newDevices})
+}
+
+// UnregisterDriver unregisters the driver of the devices of the name.
+func UnregisterDriver(name string) {
+	driversMutex.Lock()
+	defer driversMutex.Unlock()
+
+	for i := range drivers {
+		if drivers[i].name == name {
+			drivers = append(drivers[:i], drivers[i+1:]...)
+			return
+		}
+	}
+}
+
+// RegisteredDrivers returns the names of the registered drivers in the order of their registration.
+func RegisteredDrivers() []string {
+	driversMutex.RLock()
+	defer driversMutex.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for _, d := range drivers {
+		names = append(names, d.name)
+	}
+	return names
+}
+
+// NewDevices returns the devices of the node built by the driver of the name, nil if the driver is not
+// registered or the node has none of its devices.
+func NewDevices(name, nodeName string, node *v1.Node) Devices {
+	driversMutex.RLock()
+	var newDevices NewDevicesFn
+	for _, d := range drivers {
+		if d.name == name {
+			newDevices = d.newDevices
+			break
+		}
+	}
+	driversMutex.RUnlock()
+
+	if newDevices == nil {
+		return nil
+	}
+	return newDevices(nodeName, node)
+}
+
+// IsNil returns whether the devices are nil, the drivers returning their nil devices as a typed nil.
+func IsNil(devices Devices) bool {
+	if devices == nil {
+		return true
+	}
+	value := reflect.ValueOf(devices)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devices
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type testDevices struct {
+	name string
+}
+
+func (d *testDevices) AddResource(pod *v1.Pod)           {}
+func (d *testDevices) SubResource(pod *v1.Pod)           {}
+func (d *testDevices) HasDeviceRequest(pod *v1.Pod) bool { return false }
+func (d *testDevices) FilterNode(pod *v1.Pod, policy string) (int, string, error) {
+	return Success, "", nil
+}
+func (d *testDevices) ScoreNode(pod *v1.Pod, policy string) float64 { return 0 }
+func (d *testDevices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) error {
+	return nil
+}
+func (d *testDevices) Release(kubeClient kubernetes.Interface, pod *v1.Pod) error {
+	return nil
+}
+func (d *testDevices) GetIgnoredDevices() []string { return nil }
+func (d *testDevices) GetStatus() string           { return d.name }
+
+func newTestDevicesFn(name string) NewDevicesFn {
+	return func(nodeName string, node *v1.Node) Devices {
+		if node == nil {
+			var devices *testDevices
+			return devices
+		}
+		return &testDevices{name: name}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	saved := drivers
+	drivers = nil
+	defer func() { drivers = saved }()
+
+	RegisterDriver("a", newTestDevicesFn("a1"))
+	RegisterDriver("b", newTestDevicesFn("b"))
+	RegisterDriver("a", newTestDevicesFn("a2"))
+	if names := RegisteredDrivers(); !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Errorf("expected drivers [a b] in the order of their registration, but got %v", names)
+	}
+	if status := NewDevices("a", "n1", &v1.Node{}).GetStatus(); status != "a2" {
+		t.Errorf("expected the driver registered again to replace the former, but got %s", status)
+	}
+	if devices := NewDevices("c", "n1", &v1.Node{}); devices != nil {
+		t.Errorf("expected no devices of an unregistered driver, but got %v", devices)
+	}
+	if devices := NewDevices("b", "n1", nil); devices == nil || !IsNil(devices) {
+		t.Errorf("expected typed nil devices, but got %v", devices)
+	}
+	if IsNil(NewDevices("b", "n1", &v1.Node{})) {
+		t.Errorf("expected the devices not to be nil")
+	}
+
+	UnregisterDriver("a")
+	if names := RegisteredDrivers(); !reflect.DeepEqual(names, []string{"b"}) {
+		t.Errorf("expected drivers [b] after unregistering a, but got %v", names)
+	}
+}
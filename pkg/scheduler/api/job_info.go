@@ -36,6 +36,7 @@ import (
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/capabilities/dynamicresources"
 	volumescheduling "volcano.sh/volcano/pkg/scheduler/capabilities/volumebinding"
 )
 
@@ -136,12 +137,17 @@ type TaskInfo struct {
 
 	NumaInfo   *TopologyInfo
 	PodVolumes *volumescheduling.PodVolumes
+	PodClaims  *dynamicresources.PodClaims
 	Pod        *v1.Pod
 
 	// CustomBindErrHandler is a custom callback func called when task bind err.
 	CustomBindErrHandler func() error `json:"-"`
 	// CustomBindErrHandlerSucceeded indicates whether CustomBindErrHandler is executed successfully.
 	CustomBindErrHandlerSucceeded bool
+
+	// TraceParent is the W3C traceparent of the span the task is allocated in, the cache traces the bind
+	// of the task as its child.
+	TraceParent string `json:"-"`
 }
 
 func getJobID(pod *v1.Pod) JobID {
@@ -279,6 +285,7 @@ func (ti *TaskInfo) Clone() *TaskInfo {
 		TaskRole:                    ti.TaskRole,
 		Priority:                    ti.Priority,
 		PodVolumes:                  ti.PodVolumes,
+		PodClaims:                   ti.PodClaims,
 		Pod:                         ti.Pod,
 		Resreq:                      ti.Resreq.Clone(),
 		InitResreq:                  ti.InitResreq.Clone(),
@@ -334,6 +341,9 @@ type JobInfo struct {
 	UID   JobID
 	PgUID types.UID
 
+	// Generation is increased whenever the tasks of the job change, the clones of the job start with it.
+	Generation uint64
+
 	Name      string
 	Namespace string
 
@@ -583,6 +593,56 @@ func (ji *JobInfo) GetElasticResources() *Resource {
 	return elastic
 }
 
+// ElasticTasks returns the allocated tasks above the minAvailable of the job, which can be evicted without
+// breaking the gang: the lowest priority and most recently created ones, the minimal member of every
+// task role being kept.
+func (ji *JobInfo) ElasticTasks() []*TaskInfo {
+	elastic := ji.ReadyTaskNum() - ji.MinAvailable
+	if elastic <= 0 {
+		return nil
+	}
+
+	var allocated []*TaskInfo
+	roles := map[string]int32{}
+	for status, tasks := range ji.TaskStatusIndex {
+		if !AllocatedStatus(status) {
+			continue
+		}
+		for _, task := range tasks {
+			allocated = append(allocated, task)
+			roles[task.TaskRole]++
+		}
+	}
+	sort.Slice(allocated, func(i, j int) bool {
+		return elasticBefore(allocated[i], allocated[j])
+	})
+
+	var tasks []*TaskInfo
+	for _, task := range allocated {
+		if int32(len(tasks)) >= elastic {
+			break
+		}
+		if minMember, found := ji.TaskMinAvailable[task.TaskRole]; found && roles[task.TaskRole] <= minMember {
+			continue
+		}
+		roles[task.TaskRole]--
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// elasticBefore returns whether the task l is more elastic than the task r, i.e. it has a lower priority or,
+// with the same priority, it was created more recently.
+func elasticBefore(l, r *TaskInfo) bool {
+	if l.Priority != r.Priority {
+		return l.Priority < r.Priority
+	}
+	if l.Pod != nil && r.Pod != nil && !l.Pod.CreationTimestamp.Equal(&r.Pod.CreationTimestamp) {
+		return r.Pod.CreationTimestamp.Before(&l.Pod.CreationTimestamp)
+	}
+	return l.UID > r.UID
+}
+
 func (ji *JobInfo) addTaskIndex(ti *TaskInfo) {
 	if _, found := ji.TaskStatusIndex[ti.Status]; !found {
 		ji.TaskStatusIndex[ti.Status] = tasksMap{}
@@ -592,6 +652,7 @@ func (ji *JobInfo) addTaskIndex(ti *TaskInfo) {
 
 // AddTaskInfo is used to add a task to a job
 func (ji *JobInfo) AddTaskInfo(ti *TaskInfo) {
+	ji.Generation++
 	ji.Tasks[ti.UID] = ti
 	ji.addTaskIndex(ti)
 	ji.TotalRequest.Add(ti.Resreq)
@@ -634,6 +695,7 @@ func (ji *JobInfo) deleteTaskIndex(ti *TaskInfo) {
 // DeleteTaskInfo is used to delete a task from a job
 func (ji *JobInfo) DeleteTaskInfo(ti *TaskInfo) error {
 	if task, found := ji.Tasks[ti.UID]; found {
+		ji.Generation++
 		ji.TotalRequest.Sub(task.Resreq)
 		if AllocatedStatus(task.Status) {
 			ji.Allocated.Sub(task.Resreq)
@@ -649,6 +711,29 @@ func (ji *JobInfo) DeleteTaskInfo(ti *TaskInfo) error {
 
 // Clone is used to clone a jobInfo object
 func (ji *JobInfo) Clone() *JobInfo {
+	info := ji.cloneWithoutTasks()
+	for _, task := range ji.Tasks {
+		info.AddTaskInfo(task.Clone())
+	}
+	info.Generation = ji.Generation
+
+	return info
+}
+
+// CloneWithTasksOf clones the jobInfo object like Clone, but takes over the tasks of prev, a clone of the job
+// whose tasks are unchanged on both sides, so the tasks are not cloned again. prev must not be used afterwards.
+func (ji *JobInfo) CloneWithTasksOf(prev *JobInfo) *JobInfo {
+	info := ji.cloneWithoutTasks()
+	info.Tasks = prev.Tasks
+	info.TaskStatusIndex = prev.TaskStatusIndex
+	info.Allocated = prev.Allocated
+	info.TotalRequest = prev.TotalRequest
+	info.Generation = ji.Generation
+
+	return info
+}
+
+func (ji *JobInfo) cloneWithoutTasks() *JobInfo {
 	info := &JobInfo{
 		UID:       ji.UID,
 		PgUID:     ji.PgUID,
@@ -680,9 +765,6 @@ func (ji *JobInfo) Clone() *JobInfo {
 	for task, minAvailable := range ji.TaskMinAvailable {
 		info.TaskMinAvailable[task] = minAvailable
 	}
-	for _, task := range ji.Tasks {
-		info.AddTaskInfo(task.Clone())
-	}
 
 	return info
 }
@@ -1041,6 +1123,40 @@ func (ji *JobInfo) IsStarving() bool {
 	return ji.WaitingTaskNum()+ji.ReadyTaskNum() < ji.MinAvailable
 }
 
+// ExpectedRuntime returns the expected runtime of the job declared on its PodGroup, and whether it is declared
+func (ji *JobInfo) ExpectedRuntime() (time.Duration, bool) {
+	if ji.PodGroup == nil {
+		return 0, false
+	}
+	value, found := ji.PodGroup.Annotations[JobExpectedRuntime]
+	if !found {
+		return 0, false
+	}
+	runtime, err := time.ParseDuration(value)
+	if err != nil || runtime <= 0 {
+		klog.V(4).Infof("Invalid expected runtime %q of job <%s/%s>: %v", value, ji.Namespace, ji.Name, err)
+		return 0, false
+	}
+	return runtime, true
+}
+
+// Deadline returns the time the job must complete by declared on its PodGroup, and whether it is declared
+func (ji *JobInfo) Deadline() (time.Time, bool) {
+	if ji.PodGroup == nil {
+		return time.Time{}, false
+	}
+	value, found := ji.PodGroup.Annotations[JobDeadline]
+	if !found {
+		return time.Time{}, false
+	}
+	deadline, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		klog.V(4).Infof("Invalid deadline %q of job <%s/%s>: %v", value, ji.Namespace, ji.Name, err)
+		return time.Time{}, false
+	}
+	return deadline, true
+}
+
 // IsPending returns whether job is in pending status
 func (ji *JobInfo) IsPending() bool {
 	return ji.PodGroup == nil ||
@@ -18,6 +18,7 @@ package api
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
@@ -26,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/types"
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	"volcano.sh/apis/pkg/apis/scheduling"
 	schedulingv2 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
@@ -61,6 +63,7 @@ func TestAddTaskInfo(t *testing.T) {
 			pods: []*v1.Pod{case01Pod1, case01Pod2, case01Pod3, case01Pod4},
 			expected: &JobInfo{
 				UID:          case01UID,
+				Generation:   4,
 				Allocated:    buildResource("4000m", "4G", map[string]string{"pods": "3"}, 0),
 				TotalRequest: buildResource("5000m", "5G", map[string]string{"pods": "4"}, 0),
 				Tasks: tasksMap{
@@ -140,6 +143,7 @@ func TestDeleteTaskInfo(t *testing.T) {
 				Allocated:    buildResource("3000m", "3G", map[string]string{"pods": "1"}, 0),
 				TotalRequest: buildResource("4000m", "4G", map[string]string{"pods": "2"}, 0),
 				UID:          case01UID,
+				Generation:   4,
 				Tasks: tasksMap{
 					case01Task1.UID: case01Task1,
 					case01Task3.UID: case01Task3,
@@ -162,6 +166,7 @@ func TestDeleteTaskInfo(t *testing.T) {
 				Allocated:    buildResource("3000m", "3G", map[string]string{"pods": "1"}, 0),
 				TotalRequest: buildResource("4000m", "4G", map[string]string{"pods": "2"}, 0),
 				UID:          case02UID,
+				Generation:   4,
 				Tasks: tasksMap{
 					case02Task1.UID: case02Task1,
 					case02Task3.UID: case02Task3,
@@ -440,3 +445,66 @@ func TestGetElasticResources(t *testing.T) {
 		}
 	}
 }
+
+func TestElasticTasks(t *testing.T) {
+	now := metav1.Now()
+	buildTask := func(name, role string, priority int32, age time.Duration) *TaskInfo {
+		pod := buildPod("ns1", name, "node1", v1.PodRunning, BuildResourceList("1", "1G"), nil, map[string]string{v1alpha1.TaskSpecKey: role})
+		pod.Spec.Priority = &priority
+		pod.CreationTimestamp = metav1.NewTime(now.Add(-age))
+		return NewTaskInfo(pod)
+	}
+	tests := []struct {
+		name          string
+		tasks         []*TaskInfo
+		minMember     int32
+		minTaskMember map[string]int32
+		want          []string
+	}{
+		{
+			name: "no elastic task at minAvailable",
+			tasks: []*TaskInfo{
+				buildTask("worker-1", "worker", 0, 3*time.Minute),
+				buildTask("worker-2", "worker", 0, 2*time.Minute),
+			},
+			minMember: 2,
+		},
+		{
+			name: "the lowest priority and most recent tasks are elastic",
+			tasks: []*TaskInfo{
+				buildTask("worker-1", "worker", 1, 4*time.Minute),
+				buildTask("worker-2", "worker", 0, 3*time.Minute),
+				buildTask("worker-3", "worker", 1, 2*time.Minute),
+				buildTask("worker-4", "worker", 1, time.Minute),
+			},
+			minMember: 2,
+			want:      []string{"worker-2", "worker-4"},
+		},
+		{
+			name: "the minimal member of every task role is kept",
+			tasks: []*TaskInfo{
+				buildTask("ps-1", "ps", 0, 4*time.Minute),
+				buildTask("worker-1", "worker", 0, 3*time.Minute),
+				buildTask("worker-2", "worker", 0, 2*time.Minute),
+				buildTask("ps-2", "ps", 0, time.Minute),
+			},
+			minMember:     2,
+			minTaskMember: map[string]int32{"ps": 2, "worker": 1},
+			want:          []string{"worker-2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			job := NewJobInfo("job", test.tasks...)
+			pg := BuildPodgroup("pg1", "ns1", test.minMember, nil)
+			pg.Spec.MinTaskMember = test.minTaskMember
+			job.SetPodGroup(&PodGroup{PodGroup: pg})
+			var got []string
+			for _, task := range job.ElasticTasks() {
+				got = append(got, task.Name)
+			}
+			assert.ElementsMatch(t, test.want, got)
+		})
+	}
+}
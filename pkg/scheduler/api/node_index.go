@@ -0,0 +1,402 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	v1helper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/nodeaffinity"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/tainttoleration"
+)
+
+// NodeIndexFilter selects the indexes the candidate nodes of a task are pruned with.
+type NodeIndexFilter uint8
+
+const (
+	// FutureIdleFilter prunes the nodes whose future idle CPU or memory is less than the request of the task.
+	FutureIdleFilter NodeIndexFilter = 1 << iota
+	// NodeSelectorFilter prunes the nodes not matching the node selector or the required node affinity of the pod.
+	NodeSelectorFilter
+	// TaintFilter prunes the nodes with NoSchedule or NoExecute taints the pod does not tolerate.
+	TaintFilter
+)
+
+// NodeIndex indexes the nodes of a session by label, taint and future idle resource, so that the nodes
+// a task can not fit on for trivial reasons are pruned before the predicates run on the others.
+// The labels and taints do not change within a session, the future idle resource of a node is updated
+// with UpdateNode whenever a task is added to or removed from the node. It is not safe for concurrent use.
+type NodeIndex struct {
+	// nodes holds the indexed nodes in the order of the node list.
+	nodes    []*NodeInfo
+	position map[string]int
+
+	// labels maps the label key and value to the positions of the nodes with the label.
+	labels map[string]map[string][]int
+	// taints maps the NoSchedule and NoExecute taints to the positions of the nodes with the taint.
+	taints map[taintKey][]int
+	// idleCPU and idleMemory sort the nodes by future idle milli CPU and memory.
+	idleCPU    *idleIndex
+	idleMemory *idleIndex
+}
+
+type taintKey struct {
+	key    string
+	value  string
+	effect v1.TaintEffect
+}
+
+// NewNodeIndex indexes the nodes, candidates are returned in the order of the nodes.
+func NewNodeIndex(nodes []*NodeInfo) *NodeIndex {
+	ni := &NodeIndex{
+		nodes:      nodes,
+		position:   make(map[string]int, len(nodes)),
+		labels:     map[string]map[string][]int{},
+		taints:     map[taintKey][]int{},
+		idleCPU:    newIdleIndex(len(nodes)),
+		idleMemory: newIdleIndex(len(nodes)),
+	}
+
+	for pos, node := range nodes {
+		ni.position[node.Name] = pos
+		futureIdle := node.FutureIdle()
+		ni.idleCPU.values[pos] = futureIdle.MilliCPU
+		ni.idleMemory.values[pos] = futureIdle.Memory
+		if node.Node == nil {
+			continue
+		}
+		for key, value := range node.Node.Labels {
+			values, found := ni.labels[key]
+			if !found {
+				values = map[string][]int{}
+				ni.labels[key] = values
+			}
+			values[value] = append(values[value], pos)
+		}
+		for _, taint := range node.Node.Spec.Taints {
+			if !doNotScheduleTaint(&taint) {
+				continue
+			}
+			key := taintKey{key: taint.Key, value: taint.Value, effect: taint.Effect}
+			ni.taints[key] = append(ni.taints[key], pos)
+		}
+	}
+	ni.idleCPU.sort()
+	ni.idleMemory.sort()
+
+	return ni
+}
+
+// Len returns the number of indexed nodes.
+func (ni *NodeIndex) Len() int {
+	if ni == nil {
+		return 0
+	}
+	return len(ni.nodes)
+}
+
+// UpdateNode updates the future idle resource of the node in the index.
+func (ni *NodeIndex) UpdateNode(node *NodeInfo) {
+	if ni == nil {
+		return
+	}
+	pos, found := ni.position[node.Name]
+	if !found {
+		return
+	}
+	futureIdle := node.FutureIdle()
+	ni.idleCPU.update(pos, futureIdle.MilliCPU)
+	ni.idleMemory.update(pos, futureIdle.Memory)
+}
+
+// Candidates returns the nodes the task may fit on according to the filters, in the order of the index.
+func (ni *NodeIndex) Candidates(task *TaskInfo, filters NodeIndexFilter) []*NodeInfo {
+	if ni == nil {
+		return nil
+	}
+	idle, selector, taint := ni.filter(task, filters)
+	matched := idle.and(selector).and(taint)
+	if matched == nil {
+		return ni.nodes
+	}
+
+	candidates := make([]*NodeInfo, 0, matched.count())
+	for i, word := range matched {
+		for word != 0 {
+			candidates = append(candidates, ni.nodes[i*64+bits.TrailingZeros64(word)])
+			word &= word - 1
+		}
+	}
+	return candidates
+}
+
+// SetPrunedNodeErrors sets the fit errors of the nodes pruned by the filters in fe, with the reason
+// the corresponding predicate would report. It is only called when the reasons are needed, as building
+// them costs much more than pruning the nodes.
+func (ni *NodeIndex) SetPrunedNodeErrors(task *TaskInfo, filters NodeIndexFilter, fe *FitErrors) {
+	if ni == nil {
+		return
+	}
+	// The filters are checked in the order the predicates of allocate check them.
+	idle, selector, taint := ni.filter(task, filters)
+	for pos, node := range ni.nodes {
+		switch {
+		case idle != nil && !idle.has(pos):
+			_, resources := task.InitResreq.LessEqualWithResourcesName(node.FutureIdle(), Zero)
+			fe.SetNodeError(node.Name, NewFitErrWithStatus(task, node, &Status{
+				Code:   Unschedulable,
+				Reason: WrapInsufficientResourceReason(resources),
+			}))
+		case selector != nil && !selector.has(pos):
+			fe.SetNodeError(node.Name, NewFitErrWithStatus(task, node, &Status{
+				Code:   UnschedulableAndUnresolvable,
+				Reason: nodeaffinity.ErrReasonPod,
+				Plugin: nodeaffinity.Name,
+			}))
+		case taint != nil && !taint.has(pos):
+			untolerated, _ := v1helper.FindMatchingUntoleratedTaint(node.Node.Spec.Taints, task.Pod.Spec.Tolerations, doNotScheduleTaint)
+			fe.SetNodeError(node.Name, NewFitErrWithStatus(task, node, &Status{
+				Code:   UnschedulableAndUnresolvable,
+				Reason: fmt.Sprintf("node(s) had untolerated taint {%s: %s}", untolerated.Key, untolerated.Value),
+				Plugin: tainttoleration.Name,
+			}))
+		}
+	}
+}
+
+// filter returns the nodes matching each of the filters, nil if a filter does not apply.
+func (ni *NodeIndex) filter(task *TaskInfo, filters NodeIndexFilter) (idle, selector, taint nodeBitmap) {
+	if filters&FutureIdleFilter != 0 {
+		idle = ni.futureIdleCandidates(task.InitResreq)
+	}
+	if filters&NodeSelectorFilter != 0 && task.Pod != nil {
+		selector = ni.nodeSelectorCandidates(task.Pod)
+	}
+	if filters&TaintFilter != 0 && task.Pod != nil {
+		taint = ni.taintCandidates(task.Pod)
+	}
+	return idle, selector, taint
+}
+
+// futureIdleCandidates returns the nodes whose future idle CPU and memory are not less than the request,
+// with the same tolerance as Resource.LessEqual.
+func (ni *NodeIndex) futureIdleCandidates(req *Resource) nodeBitmap {
+	if req == nil {
+		return nil
+	}
+	var cpu, memory nodeBitmap
+	if req.MilliCPU >= minResource {
+		cpu = ni.idleCPU.greaterThan(req.MilliCPU-minResource, len(ni.nodes))
+	}
+	if req.Memory >= minResource {
+		memory = ni.idleMemory.greaterThan(req.Memory-minResource, len(ni.nodes))
+	}
+	return cpu.and(memory)
+}
+
+// nodeSelectorCandidates returns the nodes matching the node selector and the required node affinity of the pod.
+// The node affinity prunes nodes only if all its terms use the In and Exists operators on labels.
+func (ni *NodeIndex) nodeSelectorCandidates(pod *v1.Pod) nodeBitmap {
+	var res nodeBitmap
+	for key, value := range pod.Spec.NodeSelector {
+		res = res.and(ni.nodesWithLabel(key, value))
+	}
+
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return res
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return res
+	}
+	// The terms are ORed, the expressions of a term are ANDed.
+	matched := newNodeBitmap(len(ni.nodes))
+	for _, term := range terms {
+		if len(term.MatchFields) != 0 || len(term.MatchExpressions) == 0 {
+			return res
+		}
+		var termMatched nodeBitmap
+		for _, expr := range term.MatchExpressions {
+			exprMatched := newNodeBitmap(len(ni.nodes))
+			switch expr.Operator {
+			case v1.NodeSelectorOpIn:
+				for _, value := range expr.Values {
+					exprMatched.or(ni.nodesWithLabel(expr.Key, value))
+				}
+			case v1.NodeSelectorOpExists:
+				for value := range ni.labels[expr.Key] {
+					exprMatched.or(ni.nodesWithLabel(expr.Key, value))
+				}
+			default:
+				return res
+			}
+			termMatched = termMatched.and(exprMatched)
+		}
+		matched.or(termMatched)
+	}
+	return res.and(matched)
+}
+
+// taintCandidates returns the nodes without NoSchedule and NoExecute taints the pod does not tolerate.
+func (ni *NodeIndex) taintCandidates(pod *v1.Pod) nodeBitmap {
+	var untolerated nodeBitmap
+	for key, positions := range ni.taints {
+		taint := &v1.Taint{Key: key.key, Value: key.value, Effect: key.effect}
+		if v1helper.TolerationsTolerateTaint(pod.Spec.Tolerations, taint) {
+			continue
+		}
+		if untolerated == nil {
+			untolerated = newNodeBitmap(len(ni.nodes))
+		}
+		for _, pos := range positions {
+			untolerated.set(pos)
+		}
+	}
+	if untolerated == nil {
+		return nil
+	}
+	return untolerated.not(len(ni.nodes))
+}
+
+func (ni *NodeIndex) nodesWithLabel(key, value string) nodeBitmap {
+	res := newNodeBitmap(len(ni.nodes))
+	for _, pos := range ni.labels[key][value] {
+		res.set(pos)
+	}
+	return res
+}
+
+func doNotScheduleTaint(taint *v1.Taint) bool {
+	return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
+}
+
+// idleIndex sorts the nodes by an idle resource value.
+type idleIndex struct {
+	// values holds the value of every node by position.
+	values []float64
+	// sorted holds the positions of the nodes sorted by value and position.
+	sorted []int
+}
+
+func newIdleIndex(n int) *idleIndex {
+	return &idleIndex{values: make([]float64, n), sorted: make([]int, n)}
+}
+
+func (ii *idleIndex) less(a, b int) bool {
+	if ii.values[a] != ii.values[b] {
+		return ii.values[a] < ii.values[b]
+	}
+	return a < b
+}
+
+func (ii *idleIndex) sort() {
+	for pos := range ii.sorted {
+		ii.sorted[pos] = pos
+	}
+	sort.Slice(ii.sorted, func(i, j int) bool { return ii.less(ii.sorted[i], ii.sorted[j]) })
+}
+
+// search returns the index in sorted of the first node not less than (value, pos).
+func (ii *idleIndex) search(value float64, pos int) int {
+	return sort.Search(len(ii.sorted), func(i int) bool {
+		other := ii.sorted[i]
+		if ii.values[other] != value {
+			return ii.values[other] > value
+		}
+		return other >= pos
+	})
+}
+
+func (ii *idleIndex) update(pos int, value float64) {
+	if ii.values[pos] == value {
+		return
+	}
+	from := ii.search(ii.values[pos], pos)
+	ii.sorted = append(ii.sorted[:from], ii.sorted[from+1:]...)
+	ii.values[pos] = value
+	to := ii.search(value, pos)
+	ii.sorted = append(ii.sorted, 0)
+	copy(ii.sorted[to+1:], ii.sorted[to:])
+	ii.sorted[to] = pos
+}
+
+// greaterThan returns the nodes whose value is greater than min.
+func (ii *idleIndex) greaterThan(min float64, n int) nodeBitmap {
+	res := newNodeBitmap(n)
+	from := sort.Search(len(ii.sorted), func(i int) bool { return ii.values[ii.sorted[i]] > min })
+	for _, pos := range ii.sorted[from:] {
+		res.set(pos)
+	}
+	return res
+}
+
+// nodeBitmap is a set of node positions, nil stands for all nodes.
+type nodeBitmap []uint64
+
+func newNodeBitmap(n int) nodeBitmap {
+	return make(nodeBitmap, (n+63)/64)
+}
+
+func (b nodeBitmap) set(pos int) {
+	b[pos/64] |= 1 << (pos % 64)
+}
+
+func (b nodeBitmap) has(pos int) bool {
+	return b[pos/64]&(1<<(pos%64)) != 0
+}
+
+func (b nodeBitmap) or(o nodeBitmap) {
+	for i := range b {
+		b[i] |= o[i]
+	}
+}
+
+func (b nodeBitmap) count() int {
+	n := 0
+	for _, word := range b {
+		n += bits.OnesCount64(word)
+	}
+	return n
+}
+
+// and returns the intersection, either side being nil stands for all nodes.
+func (b nodeBitmap) and(o nodeBitmap) nodeBitmap {
+	if b == nil {
+		return o
+	}
+	if o == nil {
+		return b
+	}
+	for i := range b {
+		b[i] &= o[i]
+	}
+	return b
+}
+
+func (b nodeBitmap) not(n int) nodeBitmap {
+	for i := range b {
+		b[i] = ^b[i]
+	}
+	if rem := n % 64; rem != 0 {
+		b[len(b)-1] &= (1 << rem) - 1
+	}
+	return b
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v1helper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+)
+
+func buildIndexedNode(name, cpu, memory string, labels map[string]string, taints ...v1.Taint) *NodeInfo {
+	node := buildNode(name, BuildResourceList(cpu, memory, ScalarResource{Name: "pods", Value: "110"}))
+	node.Labels = labels
+	node.Spec.Taints = taints
+	return NewNodeInfo(node)
+}
+
+func nodeNames(nodes []*NodeInfo) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func TestNodeIndexCandidates(t *testing.T) {
+	nodes := []*NodeInfo{
+		buildIndexedNode("n1", "2", "4Gi", map[string]string{"zone": "a", "gpu": "true"}),
+		buildIndexedNode("n2", "8", "16Gi", map[string]string{"zone": "b"}),
+		buildIndexedNode("n3", "8", "16Gi", map[string]string{"zone": "a"},
+			v1.Taint{Key: "dedicated", Value: "batch", Effect: v1.TaintEffectNoSchedule}),
+		buildIndexedNode("n4", "16", "2Gi", map[string]string{"zone": "c"},
+			v1.Taint{Key: "maintenance", Effect: v1.TaintEffectPreferNoSchedule}),
+	}
+	ni := NewNodeIndex(nodes)
+	assert.Equal(t, 4, ni.Len())
+
+	tests := []struct {
+		name       string
+		pod        *v1.Pod
+		filters    NodeIndexFilter
+		candidates []string
+		reasons    map[string]string
+	}{
+		{
+			name:       "no filter",
+			pod:        buildPod("c1", "p1", "", v1.PodPending, BuildResourceList("100", "100Gi"), nil, nil),
+			candidates: []string{"n1", "n2", "n3", "n4"},
+		},
+		{
+			name:       "future idle",
+			pod:        buildPod("c1", "p1", "", v1.PodPending, BuildResourceList("4", "4Gi"), nil, nil),
+			filters:    FutureIdleFilter,
+			candidates: []string{"n2", "n3"},
+			reasons: map[string]string{
+				"n1": "Insufficient cpu",
+				"n4": "Insufficient memory",
+			},
+		},
+		{
+			name: "node selector",
+			pod: func() *v1.Pod {
+				pod := buildPod("c1", "p1", "", v1.PodPending, BuildResourceList("1", "1Gi"), nil, nil)
+				pod.Spec.NodeSelector = map[string]string{"zone": "a"}
+				return pod
+			}(),
+			filters:    NodeSelectorFilter,
+			candidates: []string{"n1", "n3"},
+			reasons: map[string]string{
+				"n2": "node(s) didn't match Pod's node affinity/selector",
+				"n4": "node(s) didn't match Pod's node affinity/selector",
+			},
+		},
+		{
+			name: "required node affinity",
+			pod: func() *v1.Pod {
+				pod := buildPod("c1", "p1", "", v1.PodPending, BuildResourceList("1", "1Gi"), nil, nil)
+				pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "gpu", Operator: v1.NodeSelectorOpExists}}},
+						{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"c"}}}},
+					}},
+				}}
+				return pod
+			}(),
+			filters:    NodeSelectorFilter,
+			candidates: []string{"n1", "n4"},
+			reasons: map[string]string{
+				"n2": "node(s) didn't match Pod's node affinity/selector",
+				"n3": "node(s) didn't match Pod's node affinity/selector",
+			},
+		},
+		{
+			name: "unsupported node affinity operator is not pruned",
+			pod: func() *v1.Pod {
+				pod := buildPod("c1", "p1", "", v1.PodPending, BuildResourceList("1", "1Gi"), nil, nil)
+				pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpNotIn, Values: []string{"a"}}}},
+					}},
+				}}
+				return pod
+			}(),
+			filters:    NodeSelectorFilter,
+			candidates: []string{"n1", "n2", "n3", "n4"},
+		},
+		{
+			name:       "untolerated taint",
+			pod:        buildPod("c1", "p1", "", v1.PodPending, BuildResourceList("1", "1Gi"), nil, nil),
+			filters:    TaintFilter,
+			candidates: []string{"n1", "n2", "n4"},
+			reasons: map[string]string{
+				"n3": "node(s) had untolerated taint {dedicated: batch}",
+			},
+		},
+		{
+			name: "tolerated taint",
+			pod: func() *v1.Pod {
+				pod := buildPod("c1", "p1", "", v1.PodPending, BuildResourceList("1", "1Gi"), nil, nil)
+				pod.Spec.Tolerations = []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}}
+				return pod
+			}(),
+			filters:    TaintFilter,
+			candidates: []string{"n1", "n2", "n3", "n4"},
+		},
+		{
+			name: "all filters",
+			pod: func() *v1.Pod {
+				pod := buildPod("c1", "p1", "", v1.PodPending, BuildResourceList("4", "1Gi"), nil, nil)
+				pod.Spec.NodeSelector = map[string]string{"zone": "a"}
+				return pod
+			}(),
+			filters:    FutureIdleFilter | NodeSelectorFilter | TaintFilter,
+			candidates: []string{},
+			reasons: map[string]string{
+				"n1": "Insufficient cpu",
+				"n2": "node(s) didn't match Pod's node affinity/selector",
+				"n3": "node(s) had untolerated taint {dedicated: batch}",
+				"n4": "node(s) didn't match Pod's node affinity/selector",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			task := NewTaskInfo(test.pod)
+			candidates := ni.Candidates(task, test.filters)
+			assert.Equal(t, test.candidates, nodeNames(candidates))
+
+			fe := NewFitErrors()
+			ni.SetPrunedNodeErrors(task, test.filters, fe)
+			reasons := map[string]string{}
+			for name, err := range fe.Nodes() {
+				reasons[name] = err.Reasons()[0]
+			}
+			if test.reasons == nil {
+				test.reasons = map[string]string{}
+			}
+			assert.Equal(t, test.reasons, reasons)
+		})
+	}
+}
+
+func TestNodeIndexUpdateNode(t *testing.T) {
+	var nodes []*NodeInfo
+	for i := 0; i < 10; i++ {
+		nodes = append(nodes, buildIndexedNode(fmt.Sprintf("n%d", i), "4", "8Gi", nil))
+	}
+	ni := NewNodeIndex(nodes)
+	task := NewTaskInfo(buildPod("c1", "p1", "", v1.PodPending, BuildResourceList("3", "1Gi"), nil, nil))
+
+	// Fill every other node, the others still fit the task.
+	for i := 0; i < len(nodes); i += 2 {
+		running := NewTaskInfo(buildPod("c1", fmt.Sprintf("r%d", i), nodes[i].Name, v1.PodRunning, BuildResourceList("2", "1Gi"), nil, nil))
+		assert.NoError(t, nodes[i].AddTask(running))
+		ni.UpdateNode(nodes[i])
+	}
+	candidates := ni.Candidates(task, FutureIdleFilter)
+	assert.Equal(t, []string{"n1", "n3", "n5", "n7", "n9"}, nodeNames(candidates))
+	fe := NewFitErrors()
+	ni.SetPrunedNodeErrors(task, FutureIdleFilter, fe)
+	assert.Equal(t, 5, len(fe.Nodes()))
+	for _, ii := range []*idleIndex{ni.idleCPU, ni.idleMemory} {
+		assert.True(t, sort.SliceIsSorted(ii.sorted, func(i, j int) bool { return ii.less(ii.sorted[i], ii.sorted[j]) }))
+	}
+
+	// Releasing the resource makes the node a candidate again.
+	assert.NoError(t, nodes[4].RemoveTask(nodes[4].Tasks[PodKey(buildPod("c1", "r4", "", v1.PodRunning, nil, nil, nil))]))
+	ni.UpdateNode(nodes[4])
+	candidates = ni.Candidates(task, FutureIdleFilter)
+	assert.Equal(t, []string{"n1", "n3", "n4", "n5", "n7", "n9"}, nodeNames(candidates))
+
+	// Nodes out of the index are ignored.
+	ni.UpdateNode(buildIndexedNode("unknown", "1", "1Gi", nil))
+	var nilIndex *NodeIndex
+	nilIndex.UpdateNode(nodes[0])
+}
+
+// linearCandidates filters the nodes one by one with the checks the index replaces.
+func linearCandidates(nodes []*NodeInfo, task *TaskInfo) []*NodeInfo {
+	selector := nodeaffinity.GetRequiredNodeAffinity(task.Pod)
+	var candidates []*NodeInfo
+	for _, node := range nodes {
+		if !task.InitResreq.LessEqual(node.FutureIdle(), Zero) {
+			continue
+		}
+		if match, _ := selector.Match(node.Node); !match {
+			continue
+		}
+		if _, untolerated := v1helper.FindMatchingUntoleratedTaint(node.Node.Spec.Taints, task.Pod.Spec.Tolerations, doNotScheduleTaint); untolerated {
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+	return candidates
+}
+
+func buildBenchmarkNodes(n int) []*NodeInfo {
+	nodes := make([]*NodeInfo, 0, n)
+	for i := 0; i < n; i++ {
+		labels := map[string]string{
+			"zone":          fmt.Sprintf("zone-%d", i%10),
+			"instance-type": fmt.Sprintf("type-%d", i%4),
+		}
+		var taints []v1.Taint
+		if i%20 == 0 {
+			taints = append(taints, v1.Taint{Key: "dedicated", Value: "infra", Effect: v1.TaintEffectNoSchedule})
+		}
+		node := buildIndexedNode(fmt.Sprintf("node-%d", i), "32", "128Gi", labels, taints...)
+		// Vary the idle resource of the nodes.
+		used := NewTaskInfo(buildPod("bench", fmt.Sprintf("used-%d", i), node.Name, v1.PodRunning,
+			BuildResourceList(fmt.Sprintf("%d", i%32), fmt.Sprintf("%dGi", (i*7)%128)), nil, nil))
+		if err := node.AddTask(used); err != nil {
+			panic(err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func buildBenchmarkTask() *TaskInfo {
+	pod := buildPod("bench", "task", "", v1.PodPending, BuildResourceList("24", "64Gi"), nil, nil)
+	pod.Spec.NodeSelector = map[string]string{"zone": "zone-3"}
+	return NewTaskInfo(pod)
+}
+
+func TestNodeIndexMatchesLinearFilter(t *testing.T) {
+	nodes := buildBenchmarkNodes(1000)
+	task := buildBenchmarkTask()
+	candidates := NewNodeIndex(nodes).Candidates(task, FutureIdleFilter|NodeSelectorFilter|TaintFilter)
+	assert.Equal(t, nodeNames(linearCandidates(nodes, task)), nodeNames(candidates))
+}
+
+func BenchmarkNodeIndex(b *testing.B) {
+	for _, n := range []int{5000, 10000} {
+		nodes := buildBenchmarkNodes(n)
+		task := buildBenchmarkTask()
+		ni := NewNodeIndex(nodes)
+
+		b.Run(fmt.Sprintf("Build/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewNodeIndex(nodes)
+			}
+		})
+		b.Run(fmt.Sprintf("Candidates/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ni.Candidates(task, FutureIdleFilter|NodeSelectorFilter|TaintFilter)
+			}
+		})
+		b.Run(fmt.Sprintf("Linear/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				linearCandidates(nodes, task)
+			}
+		})
+		b.Run(fmt.Sprintf("UpdateNode/%d", n), func(b *testing.B) {
+			// Move the nodes in the sorted index as a changed future idle resource would.
+			for i := 0; i < b.N; i++ {
+				ni.idleCPU.update(i%n, float64(i%32000))
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package api
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"time"
 
@@ -27,8 +29,7 @@ import (
 
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
-	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/gpushare"
-	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/vgpu"
+	"volcano.sh/volcano/pkg/scheduler/api/devices"
 )
 
 type AllocateFailError struct {
@@ -49,6 +50,9 @@ type NodeInfo struct {
 	Name string
 	Node *v1.Node
 
+	// Generation is increased whenever the node or its tasks change, the clones of the node start with it.
+	Generation uint64
+
 	// The state of node
 	State NodeState
 
@@ -113,6 +117,59 @@ type NodeUsage struct {
 	MetricsTime time.Time
 	CPUUsageAvg map[string]float64
 	MEMUsageAvg map[string]float64
+	// ResourceUsageAvg is the average usage of the other resources reported by the metrics source,
+	// like network, disk IO or GPU utilization, by resource name and period.
+	ResourceUsageAvg map[string]map[string]float64
+	// UsageStats are the statistics of the usage samples collected over the metrics window by resource name,
+	// the names are "cpu", "memory" and the names of the other resources reported by the metrics source.
+	UsageStats map[string]*UsageStats
+}
+
+// UsageStats are the statistics of the usage samples of a resource in the metrics window.
+type UsageStats struct {
+	Avg float64
+	P95 float64
+	Max float64
+	// Trend is the change of the usage over the window fitted by least squares, it is positive if the usage goes up.
+	Trend float64
+}
+
+// NewUsageStats summarizes the usage samples taken at the given times, the times are in ascending order.
+func NewUsageStats(times []time.Time, values []float64) *UsageStats {
+	stats := &UsageStats{}
+	if len(values) == 0 {
+		return stats
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	stats.Avg = sum / float64(len(sorted))
+	stats.P95 = sorted[int(math.Ceil(0.95*float64(len(sorted))))-1]
+	stats.Max = sorted[len(sorted)-1]
+
+	span := times[len(times)-1].Sub(times[0])
+	if len(values) < 2 || span <= 0 {
+		return stats
+	}
+	var meanX, sxx, sxy float64
+	for _, t := range times {
+		meanX += t.Sub(times[0]).Seconds()
+	}
+	meanX /= float64(len(times))
+	for i, t := range times {
+		dx := t.Sub(times[0]).Seconds() - meanX
+		sxx += dx * dx
+		sxy += dx * (values[i] - stats.Avg)
+	}
+	if sxx > 0 {
+		stats.Trend = sxy / sxx * span.Seconds()
+	}
+	return stats
 }
 
 func (nu *NodeUsage) DeepCopy() *NodeUsage {
@@ -127,6 +184,22 @@ func (nu *NodeUsage) DeepCopy() *NodeUsage {
 	for k, v := range nu.MEMUsageAvg {
 		newUsage.MEMUsageAvg[k] = v
 	}
+	if nu.ResourceUsageAvg != nil {
+		newUsage.ResourceUsageAvg = make(map[string]map[string]float64, len(nu.ResourceUsageAvg))
+		for name, usage := range nu.ResourceUsageAvg {
+			newUsage.ResourceUsageAvg[name] = make(map[string]float64, len(usage))
+			for k, v := range usage {
+				newUsage.ResourceUsageAvg[name][k] = v
+			}
+		}
+	}
+	if nu.UsageStats != nil {
+		newUsage.UsageStats = make(map[string]*UsageStats, len(nu.UsageStats))
+		for name, stats := range nu.UsageStats {
+			s := *stats
+			newUsage.UsageStats[name] = &s
+		}
+	}
 	return newUsage
 }
 
@@ -168,9 +241,16 @@ func NewNodeInfo(node *v1.Node) *NodeInfo {
 // RefreshNumaSchedulerInfoByCrd used to update scheduler numa information based the CRD numatopo
 func (ni *NodeInfo) RefreshNumaSchedulerInfoByCrd() {
 	if ni.NumaInfo == nil {
-		ni.NumaSchedulerInfo = nil
+		if ni.NumaSchedulerInfo != nil {
+			ni.NumaSchedulerInfo = nil
+			ni.Generation++
+		}
 		return
 	}
+	if ni.NumaChgFlag == NumaInfoResetFlag {
+		return
+	}
+	ni.Generation++
 
 	tmp := ni.NumaInfo.DeepCopy()
 	if ni.NumaChgFlag == NumaInfoMoreFlag {
@@ -180,18 +260,34 @@ func (ni *NodeInfo) RefreshNumaSchedulerInfoByCrd() {
 		for resName, resInfo := range tmp.NumaResMap {
 			klog.V(5).Infof("resource %s Allocatable : current %v new %v on node %s",
 				resName, numaResMap[resName], resInfo, ni.Name)
+			if _, ok := numaResMap[resName]; !ok {
+				numaResMap[resName] = resInfo
+				continue
+			}
 			if numaResMap[resName].Allocatable.Size() >= resInfo.Allocatable.Size() {
 				numaResMap[resName].Allocatable = resInfo.Allocatable.Clone()
 				numaResMap[resName].Capacity = resInfo.Capacity
 			}
+			// the allocatable quantity of the resources allocated by quantity is taken as reported, their used
+			// quantity is the one of the tasks on the node
+			numaResMap[resName].AllocatablePerNuma = resInfo.AllocatablePerNuma
+			numaResMap[resName].UsedPerNuma = resInfo.UsedPerNuma
 		}
 	}
 
 	ni.NumaChgFlag = NumaInfoResetFlag
 }
 
-// Clone used to clone nodeInfo Object
+// Clone used to clone nodeInfo Object, the sharable devices are shared with the clone
 func (ni *NodeInfo) Clone() *NodeInfo {
+	res := ni.DeepClone()
+	res.Others = ni.CloneOthers()
+	return res
+}
+
+// DeepClone clones the nodeInfo Object like Clone, but the sharable devices of the clone
+// are rebuilt from the node and its tasks, so allocating devices on it leaves ni untouched.
+func (ni *NodeInfo) DeepClone() *NodeInfo {
 	res := NewNodeInfo(ni.Node)
 
 	for _, p := range ni.Tasks {
@@ -216,8 +312,8 @@ func (ni *NodeInfo) Clone() *NodeInfo {
 
 	klog.V(5).Infof("imageStates is %v", res.ImageStates)
 
-	res.Others = ni.CloneOthers()
 	res.ImageStates = ni.CloneImageSummary()
+	res.Generation = ni.Generation
 	return res
 }
 
@@ -308,6 +404,7 @@ func (ni *NodeInfo) setNodeState(node *v1.Node) {
 
 // SetNode sets kubernetes node object to nodeInfo object
 func (ni *NodeInfo) SetNode(node *v1.Node) {
+	ni.Generation++
 	ni.setNodeState(node)
 	if !ni.Ready() {
 		klog.Warningf("Failed to set node info for %s, phase: %s, reason: %s",
@@ -337,12 +434,16 @@ func (ni *NodeInfo) setNodeOthersResource(node *v1.Node) {
 		return
 	}
 
-	ni.Others[GPUSharingDevice] = gpushare.NewGPUDevices(ni.Name, node)
-	ni.Others[vgpu.DeviceName] = vgpu.NewGPUDevices(ni.Name, node)
-	IgnoredDevicesList.Set(
-		ni.Others[GPUSharingDevice].(Devices).GetIgnoredDevices(),
-		ni.Others[vgpu.DeviceName].(Devices).GetIgnoredDevices(),
-	)
+	var ignoredDevices [][]string
+	for _, name := range devices.RegisteredDrivers() {
+		// the devices are kept even if nil, the drivers return their nil devices as a typed nil
+		dev := devices.NewDevices(name, ni.Name, node)
+		ni.Others[name] = dev
+		if dev != nil {
+			ignoredDevices = append(ignoredDevices, dev.GetIgnoredDevices())
+		}
+	}
+	IgnoredDevicesList.Set(ignoredDevices...)
 }
 
 // setNode sets kubernetes node object to nodeInfo object without assertion
@@ -436,11 +537,15 @@ func (ni *NodeInfo) AddTask(task *TaskInfo) error {
 	if ni.NumaInfo != nil {
 		ni.NumaInfo.AddTask(ti)
 	}
+	if ni.NumaSchedulerInfo != nil {
+		ni.NumaSchedulerInfo.AddTask(ti)
+	}
 
 	// Update task node name upon successful task addition.
 	task.NodeName = ni.Name
 	ti.NodeName = ni.Name
 	ni.Tasks[key] = ti
+	ni.Generation++
 
 	return nil
 }
@@ -474,25 +579,36 @@ func (ni *NodeInfo) RemoveTask(ti *TaskInfo) error {
 		}
 	}
 
+	// the resource decision of the task as added to the node is removed, whatever ti records now
 	if ni.NumaInfo != nil {
-		ni.NumaInfo.RemoveTask(ti)
+		ni.NumaInfo.RemoveTask(task)
+	}
+	if ni.NumaSchedulerInfo != nil {
+		ni.NumaSchedulerInfo.RemoveTask(task)
 	}
 
 	delete(ni.Tasks, key)
+	ni.Generation++
 
 	return nil
 }
 
 // addResource is used to add sharable devices
 func (ni *NodeInfo) addResource(pod *v1.Pod) {
-	ni.Others[GPUSharingDevice].(Devices).AddResource(pod)
-	ni.Others[vgpu.DeviceName].(Devices).AddResource(pod)
+	for _, name := range devices.RegisteredDrivers() {
+		if dev, ok := ni.Others[name].(Devices); ok {
+			dev.AddResource(pod)
+		}
+	}
 }
 
 // subResource is used to subtract sharable devices
 func (ni *NodeInfo) subResource(pod *v1.Pod) {
-	ni.Others[GPUSharingDevice].(Devices).SubResource(pod)
-	ni.Others[vgpu.DeviceName].(Devices).SubResource(pod)
+	for _, name := range devices.RegisteredDrivers() {
+		if dev, ok := ni.Others[name].(Devices); ok {
+			dev.SubResource(pod)
+		}
+	}
 }
 
 // UpdateTask is used to update a task in nodeInfo object.
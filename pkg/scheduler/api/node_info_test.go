@@ -17,12 +17,16 @@
 package api
 
 import (
+	"math"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/utils/cpuset"
 
 	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/gpushare"
 	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/vgpu"
@@ -55,6 +59,7 @@ func TestNodeInfo_AddPod(t *testing.T) {
 			expected: &NodeInfo{
 				Name:                     "n1",
 				Node:                     case01Node,
+				Generation:               2,
 				Idle:                     buildResource("5000m", "7G", map[string]string{"pods": "18"}, 20),
 				Used:                     buildResource("3000m", "3G", map[string]string{"pods": "2"}, 0),
 				Releasing:                EmptyResource(),
@@ -82,6 +87,7 @@ func TestNodeInfo_AddPod(t *testing.T) {
 			expected: &NodeInfo{
 				Name:                     "n2",
 				Node:                     case02Node,
+				Generation:               1,
 				Idle:                     buildResource("1000m", "-1G", map[string]string{"pods": "19"}, 20),
 				Used:                     buildResource("1000m", "2G", map[string]string{"pods": "1"}, 0),
 				Releasing:                EmptyResource(),
@@ -146,6 +152,7 @@ func TestNodeInfo_RemovePod(t *testing.T) {
 			expected: &NodeInfo{
 				Name:                     "n1",
 				Node:                     case01Node,
+				Generation:               4,
 				Idle:                     buildResource("4000m", "6G", map[string]string{"pods": "8"}, 10),
 				Used:                     buildResource("4000m", "4G", map[string]string{"pods": "2"}, 0),
 				OversubscriptionResource: EmptyResource(),
@@ -212,6 +219,7 @@ func TestNodeInfo_SetNode(t *testing.T) {
 			expected: &NodeInfo{
 				Name:                     "n1",
 				Node:                     case01Node2,
+				Generation:               4,
 				Idle:                     buildResource("-1", "-1G", map[string]string{"pods": "7"}, 10),
 				Used:                     buildResource("9", "9G", map[string]string{"pods": "3"}, 0),
 				OversubscriptionResource: EmptyResource(),
@@ -235,6 +243,7 @@ func TestNodeInfo_SetNode(t *testing.T) {
 			expected2: &NodeInfo{
 				Name:                     "n1",
 				Node:                     case01Node1,
+				Generation:               5,
 				Idle:                     buildResource("1", "1G", map[string]string{"pods": "12"}, 15),
 				Used:                     buildResource("9", "9G", map[string]string{"pods": "3"}, 0),
 				OversubscriptionResource: EmptyResource(),
@@ -281,3 +290,110 @@ func TestNodeInfo_SetNode(t *testing.T) {
 		}
 	}
 }
+
+func TestNewUsageStats(t *testing.T) {
+	now := time.Now()
+	times := func(n int) []time.Time {
+		ts := make([]time.Time, n)
+		for i := range ts {
+			ts[i] = now.Add(time.Duration(i) * time.Minute)
+		}
+		return ts
+	}
+	tests := []struct {
+		name     string
+		times    []time.Time
+		values   []float64
+		expected UsageStats
+	}{
+		{
+			name:     "no samples",
+			expected: UsageStats{},
+		},
+		{
+			name:     "a single sample has no trend",
+			times:    times(1),
+			values:   []float64{40},
+			expected: UsageStats{Avg: 40, P95: 40, Max: 40},
+		},
+		{
+			name:     "usage going up linearly",
+			times:    times(5),
+			values:   []float64{10, 20, 30, 40, 50},
+			expected: UsageStats{Avg: 30, P95: 50, Max: 50, Trend: 40},
+		},
+		{
+			name:     "usage going down linearly",
+			times:    times(3),
+			values:   []float64{60, 40, 20},
+			expected: UsageStats{Avg: 40, P95: 60, Max: 60, Trend: -40},
+		},
+		{
+			name:     "the p95 of 20 samples skips the highest one",
+			times:    times(20),
+			values:   []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 100},
+			expected: UsageStats{Avg: 14.5, P95: 19, Max: 100, Trend: 40.7},
+		},
+	}
+
+	for _, test := range tests {
+		got := NewUsageStats(test.times, test.values)
+		if math.Abs(got.Avg-test.expected.Avg) > 1e-6 || got.P95 != test.expected.P95 ||
+			got.Max != test.expected.Max || math.Abs(got.Trend-test.expected.Trend) > 0.1 {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, *got)
+		}
+	}
+}
+
+func TestNodeInfo_NumaAmounts(t *testing.T) {
+	newNumaInfo := func(allocatable float64) *NumatopoInfo {
+		return &NumatopoInfo{
+			Name: "n1",
+			NumaResMap: map[string]*ResourceInfo{
+				string(v1.ResourceMemory): {
+					Allocatable:        cpuset.New(),
+					AllocatablePerNuma: map[int]float64{0: allocatable, 1: allocatable},
+					UsedPerNuma:        map[int]float64{},
+				},
+			},
+		}
+	}
+	used := func(info *NumatopoInfo) map[int]float64 {
+		return info.NumaResMap[string(v1.ResourceMemory)].UsedPerNuma
+	}
+
+	node := buildNode("n1", BuildResourceList("8000m", "16G"))
+	pod := buildPod("c1", "p1", "n1", v1.PodRunning, BuildResourceList("1000m", "2G"), []metav1.OwnerReference{}, make(map[string]string))
+	ni := NewNodeInfo(node)
+	ni.NumaInfo = newNumaInfo(8e9)
+	ni.NumaSchedulerInfo = newNumaInfo(8e9)
+
+	task := NewTaskInfo(pod)
+	task.NumaInfo.ResMap = ResNumaAmounts{string(v1.ResourceMemory): {1: 2e9}}.ResMap()
+	if err := task.SetPodResourceDecision(); err != nil {
+		t.Fatalf("failed to set the resource decision: %v", err)
+	}
+	if err := ni.AddTask(task); err != nil {
+		t.Fatalf("failed to add task: %v", err)
+	}
+	assert.Equal(t, map[int]float64{1: 2e9}, used(ni.NumaInfo))
+	assert.Equal(t, map[int]float64{1: 2e9}, used(ni.NumaSchedulerInfo))
+
+	// the used quantity of the scheduler info is the one of the tasks when less is reported
+	ni.NumaInfo = newNumaInfo(4e9)
+	ni.NumaInfo.AddTask(task)
+	ni.NumaSchedulerInfo.NumaResMap[string(v1.ResourceMemory)].UsedPerNuma[0] = 1e9
+	ni.NumaChgFlag = NumaInfoLessFlag
+	ni.RefreshNumaSchedulerInfoByCrd()
+	assert.Equal(t, map[int]float64{0: 4e9, 1: 4e9}, ni.NumaSchedulerInfo.NumaResMap[string(v1.ResourceMemory)].AllocatablePerNuma)
+	assert.Equal(t, map[int]float64{1: 2e9}, used(ni.NumaSchedulerInfo))
+
+	// the decision of the task as added is removed, whatever the task removed records
+	removed := NewTaskInfo(pod)
+	removed.NumaInfo.ResMap = ResNumaAmounts{string(v1.ResourceMemory): {0: 2e9}}.ResMap()
+	if err := ni.RemoveTask(removed); err != nil {
+		t.Fatalf("failed to remove task: %v", err)
+	}
+	assert.Equal(t, map[int]float64{1: 0}, used(ni.NumaInfo))
+	assert.Equal(t, map[int]float64{1: 0}, used(ni.NumaSchedulerInfo))
+}
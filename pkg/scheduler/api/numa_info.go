@@ -18,6 +18,9 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
@@ -38,8 +41,32 @@ const (
 	NumaInfoLessFlag NumaChgFlag = 0b10
 	// DefaultMaxNodeScore indicates the default max node score
 	DefaultMaxNodeScore = 100
+	// numaResKeySeparator separates the resource name and the numa ID in the numares key of a resource
+	// allocated by quantity, as "memory@0"
+	numaResKeySeparator = "@"
 )
 
+// NumaResKey returns the numares key of the resource allocated by quantity on the numa node in the Numatopology,
+// the resources allocated by quantity as the memory, hugepages and devices are reported by numa node with the
+// allocatable quantity, while the resources allocated by id as the cpus are reported with the allocatable ids.
+func NumaResKey(resName string, numaID int) string {
+	return fmt.Sprintf("%s%s%d", resName, numaResKeySeparator, numaID)
+}
+
+// ParseNumaResKey returns the resource name and the numa ID of the numares key, false if the key is not the key
+// of a resource allocated by quantity.
+func ParseNumaResKey(key string) (string, int, bool) {
+	idx := strings.LastIndex(key, numaResKeySeparator)
+	if idx <= 0 {
+		return "", 0, false
+	}
+	numaID, err := strconv.Atoi(key[idx+1:])
+	if err != nil || numaID < 0 {
+		return "", 0, false
+	}
+	return key[:idx], numaID, true
+}
+
 // PodResourceDecision is resource allocation determinated by scheduler,
 // and passed to kubelet through pod annotation.
 type PodResourceDecision struct {
@@ -170,7 +197,11 @@ func (info *NumatopoInfo) AddTask(ti *TaskInfo) {
 
 	for numaID, resList := range numaInfo {
 		for resName, quantity := range resList {
-			info.NumaResMap[string(resName)].UsedPerNuma[numaID] += ResQuantity2Float64(resName, quantity)
+			resInfo, ok := info.NumaResMap[string(resName)]
+			if !ok || resInfo.UsedPerNuma == nil {
+				continue
+			}
+			resInfo.UsedPerNuma[numaID] += ResQuantity2Float64(resName, quantity)
 		}
 	}
 }
@@ -182,9 +213,13 @@ func (info *NumatopoInfo) RemoveTask(ti *TaskInfo) {
 		return
 	}
 
-	for numaID, resList := range ti.NumaInfo.ResMap {
+	for numaID, resList := range decision {
 		for resName, quantity := range resList {
-			info.NumaResMap[string(resName)].UsedPerNuma[numaID] -= ResQuantity2Float64(resName, quantity)
+			resInfo, ok := info.NumaResMap[string(resName)]
+			if !ok || resInfo.UsedPerNuma == nil {
+				continue
+			}
+			resInfo.UsedPerNuma[numaID] -= ResQuantity2Float64(resName, quantity)
 		}
 	}
 }
@@ -223,6 +258,32 @@ func GenerateNumaNodes(nodes map[string]*NodeInfo) map[string][]int {
 	return nodeNumaMap
 }
 
+// GenerateNodeResNumaAmounts return the idle resource amounts of all node
+func GenerateNodeResNumaAmounts(nodes map[string]*NodeInfo) map[string]ResNumaAmounts {
+	nodeAmounts := make(map[string]ResNumaAmounts)
+	for _, node := range nodes {
+		if node.NumaSchedulerInfo == nil {
+			continue
+		}
+
+		resAmounts := make(ResNumaAmounts)
+		for resName, resInfo := range node.NumaSchedulerInfo.NumaResMap {
+			if len(resInfo.AllocatablePerNuma) == 0 {
+				continue
+			}
+			amounts := make(map[int]float64, len(resInfo.AllocatablePerNuma))
+			for numaID, allocatable := range resInfo.AllocatablePerNuma {
+				amounts[numaID] = allocatable - resInfo.UsedPerNuma[numaID]
+			}
+			resAmounts[resName] = amounts
+		}
+
+		nodeAmounts[node.Name] = resAmounts
+	}
+
+	return nodeAmounts
+}
+
 // ResNumaSets is the set map of the resource
 type ResNumaSets map[string]cpuset.CPUSet
 
@@ -256,6 +317,77 @@ func (resSets ResNumaSets) Clone() ResNumaSets {
 	return newSets
 }
 
+// ResNumaAmounts is the amount map of the resource allocated by quantity, key: resource name, numa ID
+type ResNumaAmounts map[string]map[int]float64
+
+// Allocate is to remove the allocated resource amounts which are assigned to task
+func (resAmounts ResNumaAmounts) Allocate(taskAmounts ResNumaAmounts) {
+	for resName, amounts := range taskAmounts {
+		if _, ok := resAmounts[resName]; !ok {
+			continue
+		}
+		for numaID, amount := range amounts {
+			resAmounts[resName][numaID] -= amount
+		}
+	}
+}
+
+// Release is to reclaim the allocated resource amounts which are assigned to task
+func (resAmounts ResNumaAmounts) Release(taskAmounts ResNumaAmounts) {
+	for resName, amounts := range taskAmounts {
+		if _, ok := resAmounts[resName]; !ok {
+			continue
+		}
+		for numaID, amount := range amounts {
+			resAmounts[resName][numaID] += amount
+		}
+	}
+}
+
+// Add is to add the resource amounts, as the amounts assigned to the containers of a task
+func (resAmounts ResNumaAmounts) Add(amounts ResNumaAmounts) {
+	for resName, numaAmounts := range amounts {
+		if _, ok := resAmounts[resName]; !ok {
+			resAmounts[resName] = make(map[int]float64)
+		}
+		for numaID, amount := range numaAmounts {
+			resAmounts[resName][numaID] += amount
+		}
+	}
+}
+
+// ResMap returns the resource list of per numa node of the resource amounts, as recorded in the resource
+// decision of the task
+func (resAmounts ResNumaAmounts) ResMap() map[int]v1.ResourceList {
+	resMap := make(map[int]v1.ResourceList)
+	for resName, amounts := range resAmounts {
+		for numaID, amount := range amounts {
+			if amount <= 0 {
+				continue
+			}
+			if _, ok := resMap[numaID]; !ok {
+				resMap[numaID] = make(v1.ResourceList)
+			}
+			resMap[numaID][v1.ResourceName(resName)] = ResFloat642Quantity(v1.ResourceName(resName), amount)
+		}
+	}
+
+	return resMap
+}
+
+// Clone is the copy action
+func (resAmounts ResNumaAmounts) Clone() ResNumaAmounts {
+	newAmounts := make(ResNumaAmounts)
+	for resName, amounts := range resAmounts {
+		newAmounts[resName] = make(map[int]float64, len(amounts))
+		for numaID, amount := range amounts {
+			newAmounts[resName][numaID] = amount
+		}
+	}
+
+	return newAmounts
+}
+
 // ScoredNode is the wrapper for node during Scoring.
 type ScoredNode struct {
 	NodeName string
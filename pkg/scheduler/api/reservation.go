@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"time"
+)
+
+// Reservation locks a set of nodes for a starving job. The other jobs may only use the locked
+// nodes if they are expected to finish before the reservation is expected to be satisfied.
+type Reservation struct {
+	// Job is the job the nodes are reserved for.
+	Job JobID
+	// Nodes are the names of the locked nodes.
+	Nodes map[string]bool
+	// Requests are the resources the job is planned to use on every locked node.
+	Requests map[string]*Resource
+	// CreationTime is when the nodes were locked.
+	CreationTime time.Time
+	// Deadline is when the reservation expires if it is not satisfied.
+	Deadline time.Time
+	// ShadowTime is when the locked nodes are expected to have released enough resources for
+	// the job, it is zero if unknown, e.g. a running task on the nodes has no expected runtime.
+	ShadowTime time.Time
+}
+
+// Locks returns whether the reservation keeps the job off the node at the given time.
+func (r *Reservation) Locks(job *JobInfo, nodeName string, now time.Time) bool {
+	if r == nil || !r.Nodes[nodeName] || job == nil || job.UID == r.Job {
+		return false
+	}
+	runtime, found := job.ExpectedRuntime()
+	if !found || r.ShadowTime.IsZero() {
+		return true
+	}
+	return now.Add(runtime).After(r.ShadowTime)
+}
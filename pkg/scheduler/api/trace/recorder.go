@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// Recorder keeps the decision traces of the last sessions in a ring buffer.
+type Recorder struct {
+	mutex  sync.RWMutex
+	traces []*SessionTrace
+	// next is the index the next trace is written to.
+	next int
+	full bool
+}
+
+// NewRecorder returns a Recorder keeping the traces of the last size sessions.
+func NewRecorder(size int) *Recorder {
+	if size < 1 {
+		size = 1
+	}
+	return &Recorder{traces: make([]*SessionTrace, size)}
+}
+
+// Add records the trace of a session, evicting the oldest trace if the buffer is full.
+func (r *Recorder) Add(st *SessionTrace) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.traces[r.next] = st
+	r.next = (r.next + 1) % len(r.traces)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Traces returns the recorded traces from the oldest to the latest.
+func (r *Recorder) Traces() []*SessionTrace {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if !r.full {
+		return append([]*SessionTrace{}, r.traces[:r.next]...)
+	}
+	traces := make([]*SessionTrace, 0, len(r.traces))
+	traces = append(traces, r.traces[r.next:]...)
+	return append(traces, r.traces[:r.next]...)
+}
+
+// JobTraces returns the recorded traces from the oldest to the latest,
+// keeping only the sessions in which the given job was present and only that job.
+func (r *Recorder) JobTraces(namespace, name string) []*SessionTrace {
+	var traces []*SessionTrace
+	for _, st := range r.Traces() {
+		job := st.Job(namespace, name)
+		if job == nil {
+			continue
+		}
+		traces = append(traces, &SessionTrace{
+			Session:   st.Session,
+			StartTime: st.StartTime,
+			EndTime:   st.EndTime,
+			Actions:   st.Actions,
+			Jobs:      []*JobTrace{job},
+		})
+	}
+	return traces
+}
+
+// ServeHTTP writes the recorded traces as a json list; the `namespace` and `name`
+// query parameters restrict the traces to the given job.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var traces []*SessionTrace
+	if name := req.URL.Query().Get("name"); name != "" {
+		namespace := req.URL.Query().Get("namespace")
+		if namespace == "" {
+			namespace = "default"
+		}
+		traces = r.JobTraces(namespace, name)
+	} else {
+		traces = r.Traces()
+	}
+	if traces == nil {
+		traces = []*SessionTrace{}
+	}
+
+	data, err := json.Marshal(traces)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
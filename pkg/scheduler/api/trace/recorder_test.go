@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sessionIDs(traces []*SessionTrace) []string {
+	ids := []string{}
+	for _, st := range traces {
+		ids = append(ids, st.Session)
+	}
+	return ids
+}
+
+func TestRecorder(t *testing.T) {
+	recorder := NewRecorder(3)
+	assert.Equal(t, []string{}, sessionIDs(recorder.Traces()))
+
+	for _, id := range []string{"s1", "s2"} {
+		recorder.Add(&SessionTrace{Session: id})
+	}
+	assert.Equal(t, []string{"s1", "s2"}, sessionIDs(recorder.Traces()))
+
+	recorder.Add(&SessionTrace{Session: "s3", Jobs: []*JobTrace{{Namespace: "ns1", Name: "pg1"}, {Namespace: "ns1", Name: "pg2"}}})
+	recorder.Add(&SessionTrace{Session: "s4", Jobs: []*JobTrace{{Namespace: "ns1", Name: "pg1"}}})
+	recorder.Add(&SessionTrace{Session: "s5"})
+	assert.Equal(t, []string{"s3", "s4", "s5"}, sessionIDs(recorder.Traces()))
+
+	jobTraces := recorder.JobTraces("ns1", "pg1")
+	assert.Equal(t, []string{"s3", "s4"}, sessionIDs(jobTraces))
+	for _, st := range jobTraces {
+		assert.Equal(t, 1, len(st.Jobs))
+		assert.Equal(t, "pg1", st.Jobs[0].Name)
+	}
+}
+
+func TestRecorderServeHTTP(t *testing.T) {
+	recorder := NewRecorder(2)
+	recorder.Add(&SessionTrace{Session: "s1", Jobs: []*JobTrace{{Namespace: "ns1", Name: "pg1"}}})
+	recorder.Add(&SessionTrace{Session: "s2", Jobs: []*JobTrace{{Namespace: "default", Name: "pg2"}}})
+
+	tests := []struct {
+		url      string
+		code     int
+		sessions []string
+	}{
+		{url: "/debug/decisions", code: http.StatusOK, sessions: []string{"s1", "s2"}},
+		{url: "/debug/decisions?namespace=ns1&name=pg1", code: http.StatusOK, sessions: []string{"s1"}},
+		{url: "/debug/decisions?name=pg2", code: http.StatusOK, sessions: []string{"s2"}},
+		{url: "/debug/decisions?namespace=ns1&name=pg3", code: http.StatusOK, sessions: []string{}},
+	}
+
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		recorder.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, test.url, nil))
+		assert.Equal(t, test.code, rec.Code, test.url)
+
+		var traces []*SessionTrace
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &traces), test.url)
+		assert.Equal(t, test.sessions, sessionIDs(traces), test.url)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trace holds the decision trace of scheduling sessions, it only depends on
+// apimachinery so that clients such as vcctl can decode the traces served by the scheduler.
+package trace
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Extension points of the session which can veto a job.
+const (
+	JobValid       = "JobValid"
+	JobEnqueueable = "JobEnqueueable"
+	Overused       = "Overused"
+	Allocatable    = "Allocatable"
+	Predicate      = "Predicate"
+)
+
+// MaxNodeErrors is the max number of nodes whose predicate failures are kept per task,
+// the failures of the other nodes are only counted in TaskTrace.Reasons.
+const MaxNodeErrors = 50
+
+// SessionTrace is the decision trace of one scheduling session.
+type SessionTrace struct {
+	Session   string      `json:"session"`
+	StartTime metav1.Time `json:"startTime"`
+	EndTime   metav1.Time `json:"endTime"`
+	// Actions are the actions executed in the session in order.
+	Actions []string    `json:"actions,omitempty"`
+	Jobs    []*JobTrace `json:"jobs,omitempty"`
+}
+
+// JobTrace records how a job was handled in a session.
+type JobTrace struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Queue     string `json:"queue"`
+	// Actions are the actions which visited the job in order.
+	Actions []string `json:"actions,omitempty"`
+	// Vetoes are the plugins which rejected the job or its queue.
+	Vetoes []*Veto `json:"vetoes,omitempty"`
+	// Tasks are the tasks which failed the predicates.
+	Tasks []*TaskTrace `json:"tasks,omitempty"`
+	// Message is the unschedulable message of the job at the end of the session,
+	// it is empty if the job has no pending task.
+	Message string `json:"message,omitempty"`
+}
+
+// Veto is a rejection of a job by a plugin.
+type Veto struct {
+	Action         string `json:"action"`
+	Plugin         string `json:"plugin"`
+	ExtensionPoint string `json:"extensionPoint"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// TaskTrace records the predicate failures of a task.
+type TaskTrace struct {
+	Name string `json:"name"`
+	// Reasons counts the nodes which failed with each reason.
+	Reasons map[string]int `json:"reasons,omitempty"`
+	// NodeErrors holds the failure reasons per node for at most MaxNodeErrors nodes.
+	NodeErrors map[string][]string `json:"nodeErrors,omitempty"`
+}
+
+// Job returns the trace of the given job, or nil if the job is not part of the session.
+func (st *SessionTrace) Job(namespace, name string) *JobTrace {
+	for _, job := range st.Jobs {
+		if job.Namespace == namespace && job.Name == name {
+			return job
+		}
+	}
+	return nil
+}
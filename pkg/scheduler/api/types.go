@@ -312,5 +312,13 @@ type ReservedNodesFn func()
 // VictimTasksFn is the func declaration used to select victim tasks
 type VictimTasksFn func([]*TaskInfo) []*TaskInfo
 
+// BindFn is the func declaration used to bind a task to its node in place of the scheduler,
+// it returns false if it does not bind the task.
+type BindFn func(*TaskInfo) (bool, error)
+
+// EquivalentFn is the func declaration used to check if the predicate and node order results of a plugin
+// for the task only depend on its pod template and the node, so that they are shared by the tasks of its equivalence class.
+type EquivalentFn func(*TaskInfo) bool
+
 // AllocatableFn is the func declaration used to check whether the task can be allocated
 type AllocatableFn func(*QueueInfo, *TaskInfo) bool
@@ -80,6 +80,11 @@ func (f *FitErrors) GetUnschedulableAndUnresolvableNodes() map[string]sets.Empty
 	return ret
 }
 
+// Nodes returns the fit error of every node the task failed to fit, keyed by node name.
+func (f *FitErrors) Nodes() map[string]*FitError {
+	return f.nodes
+}
+
 // Error returns the final error message
 func (f *FitErrors) Error() string {
 	if f.err == "" {
@@ -39,6 +39,11 @@ const (
 	OversubscriptionMemory = "volcano.sh/oversubscription-memory"
 	// OfflineJobEvicting node will not schedule pod due to offline job evicting
 	OfflineJobEvicting = "volcano.sh/offline-job-evicting"
+	// JobExpectedRuntime is the key of the expected runtime of a job on its PodGroup, like 30m, the jobs with it
+	// may be backfilled onto the nodes reserved for another job if they finish before the reservation is satisfied
+	JobExpectedRuntime = "volcano.sh/expected-runtime"
+	// JobDeadline is the key of the time a job must complete by on its PodGroup, in RFC3339 like 2024-06-01T18:00:00Z
+	JobDeadline = "volcano.sh/deadline"
 
 	// topologyDecisionAnnotation is the key of topology decision about pod request resource
 	topologyDecisionAnnotation = "volcano.sh/topology-decision"
@@ -38,6 +38,7 @@ import (
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/informers"
 	infov1 "k8s.io/client-go/informers/core/v1"
+	resourcev1alpha3 "k8s.io/client-go/informers/resource/v1alpha3"
 	schedv1 "k8s.io/client-go/informers/scheduling/v1"
 	storagev1 "k8s.io/client-go/informers/storage/v1"
 	storagev1beta1 "k8s.io/client-go/informers/storage/v1beta1"
@@ -50,6 +51,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+	k8sfeatures "k8s.io/kubernetes/pkg/features"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 	"stathat.com/c/consistent"
 
@@ -65,6 +67,7 @@ import (
 	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/features"
 	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/capabilities/dynamicresources"
 	volumescheduling "volcano.sh/volcano/pkg/scheduler/capabilities/volumebinding"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
 	"volcano.sh/volcano/pkg/scheduler/metrics/source"
@@ -74,6 +77,8 @@ import (
 const (
 	// default interval for sync data from metrics server, the value is 30s
 	defaultMetricsInternal = 30 * time.Second
+	// default window of the node usage samples summarized in the usage statistics, the value is 10m
+	defaultMetricsWindow = 10 * time.Minute
 )
 
 // defaultIgnoredProvisioners contains provisioners that will be ignored during pod pvc request computation and preemption.
@@ -104,6 +109,14 @@ type SchedulerCache struct {
 	schedulerNames     []string
 	nodeSelectorLabels map[string]sets.Empty
 	metricsConf        map[string]string
+	// nodeUsageSamples are the usage samples of the nodes collected in the latest metrics window, by node name.
+	nodeUsageSamples map[string][]*source.NodeMetrics
+	// scheduleTrigger is called on the events worth starting a scheduling cycle early, it may be nil.
+	scheduleTrigger func(reason string)
+
+	bindFnsMutex sync.RWMutex
+	// bindFns bind the tasks they handle in place of the Binder, they are set by the plugins of the last session.
+	bindFns []schedulingapi.BindFn
 
 	podInformer                infov1.PodInformer
 	nodeInformer               infov1.NodeInformer
@@ -118,12 +131,17 @@ type SchedulerCache struct {
 	csiDriverInformer          storagev1.CSIDriverInformer
 	csiStorageCapacityInformer storagev1beta1.CSIStorageCapacityInformer
 	cpuInformer                cpuinformerv1.NumatopologyInformer
+	resourceClaimInformer      resourcev1alpha3.ResourceClaimInformer
+	deviceClassInformer        resourcev1alpha3.DeviceClassInformer
+	resourceSliceInformer      resourcev1alpha3.ResourceSliceInformer
 
 	Binder         Binder
 	Evictor        Evictor
 	StatusUpdater  StatusUpdater
 	PodGroupBinder BatchBinder
 	VolumeBinder   VolumeBinder
+	// ClaimBinder allocates the resource claims of the pods, it is nil unless DynamicResourceAllocation is enabled
+	ClaimBinder ClaimBinder
 
 	Recorder record.EventRecorder
 
@@ -152,6 +170,12 @@ type SchedulerCache struct {
 	// A map from image name to its imageState.
 	imageStates map[string]*imageState
 
+	// snapshotNodes, snapshotJobs and snapshotNodeIndex hold the clones of the previous snapshot, a clone
+	// is shared with the next snapshot instead of cloned again while neither it nor its original changed.
+	snapshotNodes     map[string]snapshotNode
+	snapshotJobs      map[schedulingapi.JobID]snapshotJob
+	snapshotNodeIndex *schedulingapi.NodeIndex
+
 	nodeWorkers uint32
 
 	// IgnoredCSIProvisioners contains a list of provisioners, and pod request pvc with these provisioners will
@@ -169,6 +193,30 @@ type multiSchedulerInfo struct {
 	c                *consistent.Consistent
 }
 
+// snapshotNode is the clone of a node in a snapshot with the generation of the node it was cloned at.
+type snapshotNode struct {
+	source     *schedulingapi.NodeInfo
+	node       *schedulingapi.NodeInfo
+	generation uint64
+}
+
+// unchanged returns whether neither the clone nor the node changed since the clone.
+func (sn snapshotNode) unchanged(node *schedulingapi.NodeInfo) bool {
+	return sn.source == node && sn.node.Generation == sn.generation && node.Generation == sn.generation
+}
+
+// snapshotJob is the clone of a job in a snapshot with the generation of the job it was cloned at.
+type snapshotJob struct {
+	source     *schedulingapi.JobInfo
+	job        *schedulingapi.JobInfo
+	generation uint64
+}
+
+// unchanged returns whether neither the tasks of the clone nor the tasks of the job changed since the clone.
+func (sj snapshotJob) unchanged(job *schedulingapi.JobInfo) bool {
+	return sj.source == job && sj.job.Generation == sj.generation && job.Generation == sj.generation
+}
+
 type imageState struct {
 	// Size of the image
 	size int64
@@ -390,6 +438,34 @@ func (dvb *defaultVolumeBinder) BindVolumes(task *schedulingapi.TaskInfo, podVol
 	return dvb.volumeBinder.BindPodVolumes(context.TODO(), task.Pod, podVolumes)
 }
 
+type defaultClaimBinder struct {
+	claimBinder *dynamicresources.Binder
+}
+
+// GetPodClaims get the resource claims of the pod allocated on the host
+func (dcb *defaultClaimBinder) GetPodClaims(task *schedulingapi.TaskInfo, node *v1.Node) (*dynamicresources.PodClaims, error) {
+	return dcb.claimBinder.GetPodClaims(task.Pod, node)
+}
+
+// AllocateClaims assumes the resource claims allocated to the task in the claim cache
+func (dcb *defaultClaimBinder) AllocateClaims(task *schedulingapi.TaskInfo, podClaims *dynamicresources.PodClaims) error {
+	return dcb.claimBinder.AssumePodClaims(podClaims)
+}
+
+// RevertClaims clean cache generated by AllocateClaims
+func (dcb *defaultClaimBinder) RevertClaims(task *schedulingapi.TaskInfo, podClaims *dynamicresources.PodClaims) {
+	if podClaims != nil {
+		klog.Infof("Revert assumed resource claims for task %v/%v on node %s", task.Namespace, task.Name, task.NodeName)
+		dcb.claimBinder.RevertPodClaims(podClaims)
+		task.PodClaims = nil
+	}
+}
+
+// BindClaims writes the allocation of the resource claims of the task
+func (dcb *defaultClaimBinder) BindClaims(task *schedulingapi.TaskInfo, podClaims *dynamicresources.PodClaims) error {
+	return dcb.claimBinder.BindPodClaims(context.TODO(), task.Pod, podClaims)
+}
+
 type podgroupBinder struct {
 	kubeclient kubernetes.Interface
 	vcclient   vcclient.Interface
@@ -485,6 +561,21 @@ func (sc *SchedulerCache) setDefaultVolumeBinder() {
 	}
 }
 
+func (sc *SchedulerCache) setDefaultClaimBinder() {
+	if sc.resourceClaimInformer == nil {
+		return
+	}
+	sc.ClaimBinder = &defaultClaimBinder{
+		claimBinder: dynamicresources.NewBinder(
+			klog.FromContext(context.TODO()),
+			sc.kubeClient,
+			sc.resourceClaimInformer,
+			sc.deviceClassInformer,
+			sc.resourceSliceInformer,
+		),
+	}
+}
+
 // newDefaultAndRootQueue init default queue and root queue
 func newDefaultAndRootQueue(vcClient vcclient.Interface, defaultQueue string) {
 	reclaimable := false
@@ -627,6 +718,7 @@ func newSchedulerCache(config *rest.Config, schedulerNames []string, defaultQueu
 	sc.addEventHandler()
 	// finally, init default volume binder which has dependencies on other informers
 	sc.setDefaultVolumeBinder()
+	sc.setDefaultClaimBinder()
 	return sc
 }
 
@@ -646,11 +738,18 @@ func (sc *SchedulerCache) addEventHandler() {
 		informerFactory.Apps().V1().StatefulSets().Informer()
 	}
 
-	// `PodDisruptionBudgets` informer is used by `Pdb` plugin
+	// `PodDisruptionBudgets` informer is used by `Pdb` and `rescheduling` plugins
 	if utilfeature.DefaultFeatureGate.Enabled(features.PodDisruptionBudgetsSupport) {
 		informerFactory.Policy().V1().PodDisruptionBudgets().Informer()
 	}
 
+	// The resource claims are allocated with the devices of the resource slices matching the device classes.
+	if utilfeature.DefaultFeatureGate.Enabled(k8sfeatures.DynamicResourceAllocation) {
+		sc.resourceClaimInformer = informerFactory.Resource().V1alpha3().ResourceClaims()
+		sc.deviceClassInformer = informerFactory.Resource().V1alpha3().DeviceClasses()
+		sc.resourceSliceInformer = informerFactory.Resource().V1alpha3().ResourceSlices()
+	}
+
 	// create informer for node information
 	sc.nodeInformer = informerFactory.Core().V1().Nodes()
 	sc.nodeInformer.Informer().AddEventHandlerWithResyncPeriod(
@@ -911,7 +1010,12 @@ func (sc *SchedulerCache) Evict(taskInfo *schedulingapi.TaskInfo, reason string)
 // Bind binds task to the target host.
 func (sc *SchedulerCache) Bind(tasks []*schedulingapi.TaskInfo) {
 	tmp := time.Now()
-	errMsg := sc.Binder.Bind(sc.kubeClient, tasks)
+	spans := sc.startBindSpans(tasks)
+	remaining, errMsg := sc.delegateBinds(tasks)
+	for uid, msg := range sc.Binder.Bind(sc.kubeClient, remaining) {
+		errMsg[uid] = msg
+	}
+	endBindSpans(spans, errMsg)
 	if len(errMsg) == 0 {
 		klog.V(3).Infof("bind ok, latency %v", time.Since(tmp))
 	} else {
@@ -928,6 +1032,7 @@ func (sc *SchedulerCache) Bind(tasks []*schedulingapi.TaskInfo) {
 			}
 			klog.V(2).Infof("resyncTask task %s", task.Name)
 			sc.VolumeBinder.RevertVolumes(task, task.PodVolumes)
+			sc.RevertClaims(task, task.PodClaims)
 			sc.resyncTask(task)
 		}
 	}
@@ -962,6 +1067,38 @@ func (sc *SchedulerCache) RevertVolumes(task *schedulingapi.TaskInfo, podVolumes
 	sc.VolumeBinder.RevertVolumes(task, podVolumes)
 }
 
+// GetPodClaims get the resource claims of the pod allocated on the host
+func (sc *SchedulerCache) GetPodClaims(task *schedulingapi.TaskInfo, node *v1.Node) (*dynamicresources.PodClaims, error) {
+	if sc.ClaimBinder == nil {
+		return nil, nil
+	}
+	return sc.ClaimBinder.GetPodClaims(task, node)
+}
+
+// AllocateClaims allocates the resource claims on the host to the task
+func (sc *SchedulerCache) AllocateClaims(task *schedulingapi.TaskInfo, podClaims *dynamicresources.PodClaims) error {
+	if sc.ClaimBinder == nil {
+		return nil
+	}
+	return sc.ClaimBinder.AllocateClaims(task, podClaims)
+}
+
+// BindClaims binds the resource claims to the task
+func (sc *SchedulerCache) BindClaims(task *schedulingapi.TaskInfo, podClaims *dynamicresources.PodClaims) error {
+	if sc.ClaimBinder == nil {
+		return nil
+	}
+	return sc.ClaimBinder.BindClaims(task, podClaims)
+}
+
+// RevertClaims clean cache generated by AllocateClaims
+func (sc *SchedulerCache) RevertClaims(task *schedulingapi.TaskInfo, podClaims *dynamicresources.PodClaims) {
+	if sc.ClaimBinder == nil {
+		return
+	}
+	sc.ClaimBinder.RevertClaims(task, podClaims)
+}
+
 // Client returns the kubernetes clientSet
 func (sc *SchedulerCache) Client() kubernetes.Interface {
 	return sc.kubeClient
@@ -1308,6 +1445,12 @@ func (sc *SchedulerCache) BindTask() {
 			if err := sc.VolumeBinder.BindVolumes(task, task.PodVolumes); err != nil {
 				klog.Errorf("task %s/%s bind Volumes failed: %#v", task.Namespace, task.Name, err)
 				sc.VolumeBinder.RevertVolumes(task, task.PodVolumes)
+				sc.RevertClaims(task, task.PodClaims)
+				sc.resyncTask(task)
+			} else if err := sc.BindClaims(task, task.PodClaims); err != nil {
+				klog.Errorf("task %s/%s bind resource claims failed: %v", task.Namespace, task.Name, err)
+				sc.VolumeBinder.RevertVolumes(task, task.PodVolumes)
+				sc.RevertClaims(task, task.PodClaims)
 				sc.resyncTask(task)
 			} else {
 				successfulTasks = append(successfulTasks, task)
@@ -1322,11 +1465,28 @@ func (sc *SchedulerCache) BindTask() {
 	sc.bindCache = sc.bindCache[0:0]
 }
 
-// Snapshot returns the complete snapshot of the cluster from cache
+// Snapshot returns the complete snapshot of the cluster from cache for a scheduling session. Only the nodes
+// and jobs changed by the cache or by the session since the previous snapshot are cloned, the others are
+// shared with the previous snapshot, which must not be used any more.
 func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 	sc.Mutex.Lock()
 	defer sc.Mutex.Unlock()
 
+	return sc.snapshot(true)
+}
+
+// DeepSnapshot returns the complete snapshot of the cluster from cache, every object is cloned so
+// the snapshot is independent of the snapshots of the sessions.
+func (sc *SchedulerCache) DeepSnapshot() *schedulingapi.ClusterInfo {
+	sc.Mutex.Lock()
+	defer sc.Mutex.Unlock()
+
+	return sc.snapshot(false)
+}
+
+// snapshot clones the cache, the clones of the previous incremental snapshot are reused if incremental.
+// Assumes that lock is already acquired.
+func (sc *SchedulerCache) snapshot(incremental bool) *schedulingapi.ClusterInfo {
 	snapshot := &schedulingapi.ClusterInfo{
 		Nodes:          make(map[string]*schedulingapi.NodeInfo),
 		Jobs:           make(map[schedulingapi.JobID]*schedulingapi.JobInfo),
@@ -1346,18 +1506,45 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 		snapshot.CSINodesStatus[value.CSINodeName] = value.Clone()
 	}
 
+	var prevNodes map[string]snapshotNode
+	var prevJobs map[schedulingapi.JobID]snapshotJob
+	if incremental {
+		prevNodes, prevJobs = sc.snapshotNodes, sc.snapshotJobs
+	}
+
+	clonedNodes := 0
 	for _, value := range sc.Nodes {
 		if !value.Ready() {
 			continue
 		}
 
-		snapshot.Nodes[value.Name] = value.Clone()
+		var node *schedulingapi.NodeInfo
+		if prev, found := prevNodes[value.Name]; found && prev.unchanged(value) {
+			node = prev.node
+		} else {
+			node = value.Clone()
+			clonedNodes++
+		}
+		snapshot.Nodes[value.Name] = node
 
 		if value.RevocableZone != "" {
 			snapshot.RevocableNodes[value.Name] = snapshot.Nodes[value.Name]
 		}
 	}
 
+	// The node index only changes with the nodes, the nodes are added and removed with the node list.
+	if incremental && clonedNodes == 0 && len(snapshot.Nodes) == len(prevNodes) && sc.snapshotNodeIndex != nil {
+		snapshot.NodeIndex = sc.snapshotNodeIndex
+	} else {
+		indexedNodes := make([]*schedulingapi.NodeInfo, 0, len(snapshot.Nodes))
+		for _, name := range snapshot.NodeList {
+			if node, found := snapshot.Nodes[name]; found {
+				indexedNodes = append(indexedNodes, node)
+			}
+		}
+		snapshot.NodeIndex = schedulingapi.NewNodeIndex(indexedNodes)
+	}
+
 	for _, value := range sc.Queues {
 		snapshot.Queues[value.UID] = value.Clone()
 	}
@@ -1379,7 +1566,12 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 				value.Namespace, value.Name, priName, value.Priority)
 		}
 
-		clonedJob := value.Clone()
+		var clonedJob *schedulingapi.JobInfo
+		if prev, found := prevJobs[value.UID]; found && prev.unchanged(value) {
+			clonedJob = value.CloneWithTasksOf(prev.job)
+		} else {
+			clonedJob = value.Clone()
+		}
 
 		cloneJobLock.Lock()
 		snapshot.Jobs[value.UID] = clonedJob
@@ -1411,8 +1603,20 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 	}
 	wg.Wait()
 
-	klog.V(3).Infof("There are <%d> Jobs, <%d> Queues and <%d> Nodes in total for scheduling.",
-		len(snapshot.Jobs), len(snapshot.Queues), len(snapshot.Nodes))
+	if incremental {
+		sc.snapshotNodes = make(map[string]snapshotNode, len(snapshot.Nodes))
+		for name, node := range snapshot.Nodes {
+			sc.snapshotNodes[name] = snapshotNode{source: sc.Nodes[name], node: node, generation: node.Generation}
+		}
+		sc.snapshotJobs = make(map[schedulingapi.JobID]snapshotJob, len(snapshot.Jobs))
+		for uid, job := range snapshot.Jobs {
+			sc.snapshotJobs[uid] = snapshotJob{source: sc.Jobs[uid], job: job, generation: job.Generation}
+		}
+		sc.snapshotNodeIndex = snapshot.NodeIndex
+	}
+
+	klog.V(3).Infof("There are <%d> Jobs, <%d> Queues and <%d> Nodes in total for scheduling, <%d> Nodes cloned.",
+		len(snapshot.Jobs), len(snapshot.Queues), len(snapshot.Nodes), clonedNodes)
 
 	return snapshot
 }
@@ -1542,6 +1746,60 @@ func (sc *SchedulerCache) SetMetricsConf(conf map[string]string) {
 	sc.metricsConf = conf
 }
 
+// SetScheduleTrigger sets the function called on the events worth starting a scheduling cycle early,
+// it must be set before the cache runs and must not block.
+func (sc *SchedulerCache) SetScheduleTrigger(trigger func(reason string)) {
+	sc.scheduleTrigger = trigger
+}
+
+// SetBindFns sets the functions binding the tasks they handle in place of the Binder.
+func (sc *SchedulerCache) SetBindFns(fns []schedulingapi.BindFn) {
+	sc.bindFnsMutex.Lock()
+	defer sc.bindFnsMutex.Unlock()
+	sc.bindFns = fns
+}
+
+// delegateBinds binds the tasks handled by the bind functions, and returns the other tasks
+// and the errors of the binds.
+func (sc *SchedulerCache) delegateBinds(tasks []*schedulingapi.TaskInfo) ([]*schedulingapi.TaskInfo, map[schedulingapi.TaskID]string) {
+	errMsg := make(map[schedulingapi.TaskID]string)
+	sc.bindFnsMutex.RLock()
+	fns := sc.bindFns
+	sc.bindFnsMutex.RUnlock()
+	if len(fns) == 0 {
+		return tasks, errMsg
+	}
+
+	remaining := make([]*schedulingapi.TaskInfo, 0, len(tasks))
+	for _, task := range tasks {
+		handled := false
+		for _, fn := range fns {
+			bound, err := fn(task)
+			if err != nil {
+				klog.Errorf("Failed to bind pod <%v/%v> to node %s : %v", task.Namespace, task.Name, task.NodeName, err)
+				errMsg[task.UID] = err.Error()
+				handled = true
+				break
+			}
+			if bound {
+				metrics.UpdateTaskScheduleDuration(metrics.Duration(task.Pod.CreationTimestamp.Time))
+				handled = true
+				break
+			}
+		}
+		if !handled {
+			remaining = append(remaining, task)
+		}
+	}
+	return remaining, errMsg
+}
+
+func (sc *SchedulerCache) triggerSchedule(reason string) {
+	if sc.scheduleTrigger != nil {
+		sc.scheduleTrigger(reason)
+	}
+}
+
 func (sc *SchedulerCache) GetMetricsData() {
 	metricsType := sc.metricsConf["type"]
 	if len(metricsType) == 0 {
@@ -1578,6 +1836,19 @@ func (sc *SchedulerCache) setMetricsData(usageInfo map[string]*source.NodeMetric
 	sc.Mutex.Lock()
 	defer sc.Mutex.Unlock()
 
+	window, err := time.ParseDuration(sc.metricsConf["window"])
+	if err != nil || window <= 0 {
+		window = defaultMetricsWindow
+	}
+	if sc.nodeUsageSamples == nil {
+		sc.nodeUsageSamples = make(map[string][]*source.NodeMetrics, len(usageInfo))
+	}
+	for nodeName := range sc.nodeUsageSamples {
+		if _, found := usageInfo[nodeName]; !found {
+			delete(sc.nodeUsageSamples, nodeName)
+		}
+	}
+
 	for nodeName, nodeMetric := range usageInfo {
 		nodeUsage := &schedulingapi.NodeUsage{
 			CPUUsageAvg: make(map[string]float64),
@@ -1586,6 +1857,13 @@ func (sc *SchedulerCache) setMetricsData(usageInfo map[string]*source.NodeMetric
 		nodeUsage.MetricsTime = nodeMetric.MetricsTime
 		nodeUsage.CPUUsageAvg[source.NODE_METRICS_PERIOD] = nodeMetric.CPU
 		nodeUsage.MEMUsageAvg[source.NODE_METRICS_PERIOD] = nodeMetric.Memory
+		if len(nodeMetric.Resources) != 0 {
+			nodeUsage.ResourceUsageAvg = make(map[string]map[string]float64, len(nodeMetric.Resources))
+			for name, usage := range nodeMetric.Resources {
+				nodeUsage.ResourceUsageAvg[name] = map[string]float64{source.NODE_METRICS_PERIOD: usage}
+			}
+		}
+		nodeUsage.UsageStats = sc.addUsageSample(nodeName, nodeMetric, window)
 
 		nodeInfo, ok := sc.Nodes[nodeName]
 		if !ok {
@@ -1594,7 +1872,55 @@ func (sc *SchedulerCache) setMetricsData(usageInfo map[string]*source.NodeMetric
 		}
 		klog.V(5).Infof("node: %s, ResourceUsage: %+v => %+v", nodeName, *nodeInfo.ResourceUsage, nodeUsage)
 		nodeInfo.ResourceUsage = nodeUsage
+		nodeInfo.Generation++
+	}
+}
+
+// addUsageSample adds the usage sample of the node, drops the samples out of the window ending at the sample
+// and returns the statistics of the samples left by resource name.
+func (sc *SchedulerCache) addUsageSample(nodeName string, sample *source.NodeMetrics, window time.Duration) map[string]*schedulingapi.UsageStats {
+	samples := sc.nodeUsageSamples[nodeName]
+	if !sample.MetricsTime.IsZero() {
+		if len(samples) == 0 || sample.MetricsTime.After(samples[len(samples)-1].MetricsTime) {
+			samples = append(samples, sample)
+		}
+		start := 0
+		for start < len(samples) && sample.MetricsTime.Sub(samples[start].MetricsTime) > window {
+			start++
+		}
+		samples = samples[start:]
+		sc.nodeUsageSamples[nodeName] = samples
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+
+	times := make([]time.Time, 0, len(samples))
+	values := map[string][]float64{}
+	for _, s := range samples {
+		times = append(times, s.MetricsTime)
+		values["cpu"] = append(values["cpu"], s.CPU)
+		values["memory"] = append(values["memory"], s.Memory)
+	}
+	// the other resources are summarized over the latest samples reporting them
+	for name := range samples[len(samples)-1].Resources {
+		first := len(samples)
+		for first > 0 {
+			if _, found := samples[first-1].Resources[name]; !found {
+				break
+			}
+			first--
+		}
+		for _, s := range samples[first:] {
+			values[name] = append(values[name], s.Resources[name])
+		}
+	}
+
+	stats := make(map[string]*schedulingapi.UsageStats, len(values))
+	for name, v := range values {
+		stats[name] = schedulingapi.NewUsageStats(times[len(times)-len(v):], v)
 	}
+	return stats
 }
 
 // createImageStateSummary returns a summarizing snapshot of the given image's state.
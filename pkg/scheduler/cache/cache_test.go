@@ -23,12 +23,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/metrics/source"
+	metricsfake "volcano.sh/volcano/pkg/scheduler/metrics/source/fake"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
@@ -339,3 +344,115 @@ func TestBindTasks(t *testing.T) {
 		t.Fatalf("succesfully binding task should have 1 event")
 	}
 }
+
+func TestIncrementalSnapshot(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	for _, name := range []string{"n1", "n2", "n3"} {
+		sc.AddOrUpdateNode(util.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	}
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPod(util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	sc.AddPod(util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil))
+	job1, job2 := api.JobID("c1/pg1"), api.JobID("c1/pg2")
+
+	sameTasks := func(a, b *api.JobInfo) bool {
+		return reflect.ValueOf(a.Tasks).Pointer() == reflect.ValueOf(b.Tasks).Pointer()
+	}
+
+	// Nothing changed, the nodes, the tasks of the jobs and the node index are shared.
+	s1 := sc.Snapshot()
+	s2 := sc.Snapshot()
+	for name, node := range s1.Nodes {
+		assert.Same(t, node, s2.Nodes[name])
+	}
+	assert.NotSame(t, s1.Jobs[job1], s2.Jobs[job1])
+	assert.True(t, sameTasks(s1.Jobs[job1], s2.Jobs[job1]))
+	assert.Same(t, s1.NodeIndex, s2.NodeIndex)
+
+	// The deep snapshot shares nothing and leaves the incremental snapshot untouched.
+	deep := sc.DeepSnapshot()
+	assert.NotSame(t, s2.Nodes["n1"], deep.Nodes["n1"])
+	assert.False(t, sameTasks(s2.Jobs[job1], deep.Jobs[job1]))
+
+	// The session allocates a task, the node and the job it changed are cloned again from the cache.
+	task := s2.Jobs[job2].Tasks[api.TaskID("c1-p2")]
+	assert.NoError(t, s2.Jobs[job2].UpdateTaskStatus(task, api.Allocated))
+	assert.NoError(t, s2.Nodes["n2"].AddTask(task))
+	s2.Jobs[job1].NodesFitErrors["t"] = api.NewFitErrors()
+	s3 := sc.Snapshot()
+	assert.NotSame(t, s2.Nodes["n2"], s3.Nodes["n2"])
+	assert.Empty(t, s3.Nodes["n2"].Tasks)
+	assert.Same(t, s2.Nodes["n1"], s3.Nodes["n1"])
+	assert.False(t, sameTasks(s2.Jobs[job2], s3.Jobs[job2]))
+	assert.Equal(t, api.Pending, s3.Jobs[job2].Tasks[api.TaskID("c1-p2")].Status)
+	assert.True(t, sameTasks(s2.Jobs[job1], s3.Jobs[job1]))
+	assert.Empty(t, s3.Jobs[job1].NodesFitErrors)
+	assert.NotSame(t, s2.NodeIndex, s3.NodeIndex)
+
+	// The cache binds the task, the node and the job are cloned again.
+	sc.UpdatePod(util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+		util.BuildPod("c1", "p2", "n3", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil))
+	s4 := sc.Snapshot()
+	assert.NotSame(t, s3.Nodes["n3"], s4.Nodes["n3"])
+	assert.Len(t, s4.Nodes["n3"].Tasks, 1)
+	assert.Same(t, s3.Nodes["n1"], s4.Nodes["n1"])
+	assert.False(t, sameTasks(s3.Jobs[job2], s4.Jobs[job2]))
+	assert.Equal(t, api.Running, s4.Jobs[job2].Tasks[api.TaskID("c1-p2")].Status)
+
+	// A node deleted and added again is cloned again, even if its generation is the same.
+	assert.NoError(t, sc.RemoveNode("n1"))
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("8", "16Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	s5 := sc.Snapshot()
+	assert.NotSame(t, s4.Nodes["n1"], s5.Nodes["n1"])
+	assert.Equal(t, float64(8000), s5.Nodes["n1"].Allocatable.MilliCPU)
+}
+
+func TestGetMetricsData(t *testing.T) {
+	client := metricsfake.NewMetricsClient()
+	metricsfake.Register(client)
+	defer metricsfake.Unregister()
+
+	cache := &SchedulerCache{
+		Nodes:       make(map[string]*api.NodeInfo),
+		NodeList:    []string{},
+		metricsConf: map[string]string{"type": metricsfake.MetricsType, "window": "3m"},
+	}
+	cache.AddOrUpdateNode(buildNode("n1", api.BuildResourceList("2000m", "10G")))
+	cache.AddOrUpdateNode(buildNode("n2", api.BuildResourceList("2000m", "10G")))
+
+	start := time.Now().Add(-10 * time.Minute)
+	for i, cpu := range []float64{90, 10, 20, 30, 40} {
+		client.SetNodeMetrics("n1", &source.NodeMetrics{
+			MetricsTime: start.Add(time.Duration(i) * time.Minute),
+			CPU:         cpu,
+			Memory:      50,
+			Resources:   map[string]float64{"gpu": float64(60 - 10*i)},
+		})
+		cache.GetMetricsData()
+	}
+
+	usage := cache.Nodes["n1"].ResourceUsage
+	if usage.CPUUsageAvg[source.NODE_METRICS_PERIOD] != 40 {
+		t.Errorf("expected the cpu average 40 of the latest sample, got %v", usage.CPUUsageAvg[source.NODE_METRICS_PERIOD])
+	}
+	expected := map[string]*api.UsageStats{
+		// the sample of 90 is out of the window of 3 minutes
+		"cpu":    {Avg: 25, P95: 40, Max: 40, Trend: 30},
+		"memory": {Avg: 50, P95: 50, Max: 50, Trend: 0},
+		"gpu":    {Avg: 35, P95: 50, Max: 50, Trend: -30},
+	}
+	if !equality.Semantic.DeepEqual(usage.UsageStats, expected) {
+		for name, stats := range usage.UsageStats {
+			t.Logf("%s: %+v", name, *stats)
+		}
+		t.Errorf("unexpected usage stats of node n1")
+	}
+	if len(cache.nodeUsageSamples["n1"]) != 4 {
+		t.Errorf("expected 4 samples of node n1 in the window, got %d", len(cache.nodeUsageSamples["n1"]))
+	}
+	if cache.Nodes["n2"].ResourceUsage.UsageStats != nil {
+		t.Errorf("expected no usage stats of node n2 without metrics, got %v", cache.Nodes["n2"].ResourceUsage.UsageStats)
+	}
+}
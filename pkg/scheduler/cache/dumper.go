@@ -19,6 +19,8 @@ package cache
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/signal"
 	"path"
@@ -34,7 +36,9 @@ import (
 
 // Dumper writes some information from the scheduler cache to the scheduler logs
 // for debugging purposes. Usage: run `kill -s USR2 <pid>` in the shell, where <pid>
-// is the process id of the scheduler process.
+// is the process id of the scheduler process. `kill -s USR1 <pid>` writes a
+// ClusterSnapshot of the whole cache to a json file in RootDir, the same snapshot
+// is served over HTTP by ServeHTTP.
 type Dumper struct {
 	Cache   Cache
 	RootDir string // target directory for the dumped json file
@@ -42,7 +46,6 @@ type Dumper struct {
 
 // dumpToJSONFile marsh scheduler cache snapshot to json file
 func (d *Dumper) dumpToJSONFile() {
-	snapshot := d.Cache.Snapshot()
 	name := fmt.Sprintf("snapshot-%d.json", time.Now().Unix())
 	fName := path.Join(d.RootDir, name)
 	file, err := os.OpenFile(fName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
@@ -52,17 +55,58 @@ func (d *Dumper) dumpToJSONFile() {
 	}
 	defer file.Close()
 	klog.Infoln("Starting to dump info in scheduler cache to file", fName)
-	if err = json.NewEncoder(file).Encode(snapshot.Nodes); err != nil {
-		klog.Errorf("Failed to dump info in scheduler cache, json encode error: %v", err)
+	if err = d.writeSnapshot(file); err != nil {
+		klog.Errorf("Failed to dump info in scheduler cache: %v", err)
 		return
 	}
 
 	klog.Infoln("Successfully dump info in scheduler cache to file", fName)
 }
 
+// writeSnapshot encodes a ClusterSnapshot of the scheduler cache to the writer.
+func (d *Dumper) writeSnapshot(w io.Writer) error {
+	data, err := d.encodeSnapshot()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (d *Dumper) encodeSnapshot() ([]byte, error) {
+	snapshot, err := NewClusterSnapshot(d.Cache.DeepSnapshot())
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("json encode error: %v", err)
+	}
+	return data, nil
+}
+
+// ServeHTTP writes a ClusterSnapshot of the scheduler cache as the response,
+// the handler must be protected by authentication since it exposes the whole cluster state.
+func (d *Dumper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := d.encodeSnapshot()
+	if err != nil {
+		klog.Errorf("Failed to serve scheduler cache snapshot: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		klog.Errorf("Failed to write scheduler cache snapshot: %v", err)
+	}
+}
+
 // dumpAll prints all information to log
 func (d *Dumper) dumpAll() {
-	snapshot := d.Cache.Snapshot()
+	snapshot := d.Cache.DeepSnapshot()
 	klog.Info("Dump of nodes info in scheduler cache")
 	for _, nodeInfo := range snapshot.Nodes {
 		klog.Info(d.printNodeInfo(nodeInfo))
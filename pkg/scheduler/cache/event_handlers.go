@@ -442,6 +442,7 @@ func (sc *SchedulerCache) DeletePod(obj interface{}) {
 		klog.Errorf("Failed to delete pod %v from cache: %v", pod.Name, err)
 		return
 	}
+	sc.triggerSchedule(metrics.TriggerPodDeleted)
 
 	klog.V(3).Infof("Deleted pod <%s/%v> from cache.", pod.Namespace, pod.Name)
 }
@@ -498,6 +499,7 @@ func (sc *SchedulerCache) AddOrUpdateNode(node *v1.Node) error {
 		sc.removeNodeImageStates(node.Name)
 	} else {
 		sc.Nodes[node.Name] = schedulingapi.NewNodeInfo(node)
+		sc.triggerSchedule(metrics.TriggerNodeAdded)
 	}
 	sc.addNodeImageStates(node, sc.Nodes[node.Name])
 
@@ -761,6 +763,7 @@ func (sc *SchedulerCache) AddPodGroupV1beta1(obj interface{}) {
 		klog.Errorf("Failed to add PodGroup %s into cache: %v", ss.Name, err)
 		return
 	}
+	sc.triggerSchedule(metrics.TriggerPodGroupAdded)
 }
 
 // UpdatePodGroupV1beta1 add podgroup to scheduler cache
@@ -845,6 +848,7 @@ func (sc *SchedulerCache) AddQueueV1beta1(obj interface{}) {
 
 	klog.V(4).Infof("Add Queue(%s) into cache, spec(%#v)", ss.Name, ss.Spec)
 	sc.addQueue(queue)
+	sc.triggerSchedule(metrics.TriggerQueueChanged)
 }
 
 // UpdateQueueV1beta1 update queue to scheduler cache
@@ -873,6 +877,10 @@ func (sc *SchedulerCache) UpdateQueueV1beta1(oldObj, newObj interface{}) {
 	sc.Mutex.Lock()
 	defer sc.Mutex.Unlock()
 	sc.updateQueue(newQueue)
+	// The status of the queue is updated by the scheduler itself every cycle.
+	if !equality.Semantic.DeepEqual(oldSS.Spec, newSS.Spec) {
+		sc.triggerSchedule(metrics.TriggerQueueChanged)
+	}
 }
 
 // DeleteQueueV1beta1 delete queue from the scheduler cache
@@ -896,6 +904,7 @@ func (sc *SchedulerCache) DeleteQueueV1beta1(obj interface{}) {
 	sc.Mutex.Lock()
 	defer sc.Mutex.Unlock()
 	sc.deleteQueue(schedulingapi.QueueID(ss.Name))
+	sc.triggerSchedule(metrics.TriggerQueueChanged)
 }
 
 func (sc *SchedulerCache) addQueue(queue *scheduling.Queue) {
@@ -1090,6 +1099,11 @@ func getNumaInfo(srcInfo *nodeinfov1alpha1.Numatopology) *schedulingapi.Numatopo
 
 	numaResMap := srcInfo.Spec.NumaResMap
 	for name, resInfo := range numaResMap {
+		if resName, numaID, ok := schedulingapi.ParseNumaResKey(name); ok {
+			addNumaResAmount(numaInfo, resName, numaID, resInfo)
+			continue
+		}
+
 		tmp := schedulingapi.ResourceInfo{}
 		tmp.Capacity = resInfo.Capacity
 		allocatable, err := cpuset.Parse(resInfo.Allocatable)
@@ -1120,6 +1134,33 @@ func getNumaInfo(srcInfo *nodeinfov1alpha1.Numatopology) *schedulingapi.Numatopo
 	return numaInfo
 }
 
+// addNumaResAmount adds the allocatable quantity of the resource allocated by quantity on the numa node.
+func addNumaResAmount(numaInfo *schedulingapi.NumatopoInfo, resName string, numaID int, resInfo nodeinfov1alpha1.ResourceInfo) {
+	quantity, err := resource.ParseQuantity(resInfo.Allocatable)
+	if err != nil {
+		klog.ErrorS(err, "Failed to parse input as quantity", "resource", resName, "numa", numaID, "allocatable", resInfo.Allocatable)
+		return
+	}
+
+	tmp, ok := numaInfo.NumaResMap[resName]
+	if !ok {
+		tmp = &schedulingapi.ResourceInfo{
+			Allocatable:        cpuset.New(),
+			AllocatablePerNuma: make(map[int]float64),
+			UsedPerNuma:        make(map[int]float64),
+		}
+		numaInfo.NumaResMap[resName] = tmp
+	}
+	if tmp.AllocatablePerNuma == nil {
+		tmp.AllocatablePerNuma = make(map[int]float64)
+	}
+	if tmp.UsedPerNuma == nil {
+		tmp.UsedPerNuma = make(map[int]float64)
+	}
+	tmp.AllocatablePerNuma[numaID] = schedulingapi.ResQuantity2Float64(v1.ResourceName(resName), quantity)
+	tmp.Capacity += resInfo.Capacity
+}
+
 // Assumes that lock is already acquired.
 func (sc *SchedulerCache) addNumaInfo(info *nodeinfov1alpha1.Numatopology) error {
 	if sc.Nodes[info.Name] == nil {
@@ -1127,11 +1168,16 @@ func (sc *SchedulerCache) addNumaInfo(info *nodeinfov1alpha1.Numatopology) error
 		sc.Nodes[info.Name].Name = info.Name
 	}
 
+	newLocalInfo := getNumaInfo(info)
+	// the used quantity of the resources allocated by quantity is the one of the tasks on the node
+	for _, task := range sc.Nodes[info.Name].Tasks {
+		newLocalInfo.AddTask(task)
+	}
+
 	if sc.Nodes[info.Name].NumaInfo == nil {
-		sc.Nodes[info.Name].NumaInfo = getNumaInfo(info)
+		sc.Nodes[info.Name].NumaInfo = newLocalInfo
 		sc.Nodes[info.Name].NumaChgFlag = schedulingapi.NumaInfoMoreFlag
 	} else {
-		newLocalInfo := getNumaInfo(info)
 		if sc.Nodes[info.Name].NumaInfo.Compare(newLocalInfo) {
 			sc.Nodes[info.Name].NumaChgFlag = schedulingapi.NumaInfoMoreFlag
 		} else {
@@ -1140,6 +1186,7 @@ func (sc *SchedulerCache) addNumaInfo(info *nodeinfov1alpha1.Numatopology) error
 
 		sc.Nodes[info.Name].NumaInfo = newLocalInfo
 	}
+	sc.Nodes[info.Name].Generation++
 
 	for resName, NumaResInfo := range sc.Nodes[info.Name].NumaInfo.NumaResMap {
 		klog.V(3).Infof("resource %s Allocatable %v on node[%s] into cache", resName, NumaResInfo, info.Name)
@@ -1155,6 +1202,7 @@ func (sc *SchedulerCache) deleteNumaInfo(info *nodeinfov1alpha1.Numatopology) {
 	if sc.Nodes[info.Name] != nil {
 		sc.Nodes[info.Name].NumaInfo = nil
 		sc.Nodes[info.Name].NumaChgFlag = schedulingapi.NumaInfoResetFlag
+		sc.Nodes[info.Name].Generation++
 		klog.V(3).Infof("delete numainfo in cahce for node<%s>", info.Name)
 	}
 }
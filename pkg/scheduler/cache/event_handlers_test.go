@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/workqueue"
 
+	nodeinfov1alpha1 "volcano.sh/apis/pkg/apis/nodeinfo/v1alpha1"
 	"volcano.sh/apis/pkg/apis/scheduling"
 	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
@@ -710,3 +711,32 @@ func TestSchedulerCache_SyncNode(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNumaInfo(t *testing.T) {
+	numatopo := &nodeinfov1alpha1.Numatopology{
+		ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+		Spec: nodeinfov1alpha1.NumatopoSpec{
+			NumaResMap: map[string]nodeinfov1alpha1.ResourceInfo{
+				"cpu":              {Allocatable: "0-3", Capacity: 4},
+				"memory@0":         {Allocatable: "8Gi", Capacity: 8},
+				"memory@1":         {Allocatable: "4Gi", Capacity: 8},
+				"nvidia.com/gpu@1": {Allocatable: "2", Capacity: 2},
+			},
+		},
+	}
+
+	numaInfo := getNumaInfo(numatopo)
+	assert.Equal(t, 4, numaInfo.NumaResMap["cpu"].Allocatable.Size())
+	assert.Equal(t, map[int]float64{0: 8 * 1024 * 1024 * 1024, 1: 4 * 1024 * 1024 * 1024}, numaInfo.NumaResMap["memory"].AllocatablePerNuma)
+	assert.Equal(t, 16, numaInfo.NumaResMap["memory"].Capacity)
+	assert.Equal(t, map[int]float64{1: 2}, numaInfo.NumaResMap["nvidia.com/gpu"].AllocatablePerNuma)
+
+	node := &schedulingapi.NodeInfo{Name: "n1", NumaSchedulerInfo: numaInfo}
+	idle := schedulingapi.GenerateNodeResNumaAmounts(map[string]*schedulingapi.NodeInfo{"n1": node})
+	numaInfo.AddTask(&schedulingapi.TaskInfo{NumaInfo: &schedulingapi.TopologyInfo{
+		ResMap: schedulingapi.ResNumaAmounts{"nvidia.com/gpu": {1: 1}}.ResMap(),
+	}})
+	assert.Equal(t, float64(2), idle["n1"]["nvidia.com/gpu"][1])
+	idle = schedulingapi.GenerateNodeResNumaAmounts(map[string]*schedulingapi.NodeInfo{"n1": node})
+	assert.Equal(t, float64(1), idle["n1"]["nvidia.com/gpu"][1])
+}
@@ -25,6 +25,7 @@ import (
 
 	vcclient "volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/capabilities/dynamicresources"
 	"volcano.sh/volcano/pkg/scheduler/capabilities/volumebinding"
 )
 
@@ -34,9 +35,13 @@ type Cache interface {
 	// Run start informer
 	Run(stopCh <-chan struct{})
 
-	// Snapshot deep copy overall cache information into snapshot
+	// Snapshot copies overall cache information into snapshot for a scheduling session, the nodes and jobs
+	// unchanged since the previous snapshot are shared with it, so one session at a time may use the snapshots
 	Snapshot() *api.ClusterInfo
 
+	// DeepSnapshot deep copy overall cache information into snapshot independent of the other snapshots
+	DeepSnapshot() *api.ClusterInfo
+
 	// WaitForCacheSync waits for all cache synced
 	WaitForCacheSync(stopCh <-chan struct{})
 
@@ -72,6 +77,18 @@ type Cache interface {
 	// RevertVolumes clean cache generated by AllocateVolumes
 	RevertVolumes(task *api.TaskInfo, podVolumes *volumebinding.PodVolumes)
 
+	// GetPodClaims get the resource claims of the pod allocated on the host
+	GetPodClaims(task *api.TaskInfo, node *v1.Node) (*dynamicresources.PodClaims, error)
+
+	// AllocateClaims allocates the resource claims on the host to the task
+	AllocateClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) error
+
+	// BindClaims binds the resource claims to the task
+	BindClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) error
+
+	// RevertClaims clean cache generated by AllocateClaims
+	RevertClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims)
+
 	// Client returns the kubernetes clientSet, which can be used by plugins
 	Client() kubernetes.Interface
 
@@ -89,6 +106,12 @@ type Cache interface {
 	// SetMetricsConf set the metrics server related configuration
 	SetMetricsConf(conf map[string]string)
 
+	// SetScheduleTrigger set the function called on the events worth starting a scheduling cycle early
+	SetScheduleTrigger(trigger func(reason string))
+
+	// SetBindFns set the functions binding the tasks they handle in place of the Binder
+	SetBindFns(fns []api.BindFn)
+
 	// EventRecorder returns the event recorder
 	EventRecorder() record.EventRecorder
 }
@@ -101,6 +124,14 @@ type VolumeBinder interface {
 	BindVolumes(task *api.TaskInfo, podVolumes *volumebinding.PodVolumes) error
 }
 
+// ClaimBinder interface for allocate and bind resource claims
+type ClaimBinder interface {
+	GetPodClaims(task *api.TaskInfo, node *v1.Node) (*dynamicresources.PodClaims, error)
+	RevertClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims)
+	AllocateClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) error
+	BindClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) error
+}
+
 // Binder interface for binding task and hostname
 type Binder interface {
 	Bind(kubeClient kubernetes.Interface, tasks []*api.TaskInfo) map[api.TaskID]string
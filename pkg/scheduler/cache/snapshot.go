@@ -0,0 +1,313 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingscheme "volcano.sh/apis/pkg/apis/scheduling/scheme"
+	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// ClusterSnapshotAPIVersion is the version of the serialized cluster snapshot,
+	// it must be bumped on incompatible changes of the format.
+	ClusterSnapshotAPIVersion = "scheduler.volcano.sh/v1alpha1"
+	// ClusterSnapshotKind is the kind of the serialized cluster snapshot.
+	ClusterSnapshotKind = "ClusterSnapshot"
+)
+
+// ClusterSnapshot is the serialized form of a schedulingapi.ClusterInfo. Only the source
+// objects and the state owned by the scheduler (task status, node name, job priority...)
+// are kept; the derived accounting such as the idle, used, releasing and pipelined
+// resources of nodes is rebuilt when the snapshot is loaded.
+//
+// Numa topology and pod volumes are not part of the snapshot.
+type ClusterSnapshot struct {
+	metav1.TypeMeta `json:",inline"`
+	// CreationTimestamp is the time the snapshot was taken.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+
+	Nodes          []*NodeSnapshot                    `json:"nodes,omitempty"`
+	Jobs           []*JobSnapshot                     `json:"jobs,omitempty"`
+	Queues         []*vcv1beta1.Queue                 `json:"queues,omitempty"`
+	Namespaces     []*schedulingapi.NamespaceInfo     `json:"namespaces,omitempty"`
+	NodeList       []string                           `json:"nodeList,omitempty"`
+	CSINodesStatus []*schedulingapi.CSINodeStatusInfo `json:"csiNodesStatus,omitempty"`
+}
+
+// NodeSnapshot is the serialized form of a schedulingapi.NodeInfo.
+type NodeSnapshot struct {
+	// Name is the name of the NodeInfo, the node is nil if the node is only known from its pods or its Numatopology.
+	Name string   `json:"name"`
+	Node *v1.Node `json:"node"`
+	// Tasks are all tasks placed on the node, including the ones
+	// which do not belong to any job, e.g. pods of other schedulers.
+	Tasks         []*TaskSnapshot                            `json:"tasks,omitempty"`
+	ResourceUsage *schedulingapi.NodeUsage                   `json:"resourceUsage,omitempty"`
+	ImageStates   map[string]*k8sframework.ImageStateSummary `json:"imageStates,omitempty"`
+}
+
+// JobSnapshot is the serialized form of a schedulingapi.JobInfo.
+type JobSnapshot struct {
+	UID      schedulingapi.JobID   `json:"uid"`
+	Queue    schedulingapi.QueueID `json:"queue"`
+	Priority int32                 `json:"priority"`
+	PodGroup *vcv1beta1.PodGroup   `json:"podGroup"`
+	Tasks    []*TaskSnapshot       `json:"tasks,omitempty"`
+}
+
+// TaskSnapshot is the serialized form of a schedulingapi.TaskInfo.
+type TaskSnapshot struct {
+	Pod *v1.Pod `json:"pod"`
+	// Status is the status of the task in the scheduler, it may differ from the
+	// pod phase, e.g. for tasks being bound or evicted.
+	Status   string `json:"status"`
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+// NewClusterSnapshot converts the ClusterInfo into its serialized form.
+func NewClusterSnapshot(ci *schedulingapi.ClusterInfo) (*ClusterSnapshot, error) {
+	snapshot := &ClusterSnapshot{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ClusterSnapshotAPIVersion,
+			Kind:       ClusterSnapshotKind,
+		},
+		CreationTimestamp: metav1.Now(),
+		NodeList:          ci.NodeList,
+	}
+
+	nodeNames := make([]string, 0, len(ci.Nodes))
+	for name := range ci.Nodes {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+	for _, name := range nodeNames {
+		node := ci.Nodes[name]
+		snapshot.Nodes = append(snapshot.Nodes, &NodeSnapshot{
+			Name:          node.Name,
+			Node:          node.Node,
+			Tasks:         newTaskSnapshots(node.Tasks),
+			ResourceUsage: node.ResourceUsage,
+			ImageStates:   node.ImageStates,
+		})
+	}
+
+	for _, job := range ci.Jobs {
+		if job.PodGroup == nil {
+			continue
+		}
+		pg := &vcv1beta1.PodGroup{}
+		if err := schedulingscheme.Scheme.Convert(&job.PodGroup.PodGroup, pg, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert podgroup of job <%s>: %v", job.UID, err)
+		}
+		snapshot.Jobs = append(snapshot.Jobs, &JobSnapshot{
+			UID:      job.UID,
+			Queue:    job.Queue,
+			Priority: job.Priority,
+			PodGroup: pg,
+			Tasks:    newTaskSnapshots(job.Tasks),
+		})
+	}
+	sort.Slice(snapshot.Jobs, func(i, j int) bool {
+		return snapshot.Jobs[i].UID < snapshot.Jobs[j].UID
+	})
+
+	for _, queue := range ci.Queues {
+		q := &vcv1beta1.Queue{}
+		if err := schedulingscheme.Scheme.Convert(queue.Queue, q, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert queue <%s>: %v", queue.Name, err)
+		}
+		snapshot.Queues = append(snapshot.Queues, q)
+	}
+	sort.Slice(snapshot.Queues, func(i, j int) bool {
+		return snapshot.Queues[i].Name < snapshot.Queues[j].Name
+	})
+
+	for _, ns := range ci.NamespaceInfo {
+		snapshot.Namespaces = append(snapshot.Namespaces, ns)
+	}
+	sort.Slice(snapshot.Namespaces, func(i, j int) bool {
+		return snapshot.Namespaces[i].Name < snapshot.Namespaces[j].Name
+	})
+
+	for _, status := range ci.CSINodesStatus {
+		snapshot.CSINodesStatus = append(snapshot.CSINodesStatus, status)
+	}
+	sort.Slice(snapshot.CSINodesStatus, func(i, j int) bool {
+		return snapshot.CSINodesStatus[i].CSINodeName < snapshot.CSINodesStatus[j].CSINodeName
+	})
+
+	return snapshot, nil
+}
+
+func newTaskSnapshots(tasks map[schedulingapi.TaskID]*schedulingapi.TaskInfo) []*TaskSnapshot {
+	snapshots := make([]*TaskSnapshot, 0, len(tasks))
+	for _, task := range tasks {
+		snapshots = append(snapshots, &TaskSnapshot{
+			Pod:      task.Pod,
+			Status:   task.Status.String(),
+			NodeName: task.NodeName,
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].Pod.Namespace != snapshots[j].Pod.Namespace {
+			return snapshots[i].Pod.Namespace < snapshots[j].Pod.Namespace
+		}
+		return snapshots[i].Pod.Name < snapshots[j].Pod.Name
+	})
+	return snapshots
+}
+
+// ReadClusterSnapshot decodes a serialized cluster snapshot and checks its version.
+func ReadClusterSnapshot(r io.Reader) (*ClusterSnapshot, error) {
+	snapshot := &ClusterSnapshot{}
+	if err := json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode cluster snapshot: %v", err)
+	}
+	if snapshot.Kind != ClusterSnapshotKind || snapshot.APIVersion != ClusterSnapshotAPIVersion {
+		return nil, fmt.Errorf("unsupported cluster snapshot %s/%s, expected %s/%s",
+			snapshot.APIVersion, snapshot.Kind, ClusterSnapshotAPIVersion, ClusterSnapshotKind)
+	}
+	return snapshot, nil
+}
+
+// ClusterInfo rebuilds the ClusterInfo from the snapshot, every call returns new objects.
+func (s *ClusterSnapshot) ClusterInfo() (*schedulingapi.ClusterInfo, error) {
+	ci := &schedulingapi.ClusterInfo{
+		Nodes:          make(map[string]*schedulingapi.NodeInfo),
+		Jobs:           make(map[schedulingapi.JobID]*schedulingapi.JobInfo),
+		Queues:         make(map[schedulingapi.QueueID]*schedulingapi.QueueInfo),
+		NamespaceInfo:  make(map[schedulingapi.NamespaceName]*schedulingapi.NamespaceInfo),
+		RevocableNodes: make(map[string]*schedulingapi.NodeInfo),
+		NodeList:       append([]string{}, s.NodeList...),
+		CSINodesStatus: make(map[string]*schedulingapi.CSINodeStatusInfo),
+	}
+
+	for _, ns := range s.Nodes {
+		node := schedulingapi.NewNodeInfo(ns.Node.DeepCopy())
+		if ns.Node == nil {
+			node.Name = ns.Name
+		}
+		if ns.ResourceUsage != nil {
+			node.ResourceUsage = ns.ResourceUsage.DeepCopy()
+		}
+		for name, state := range ns.ImageStates {
+			node.ImageStates[name] = &k8sframework.ImageStateSummary{Size: state.Size, NumNodes: state.NumNodes}
+		}
+		// Adding the tasks through the NodeInfo recomputes idle, used, releasing and pipelined resources.
+		for _, ts := range ns.Tasks {
+			task, err := ts.taskInfo()
+			if err != nil {
+				return nil, fmt.Errorf("node <%s>: %v", node.Name, err)
+			}
+			if err := node.AddTask(task); err != nil {
+				return nil, fmt.Errorf("failed to add task <%s/%s> to node <%s>: %v",
+					task.Namespace, task.Name, node.Name, err)
+			}
+		}
+		ci.Nodes[node.Name] = node
+		if node.RevocableZone != "" {
+			ci.RevocableNodes[node.Name] = node
+		}
+	}
+
+	for _, qs := range s.Queues {
+		queue := &scheduling.Queue{}
+		if err := schedulingscheme.Scheme.Convert(qs, queue, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert queue <%s>: %v", qs.Name, err)
+		}
+		qi := schedulingapi.NewQueueInfo(queue)
+		ci.Queues[qi.UID] = qi
+	}
+
+	for _, js := range s.Jobs {
+		pg := scheduling.PodGroup{}
+		if err := schedulingscheme.Scheme.Convert(js.PodGroup, &pg, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert podgroup of job <%s>: %v", js.UID, err)
+		}
+		job := schedulingapi.NewJobInfo(js.UID)
+		job.SetPodGroup(&schedulingapi.PodGroup{PodGroup: pg, Version: schedulingapi.PodGroupVersionV1Beta1})
+		job.Queue = js.Queue
+		job.Priority = js.Priority
+		// Adding the tasks through the JobInfo recomputes the task status index and allocated resources.
+		for _, ts := range js.Tasks {
+			task, err := ts.taskInfo()
+			if err != nil {
+				return nil, fmt.Errorf("job <%s>: %v", js.UID, err)
+			}
+			job.AddTaskInfo(task)
+		}
+		ci.Jobs[job.UID] = job
+	}
+
+	for _, ns := range s.Namespaces {
+		info := &schedulingapi.NamespaceInfo{
+			Name:        ns.Name,
+			QuotaStatus: make(map[string]v1.ResourceQuotaStatus, len(ns.QuotaStatus)),
+		}
+		for name, status := range ns.QuotaStatus {
+			info.QuotaStatus[name] = *status.DeepCopy()
+		}
+		ci.NamespaceInfo[info.Name] = info
+	}
+
+	for _, cs := range s.CSINodesStatus {
+		status := &schedulingapi.CSINodeStatusInfo{
+			CSINodeName:  cs.CSINodeName,
+			DriverStatus: make(map[string]bool, len(cs.DriverStatus)),
+		}
+		for driver, available := range cs.DriverStatus {
+			status.DriverStatus[driver] = available
+		}
+		ci.CSINodesStatus[status.CSINodeName] = status
+	}
+
+	return ci, nil
+}
+
+func (ts *TaskSnapshot) taskInfo() (*schedulingapi.TaskInfo, error) {
+	if ts == nil || ts.Pod == nil {
+		return nil, fmt.Errorf("task without pod")
+	}
+	status, err := parseTaskStatus(ts.Status)
+	if err != nil {
+		return nil, fmt.Errorf("task <%s/%s>: %v", ts.Pod.Namespace, ts.Pod.Name, err)
+	}
+	task := schedulingapi.NewTaskInfo(ts.Pod.DeepCopy())
+	task.Status = status
+	task.NodeName = ts.NodeName
+	return task, nil
+}
+
+func parseTaskStatus(s string) (schedulingapi.TaskStatus, error) {
+	for status := schedulingapi.Pending; status <= schedulingapi.Unknown; status <<= 1 {
+		if status.String() == s {
+			return status, nil
+		}
+	}
+	return schedulingapi.Unknown, fmt.Errorf("unknown task status %q", s)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func buildSnapshotTestCache() *SchedulerCache {
+	sc := NewCustomMockSchedulerCache("volcano", nil, nil, nil, nil, nil, &record.FakeRecorder{})
+
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, api.BuildResourceList("8", "8G")))
+	sc.AddResourceQuota(util.BuildResourceQuota("rq1", "c1", api.BuildResourceList("10", "10G")))
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+	n2 := util.BuildNode("n2", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+	n2.Labels = map[string]string{schedulingv1beta1.RevocableZone: "rz1"}
+	sc.AddOrUpdateNode(n1)
+	sc.AddOrUpdateNode(n2)
+
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning))
+	sc.AddPod(util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+	releasing := util.BuildPod("c1", "p2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", nil, nil)
+	releasing.DeletionTimestamp = &metav1.Time{}
+	sc.AddPod(releasing)
+	sc.AddPod(util.BuildPod("c1", "p3", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+	// A pod of another scheduler only accounted on the node.
+	sc.AddPod(util.BuildPod("c2", "p4", "n2", v1.PodRunning, api.BuildResourceList("2", "1G"), "", nil, nil))
+
+	return sc
+}
+
+func TestClusterSnapshotRoundTrip(t *testing.T) {
+	sc := buildSnapshotTestCache()
+	ci := sc.Snapshot()
+
+	// Pipeline the pending task, so that the snapshot holds every kind of node accounting.
+	job := ci.Jobs["c1/pg1"]
+	for _, task := range job.Tasks {
+		if task.Status != api.Pending {
+			continue
+		}
+		task.NodeName = "n2"
+		assert.NoError(t, job.UpdateTaskStatus(task, api.Pipelined))
+		assert.NoError(t, ci.Nodes["n2"].AddTask(task))
+	}
+
+	snapshot, err := NewClusterSnapshot(ci)
+	assert.NoError(t, err)
+	data, err := json.Marshal(snapshot)
+	assert.NoError(t, err)
+	loadedSnapshot, err := ReadClusterSnapshot(bytes.NewReader(data))
+	assert.NoError(t, err)
+	loaded, err := loadedSnapshot.ClusterInfo()
+	assert.NoError(t, err)
+
+	assert.Equal(t, len(ci.Nodes), len(loaded.Nodes))
+	for name, node := range ci.Nodes {
+		got := loaded.Nodes[name]
+		if !assert.NotNil(t, got, "node %s", name) {
+			continue
+		}
+		assert.True(t, node.Idle.Equal(got.Idle, api.Zero), "node %s idle: expected %v, got %v", name, node.Idle, got.Idle)
+		assert.True(t, node.Used.Equal(got.Used, api.Zero), "node %s used: expected %v, got %v", name, node.Used, got.Used)
+		assert.True(t, node.Releasing.Equal(got.Releasing, api.Zero), "node %s releasing: expected %v, got %v", name, node.Releasing, got.Releasing)
+		assert.True(t, node.Pipelined.Equal(got.Pipelined, api.Zero), "node %s pipelined: expected %v, got %v", name, node.Pipelined, got.Pipelined)
+		assert.Equal(t, len(node.Tasks), len(got.Tasks), "node %s tasks", name)
+	}
+	assert.Contains(t, loaded.RevocableNodes, "n2")
+	assert.False(t, loaded.Nodes["n2"].Pipelined.IsEmpty())
+	assert.False(t, loaded.Nodes["n1"].Releasing.IsEmpty())
+
+	assert.Equal(t, len(ci.Jobs), len(loaded.Jobs))
+	for uid, job := range ci.Jobs {
+		got := loaded.Jobs[uid]
+		if !assert.NotNil(t, got, "job %s", uid) {
+			continue
+		}
+		assert.Equal(t, job.Queue, got.Queue)
+		assert.Equal(t, job.MinAvailable, got.MinAvailable)
+		assert.Equal(t, job.PodGroup.Status.Phase, got.PodGroup.Status.Phase)
+		assert.True(t, job.Allocated.Equal(got.Allocated, api.Zero), "job %s allocated", uid)
+		for status, tasks := range job.TaskStatusIndex {
+			assert.Equal(t, len(tasks), len(got.TaskStatusIndex[status]), "job %s tasks in %v", uid, status)
+		}
+	}
+
+	assert.Equal(t, len(ci.Queues), len(loaded.Queues))
+	assert.Equal(t, ci.Queues["q1"].Weight, loaded.Queues["q1"].Weight)
+	assert.Equal(t, ci.NamespaceInfo["c1"].QuotaStatus, loaded.NamespaceInfo["c1"].QuotaStatus)
+	assert.Equal(t, ci.NodeList, loaded.NodeList)
+}
+
+func TestClusterSnapshotNodeWithoutNode(t *testing.T) {
+	sc := buildSnapshotTestCache()
+	ci := sc.Snapshot()
+	// A node known only from its pods has no node object.
+	ci.Nodes["n0"] = api.NewNodeInfo(nil)
+	ci.Nodes["n0"].Name = "n0"
+
+	snapshot, err := NewClusterSnapshot(ci)
+	assert.NoError(t, err)
+	assert.Equal(t, "n0", snapshot.Nodes[0].Name)
+	assert.Nil(t, snapshot.Nodes[0].Node)
+
+	loaded, err := snapshot.ClusterInfo()
+	assert.NoError(t, err)
+	assert.Contains(t, loaded.Nodes, "n0")
+	assert.Equal(t, len(ci.Nodes), len(loaded.Nodes))
+}
+
+func TestClusterSnapshotTaskWithoutPod(t *testing.T) {
+	ci := buildSnapshotTestCache().Snapshot()
+	snapshot, err := NewClusterSnapshot(ci)
+	assert.NoError(t, err)
+	data, err := json.Marshal(snapshot)
+	assert.NoError(t, err)
+
+	// A hand-edited or truncated snapshot may drop the pod of a task.
+	raw := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(data, &raw))
+	jobs := raw["jobs"].([]interface{})
+	tasks := jobs[0].(map[string]interface{})["tasks"].([]interface{})
+	delete(tasks[0].(map[string]interface{}), "pod")
+	data, err = json.Marshal(raw)
+	assert.NoError(t, err)
+
+	loaded, err := ReadClusterSnapshot(bytes.NewReader(data))
+	assert.NoError(t, err)
+	_, err = loaded.ClusterInfo()
+	assert.ErrorContains(t, err, "task without pod")
+}
+
+func TestReadClusterSnapshotVersion(t *testing.T) {
+	_, err := ReadClusterSnapshot(bytes.NewReader([]byte(`{"apiVersion":"scheduler.volcano.sh/v0","kind":"ClusterSnapshot"}`)))
+	assert.Error(t, err)
+	_, err = ReadClusterSnapshot(bytes.NewReader([]byte(`{"nodes":[]}`)))
+	assert.Error(t, err)
+}
+
+func TestDumperServeHTTP(t *testing.T) {
+	dumper := &Dumper{Cache: buildSnapshotTestCache()}
+
+	rec := httptest.NewRecorder()
+	dumper.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/cache/snapshot", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	snapshot, err := ReadClusterSnapshot(rec.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(snapshot.Nodes))
+	assert.Equal(t, 1, len(snapshot.Jobs))
+
+	rec = httptest.NewRecorder()
+	dumper.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/cache/snapshot", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
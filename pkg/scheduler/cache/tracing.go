@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/util/tracing"
+)
+
+// startBindSpans starts the spans of the binds of the tasks as children of the spans the tasks were allocated in.
+// The trace context of the spans is set into the annotations of the pods, the bindings copy them to the pods
+// so that the components handling the pods afterwards join the trace of their scheduling.
+func (sc *SchedulerCache) startBindSpans(tasks []*schedulingapi.TaskInfo) map[schedulingapi.TaskID]trace.Span {
+	spans := make(map[schedulingapi.TaskID]trace.Span, len(tasks))
+	var recording []*schedulingapi.TaskInfo
+	for _, task := range tasks {
+		ctx, span := tracing.Start(tracing.ContextWithTraceParent(context.Background(), task.TraceParent), "SchedulerCache.Bind",
+			tracing.JobKey.String(string(task.Job)),
+			tracing.TaskKey.String(task.Namespace+"/"+task.Name),
+			tracing.NodeKey.String(task.NodeName))
+		spans[task.UID] = span
+		if !span.IsRecording() {
+			continue
+		}
+		recording = append(recording, task)
+
+		// The pod is shared with the informer, the annotation is set into a copy.
+		pod := task.Pod.DeepCopy()
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		if tracing.InjectAnnotation(ctx, pod.Annotations) {
+			task.Pod = pod
+		}
+	}
+	if len(recording) == 0 {
+		return spans
+	}
+
+	sc.Mutex.Lock()
+	defer sc.Mutex.Unlock()
+	for _, task := range recording {
+		if job, found := sc.Jobs[task.Job]; found {
+			spans[task.UID].SetAttributes(tracing.QueueKey.String(string(job.Queue)))
+		}
+	}
+	return spans
+}
+
+// endBindSpans ends the spans of the binds, the spans of the failed binds are ended with their error.
+func endBindSpans(spans map[schedulingapi.TaskID]trace.Span, errMsg map[schedulingapi.TaskID]string) {
+	for uid, span := range spans {
+		if reason, failed := errMsg[uid]; failed {
+			span.SetStatus(codes.Error, reason)
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/util/tracing"
+)
+
+// annotationBinder records the annotations of the bound pods and fails the binds of the failed pods.
+type annotationBinder struct {
+	annotations map[string]map[string]string
+	failed      map[string]bool
+}
+
+func (ab *annotationBinder) Bind(_ kubernetes.Interface, tasks []*api.TaskInfo) map[api.TaskID]string {
+	errMsg := map[api.TaskID]string{}
+	for _, task := range tasks {
+		ab.annotations[task.Name] = task.Pod.Annotations
+		if ab.failed[task.Name] {
+			errMsg[task.UID] = "fake bind error"
+		}
+	}
+	return errMsg
+}
+
+func TestBindSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	binder := &annotationBinder{annotations: map[string]map[string]string{}, failed: map[string]bool{"p2": true}}
+	cache := NewDefaultMockSchedulerCache("volcano")
+	cache.Binder = binder
+	job := api.NewJobInfo("c1/j1")
+	job.Queue = "q1"
+	cache.Jobs[job.UID] = job
+
+	ctx, commit := provider.Tracer("test").Start(context.Background(), "Statement.Commit")
+	var tasks []*api.TaskInfo
+	pods := map[string]*v1.Pod{}
+	for _, name := range []string{"p1", "p2"} {
+		pod := buildPod("c1", name, "n1", v1.PodPending, api.BuildResourceList("1", "1G"), []metav1.OwnerReference{}, map[string]string{})
+		pods[name] = pod
+		task := api.NewTaskInfo(pod)
+		task.Job = job.UID
+		task.TraceParent = tracing.TraceParent(ctx)
+		tasks = append(tasks, task)
+	}
+	cache.Bind(tasks)
+	commit.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		if span.Name() == "SchedulerCache.Bind" {
+			for _, attr := range span.Attributes() {
+				if attr.Key == tracing.TaskKey {
+					spans[attr.Value.AsString()] = span
+				}
+			}
+			assert.Contains(t, span.Attributes(), tracing.QueueKey.String("q1"))
+			assert.Equal(t, commit.SpanContext().SpanID(), span.Parent().SpanID(), "the bind span is a child of the commit span")
+		}
+	}
+	assert.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans["c1/p1"].Status().Code)
+	assert.Equal(t, codes.Error, spans["c1/p2"].Status().Code)
+
+	for _, name := range []string{"p1", "p2"} {
+		bound := trace.SpanContextFromContext(tracing.ContextFromAnnotations(context.Background(), binder.annotations[name]))
+		assert.Equal(t, spans["c1/"+name].SpanContext().SpanID(), bound.SpanID(), "the binding carries the bind span of %s", name)
+		assert.NotContains(t, pods[name].Annotations, tracing.TraceContextAnnotation, "the pod of the informer is not modified")
+	}
+}
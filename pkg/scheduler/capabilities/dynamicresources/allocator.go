@@ -0,0 +1,340 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicresources
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1alpha3"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+)
+
+// DeviceID identifies a device published in a ResourceSlice.
+type DeviceID struct {
+	Driver string
+	Pool   string
+	Device string
+}
+
+func (id DeviceID) String() string {
+	return id.Driver + "/" + id.Pool + "/" + id.Device
+}
+
+// device is a device available on a node.
+type device struct {
+	id    DeviceID
+	basic *resourceapi.BasicDevice
+	// nodeLocal is whether the device is only available on the node, not on all nodes.
+	nodeLocal bool
+}
+
+// nodeMatches returns whether the node selector matches the node, a nil selector matching all nodes.
+func nodeMatches(selector *v1.NodeSelector, node *v1.Node) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	ns, err := nodeaffinity.NewNodeSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return ns.Match(node), nil
+}
+
+// nodeDevices returns the devices of the slices available on the node. Only the slices of the latest generation
+// of the pools are used, and the pools not published completely yet are ignored.
+func nodeDevices(slices []*resourceapi.ResourceSlice, node *v1.Node) ([]*device, error) {
+	type poolID struct {
+		driver string
+		pool   string
+	}
+	pools := map[poolID][]*resourceapi.ResourceSlice{}
+	for _, slice := range slices {
+		id := poolID{driver: slice.Spec.Driver, pool: slice.Spec.Pool.Name}
+		if current := pools[id]; len(current) > 0 {
+			if current[0].Spec.Pool.Generation > slice.Spec.Pool.Generation {
+				continue
+			}
+			if current[0].Spec.Pool.Generation < slice.Spec.Pool.Generation {
+				current = nil
+			}
+			pools[id] = append(current, slice)
+			continue
+		}
+		pools[id] = []*resourceapi.ResourceSlice{slice}
+	}
+
+	var devices []*device
+	for _, poolSlices := range pools {
+		if int64(len(poolSlices)) != poolSlices[0].Spec.Pool.ResourceSliceCount {
+			continue
+		}
+		for _, slice := range poolSlices {
+			nodeLocal := !slice.Spec.AllNodes
+			switch {
+			case slice.Spec.AllNodes:
+			case slice.Spec.NodeName != "":
+				if slice.Spec.NodeName != node.Name {
+					continue
+				}
+			default:
+				matched, err := nodeMatches(slice.Spec.NodeSelector, node)
+				if err != nil {
+					return nil, fmt.Errorf("failed to match the node selector of resourceslice %s: %v", slice.Name, err)
+				}
+				if !matched {
+					continue
+				}
+			}
+			for i := range slice.Spec.Devices {
+				devices = append(devices, &device{
+					id: DeviceID{
+						Driver: slice.Spec.Driver,
+						Pool:   slice.Spec.Pool.Name,
+						Device: slice.Spec.Devices[i].Name,
+					},
+					basic:     slice.Spec.Devices[i].Basic,
+					nodeLocal: nodeLocal,
+				})
+			}
+		}
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].id.String() < devices[j].id.String()
+	})
+	return devices, nil
+}
+
+// allocator allocates the devices of the requests of a claim among the devices of a node.
+type allocator struct {
+	claim *resourceapi.ResourceClaim
+	// candidates are the devices matching each request and not in use
+	candidates [][]*device
+	// counts are the number of devices of each request
+	counts []int
+
+	picked    [][]*device
+	pickedSet sets.Set[DeviceID]
+}
+
+// allocate returns the allocation of the devices of the claim on the node, the devices in use being only
+// allocated to the requests with admin access.
+func allocate(claim *resourceapi.ResourceClaim, node *v1.Node, classes map[string]*resourceapi.DeviceClass,
+	devices []*device, inUse sets.Set[DeviceID]) (*resourceapi.AllocationResult, error) {
+	requests := claim.Spec.Devices.Requests
+	a := &allocator{
+		claim:      claim,
+		candidates: make([][]*device, len(requests)),
+		counts:     make([]int, len(requests)),
+		picked:     make([][]*device, len(requests)),
+		pickedSet:  sets.New[DeviceID](),
+	}
+
+	for i := range requests {
+		request := &requests[i]
+		class, found := classes[request.DeviceClassName]
+		if !found {
+			return nil, fmt.Errorf("deviceclass %s of request %s not found", request.DeviceClassName, request.Name)
+		}
+		matching := 0
+		for _, d := range devices {
+			matched, err := matchSelectors(class.Spec.Selectors, d)
+			if err == nil && matched {
+				matched, err = matchSelectors(request.Selectors, d)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("request %s: %v", request.Name, err)
+			}
+			if !matched {
+				continue
+			}
+			matching++
+			if request.AdminAccess || !inUse.Has(d.id) {
+				a.candidates[i] = append(a.candidates[i], d)
+			}
+		}
+
+		switch request.AllocationMode {
+		case resourceapi.DeviceAllocationModeAll:
+			if matching == 0 || len(a.candidates[i]) != matching {
+				return nil, fmt.Errorf("request %s needs all the %d matching devices, %d of them are available",
+					request.Name, matching, len(a.candidates[i]))
+			}
+			a.counts[i] = matching
+		case resourceapi.DeviceAllocationModeExactCount, "":
+			a.counts[i] = int(request.Count)
+			if a.counts[i] == 0 {
+				a.counts[i] = 1
+			}
+		default:
+			return nil, fmt.Errorf("request %s has unsupported allocation mode %s", request.Name, request.AllocationMode)
+		}
+		if len(a.candidates[i]) < a.counts[i] {
+			return nil, fmt.Errorf("request %s needs %d devices, %d of them are available",
+				request.Name, a.counts[i], len(a.candidates[i]))
+		}
+	}
+
+	if !a.pick(0, 0) {
+		return nil, fmt.Errorf("no devices satisfy the constraints of the requests")
+	}
+	return a.result(node, classes), nil
+}
+
+// pick picks the devices of the requests from the request i, its devices being picked in the order of the
+// candidates from the candidate start, and backtracks when the constraints are not satisfied.
+func (a *allocator) pick(i, start int) bool {
+	if i == len(a.counts) {
+		return true
+	}
+	if len(a.picked[i]) == a.counts[i] {
+		return a.pick(i+1, 0)
+	}
+	for c := start; c < len(a.candidates[i]); c++ {
+		d := a.candidates[i][c]
+		if a.pickedSet.Has(d.id) || !a.satisfiesConstraints(i, d) {
+			continue
+		}
+		a.picked[i] = append(a.picked[i], d)
+		a.pickedSet.Insert(d.id)
+		if a.pick(i, c+1) {
+			return true
+		}
+		a.picked[i] = a.picked[i][:len(a.picked[i])-1]
+		a.pickedSet.Delete(d.id)
+	}
+	return false
+}
+
+// satisfiesConstraints returns whether the device of the request i has the same attributes as the devices
+// already picked, as required by the matchAttribute constraints of the claim.
+func (a *allocator) satisfiesConstraints(i int, d *device) bool {
+	requestName := a.claim.Spec.Devices.Requests[i].Name
+	for _, constraint := range a.claim.Spec.Devices.Constraints {
+		if constraint.MatchAttribute == nil || !constrains(constraint, requestName) {
+			continue
+		}
+		value := attribute(d, *constraint.MatchAttribute)
+		if value == nil {
+			return false
+		}
+		for j, picked := range a.picked {
+			if !constrains(constraint, a.claim.Spec.Devices.Requests[j].Name) {
+				continue
+			}
+			for _, p := range picked {
+				if !equalAttributes(value, attribute(p, *constraint.MatchAttribute)) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// constrains returns whether the constraint applies to the request, the constraints without requests
+// applying to all of them.
+func constrains(constraint resourceapi.DeviceConstraint, request string) bool {
+	if len(constraint.Requests) == 0 {
+		return true
+	}
+	for _, r := range constraint.Requests {
+		if r == request {
+			return true
+		}
+	}
+	return false
+}
+
+// attribute returns the attribute of the device with the fully qualified name, nil if it has none.
+func attribute(d *device, name resourceapi.FullyQualifiedName) *resourceapi.DeviceAttribute {
+	if d.basic == nil {
+		return nil
+	}
+	domain, id := qualifiedName(d.id.Driver, string(name))
+	for attributeName, value := range d.basic.Attributes {
+		if attributeDomain, attributeID := qualifiedName(d.id.Driver, string(attributeName)); attributeDomain == domain && attributeID == id {
+			value := value
+			return &value
+		}
+	}
+	return nil
+}
+
+func equalAttributes(l, r *resourceapi.DeviceAttribute) bool {
+	if l == nil || r == nil {
+		return false
+	}
+	switch {
+	case l.IntValue != nil:
+		return r.IntValue != nil && *l.IntValue == *r.IntValue
+	case l.BoolValue != nil:
+		return r.BoolValue != nil && *l.BoolValue == *r.BoolValue
+	case l.StringValue != nil:
+		return r.StringValue != nil && *l.StringValue == *r.StringValue
+	case l.VersionValue != nil:
+		return r.VersionValue != nil && *l.VersionValue == *r.VersionValue
+	}
+	return false
+}
+
+// result returns the allocation of the picked devices with the configuration of their classes and of the claim.
+// The allocation is restricted to the node when a device is not available on all nodes.
+func (a *allocator) result(node *v1.Node, classes map[string]*resourceapi.DeviceClass) *resourceapi.AllocationResult {
+	allocation := &resourceapi.AllocationResult{}
+	nodeLocal := false
+	for i, picked := range a.picked {
+		request := &a.claim.Spec.Devices.Requests[i]
+		for _, d := range picked {
+			allocation.Devices.Results = append(allocation.Devices.Results, resourceapi.DeviceRequestAllocationResult{
+				Request: request.Name,
+				Driver:  d.id.Driver,
+				Pool:    d.id.Pool,
+				Device:  d.id.Device,
+			})
+			nodeLocal = nodeLocal || d.nodeLocal
+		}
+		for _, config := range classes[request.DeviceClassName].Spec.Config {
+			allocation.Devices.Config = append(allocation.Devices.Config, resourceapi.DeviceAllocationConfiguration{
+				Source:              resourceapi.AllocationConfigSourceClass,
+				Requests:            []string{request.Name},
+				DeviceConfiguration: config.DeviceConfiguration,
+			})
+		}
+	}
+	for _, config := range a.claim.Spec.Devices.Config {
+		allocation.Devices.Config = append(allocation.Devices.Config, resourceapi.DeviceAllocationConfiguration{
+			Source:              resourceapi.AllocationConfigSourceClaim,
+			Requests:            config.Requests,
+			DeviceConfiguration: config.DeviceConfiguration,
+		})
+	}
+	if nodeLocal {
+		allocation.NodeSelector = &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{{
+				MatchFields: []v1.NodeSelectorRequirement{{
+					Key:      "metadata.name",
+					Operator: v1.NodeSelectorOpIn,
+					Values:   []string{node.Name},
+				}},
+			}},
+		}
+	}
+	return allocation
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicresources
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	resourceinformers "k8s.io/client-go/informers/resource/v1alpha3"
+	"k8s.io/client-go/kubernetes"
+	resourcelisters "k8s.io/client-go/listers/resource/v1alpha3"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// PodClaims is the resource claims of a pod allocated on a node.
+type PodClaims struct {
+	// Claims are the claims of the pod with their allocation and reserved for the pod.
+	Claims []*resourceapi.ResourceClaim
+}
+
+// Binder allocates the devices of the resource claims of the pods from the ResourceSlices. The claims allocated
+// are assumed in the claim cache until their allocation is written by BindPodClaims or reverted by RevertPodClaims,
+// like the volumes of the volume binder.
+type Binder struct {
+	kubeClient  kubernetes.Interface
+	claimCache  *ClaimAssumeCache
+	classLister resourcelisters.DeviceClassLister
+	sliceLister resourcelisters.ResourceSliceLister
+}
+
+// NewBinder returns a binder of the resource claims.
+func NewBinder(logger klog.Logger, kubeClient kubernetes.Interface, claimInformer resourceinformers.ResourceClaimInformer,
+	classInformer resourceinformers.DeviceClassInformer, sliceInformer resourceinformers.ResourceSliceInformer) *Binder {
+	return &Binder{
+		kubeClient:  kubeClient,
+		claimCache:  NewClaimAssumeCache(logger, claimInformer.Informer()),
+		classLister: classInformer.Lister(),
+		sliceLister: sliceInformer.Lister(),
+	}
+}
+
+// claimName returns the name of the claim of the pod, an empty name if the pod needs no claim for it.
+func claimName(pod *v1.Pod, podClaim *v1.PodResourceClaim) (string, bool, error) {
+	if podClaim.ResourceClaimName != nil {
+		return *podClaim.ResourceClaimName, false, nil
+	}
+	if podClaim.ResourceClaimTemplateName == nil {
+		return "", false, fmt.Errorf("resource claim %s of pod %s/%s has neither a claim nor a template", podClaim.Name, pod.Namespace, pod.Name)
+	}
+	for _, status := range pod.Status.ResourceClaimStatuses {
+		if status.Name == podClaim.Name {
+			if status.ResourceClaimName == nil {
+				return "", false, nil
+			}
+			return *status.ResourceClaimName, true, nil
+		}
+	}
+	return "", false, fmt.Errorf("resourceclaim of %s is not created yet for pod %s/%s", podClaim.Name, pod.Namespace, pod.Name)
+}
+
+func reservedFor(claim *resourceapi.ResourceClaim, pod *v1.Pod) bool {
+	for _, consumer := range claim.Status.ReservedFor {
+		if consumer.UID == pod.UID {
+			return true
+		}
+	}
+	return false
+}
+
+func podReference(pod *v1.Pod) resourceapi.ResourceClaimConsumerReference {
+	return resourceapi.ResourceClaimConsumerReference{Resource: "pods", Name: pod.Name, UID: pod.UID}
+}
+
+// GetPodClaims returns the claims of the pod allocated on the node, and an error if they may not be allocated
+// on it. The claims already allocated must be allocated on the node, the others are allocated with the devices
+// of the node which are not allocated to other claims. It returns nil if the pod has no claims.
+func (b *Binder) GetPodClaims(pod *v1.Pod, node *v1.Node) (*PodClaims, error) {
+	if len(pod.Spec.ResourceClaims) == 0 {
+		return nil, nil
+	}
+
+	podClaims := &PodClaims{}
+	var devices []*device
+	var inUse sets.Set[DeviceID]
+	var classes map[string]*resourceapi.DeviceClass
+	for i := range pod.Spec.ResourceClaims {
+		name, fromTemplate, err := claimName(pod, &pod.Spec.ResourceClaims[i])
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			continue
+		}
+		claim, err := b.claimCache.GetClaim(pod.Namespace + "/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resourceclaim %s/%s: %v", pod.Namespace, name, err)
+		}
+		if claim.DeletionTimestamp != nil {
+			return nil, fmt.Errorf("resourceclaim %s/%s is being deleted", claim.Namespace, claim.Name)
+		}
+		if fromTemplate && !metav1.IsControlledBy(claim, pod) {
+			return nil, fmt.Errorf("resourceclaim %s/%s was not created for pod %s/%s", claim.Namespace, claim.Name, pod.Namespace, pod.Name)
+		}
+		claim = claim.DeepCopy()
+
+		if claim.Status.Allocation != nil {
+			if claim.Status.DeallocationRequested {
+				return nil, fmt.Errorf("resourceclaim %s/%s is being deallocated", claim.Namespace, claim.Name)
+			}
+			if !reservedFor(claim, pod) && len(claim.Status.ReservedFor) >= resourceapi.ResourceClaimReservedForMaxSize {
+				return nil, fmt.Errorf("resourceclaim %s/%s is reserved for too many pods", claim.Namespace, claim.Name)
+			}
+			matched, err := nodeMatches(claim.Status.Allocation.NodeSelector, node)
+			if err != nil {
+				return nil, fmt.Errorf("failed to match the allocation of resourceclaim %s/%s: %v", claim.Namespace, claim.Name, err)
+			}
+			if !matched {
+				return nil, fmt.Errorf("resourceclaim %s/%s is allocated on other nodes", claim.Namespace, claim.Name)
+			}
+		} else {
+			if claim.Spec.Controller != "" {
+				return nil, fmt.Errorf("resourceclaim %s/%s is allocated by controller %s which is not supported",
+					claim.Namespace, claim.Name, claim.Spec.Controller)
+			}
+			if devices == nil {
+				if devices, inUse, classes, err = b.nodeState(node); err != nil {
+					return nil, err
+				}
+			}
+			allocation, err := allocate(claim, node, classes, devices, inUse)
+			if err != nil {
+				return nil, fmt.Errorf("failed to allocate resourceclaim %s/%s: %v", claim.Namespace, claim.Name, err)
+			}
+			claim.Status.Allocation = allocation
+			insertInUse(inUse, claim)
+		}
+
+		if !reservedFor(claim, pod) {
+			claim.Status.ReservedFor = append(claim.Status.ReservedFor, podReference(pod))
+		}
+		podClaims.Claims = append(podClaims.Claims, claim)
+	}
+	return podClaims, nil
+}
+
+// nodeState returns the devices available on the node, the devices allocated to the claims and the device classes.
+func (b *Binder) nodeState(node *v1.Node) ([]*device, sets.Set[DeviceID], map[string]*resourceapi.DeviceClass, error) {
+	slices, err := b.sliceLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list resourceslices: %v", err)
+	}
+	devices, err := nodeDevices(slices, node)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	inUse := sets.New[DeviceID]()
+	for _, claim := range b.claimCache.ListClaims() {
+		insertInUse(inUse, claim)
+	}
+
+	classList, err := b.classLister.List(labels.Everything())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list deviceclasses: %v", err)
+	}
+	classes := make(map[string]*resourceapi.DeviceClass, len(classList))
+	for _, class := range classList {
+		classes[class.Name] = class
+	}
+	return devices, inUse, classes, nil
+}
+
+// insertInUse inserts the devices allocated to the claim without admin access.
+func insertInUse(inUse sets.Set[DeviceID], claim *resourceapi.ResourceClaim) {
+	if claim.Status.Allocation == nil {
+		return
+	}
+	adminAccess := sets.New[string]()
+	for _, request := range claim.Spec.Devices.Requests {
+		if request.AdminAccess {
+			adminAccess.Insert(request.Name)
+		}
+	}
+	for _, result := range claim.Status.Allocation.Devices.Results {
+		if !adminAccess.Has(result.Request) {
+			inUse.Insert(DeviceID{Driver: result.Driver, Pool: result.Pool, Device: result.Device})
+		}
+	}
+}
+
+// AssumePodClaims assumes the claims allocated in the claim cache, so that their devices are not allocated
+// to other claims.
+func (b *Binder) AssumePodClaims(podClaims *PodClaims) error {
+	if podClaims == nil {
+		return nil
+	}
+	for i, claim := range podClaims.Claims {
+		if err := b.claimCache.Assume(claim); err != nil {
+			b.restore(podClaims.Claims[:i])
+			return fmt.Errorf("failed to assume resourceclaim %s/%s: %v", claim.Namespace, claim.Name, err)
+		}
+	}
+	return nil
+}
+
+// RevertPodClaims restores the claims assumed by AssumePodClaims.
+func (b *Binder) RevertPodClaims(podClaims *PodClaims) {
+	if podClaims == nil {
+		return
+	}
+	b.restore(podClaims.Claims)
+}
+
+func (b *Binder) restore(claims []*resourceapi.ResourceClaim) {
+	for _, claim := range claims {
+		key, err := cache.MetaNamespaceKeyFunc(claim)
+		if err != nil {
+			continue
+		}
+		b.claimCache.Restore(key)
+	}
+}
+
+// BindPodClaims writes the allocation of the claims and their reservation for the pod to the API server,
+// protecting the allocated claims from deletion with a finalizer.
+func (b *Binder) BindPodClaims(ctx context.Context, pod *v1.Pod, podClaims *PodClaims) error {
+	if podClaims == nil {
+		return nil
+	}
+	for _, assumed := range podClaims.Claims {
+		claim, err := b.claimCache.GetAPIClaim(assumed.Namespace + "/" + assumed.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get resourceclaim %s/%s: %v", assumed.Namespace, assumed.Name, err)
+		}
+		if claim.Status.Allocation != nil && reservedFor(claim, pod) {
+			continue
+		}
+
+		claim = claim.DeepCopy()
+		if claim.Status.Allocation == nil {
+			if !hasFinalizer(claim) {
+				claim.Finalizers = append(claim.Finalizers, resourceapi.Finalizer)
+				if claim, err = b.kubeClient.ResourceV1alpha3().ResourceClaims(claim.Namespace).Update(ctx, claim, metav1.UpdateOptions{}); err != nil {
+					return fmt.Errorf("failed to add the finalizer of resourceclaim %s/%s: %v", assumed.Namespace, assumed.Name, err)
+				}
+			}
+			claim.Status.Allocation = assumed.Status.Allocation
+		}
+		if !reservedFor(claim, pod) {
+			claim.Status.ReservedFor = append(claim.Status.ReservedFor, podReference(pod))
+		}
+		updated, err := b.kubeClient.ResourceV1alpha3().ResourceClaims(claim.Namespace).UpdateStatus(ctx, claim, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update the status of resourceclaim %s/%s: %v", claim.Namespace, claim.Name, err)
+		}
+		if err := b.claimCache.Assume(updated); err != nil {
+			klog.V(4).Infof("Failed to assume the updated resourceclaim %s/%s: %v", claim.Namespace, claim.Name, err)
+		}
+	}
+	return nil
+}
+
+func hasFinalizer(claim *resourceapi.ResourceClaim) bool {
+	for _, finalizer := range claim.Finalizers {
+		if finalizer == resourceapi.Finalizer {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicresources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1alpha3"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/klog/v2"
+)
+
+const driver = "gpu.example.com"
+
+func buildNode(name string) *v1.Node {
+	return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func buildClass(name, expression string) *resourceapi.DeviceClass {
+	return &resourceapi.DeviceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: resourceapi.DeviceClassSpec{
+			Selectors: []resourceapi.DeviceSelector{{CEL: &resourceapi.CELDeviceSelector{Expression: expression}}},
+		},
+	}
+}
+
+// buildSlice returns the slice of the gpus of the node, in the numa node and with the memory of their values.
+func buildSlice(node string, gpus map[string][2]string) *resourceapi.ResourceSlice {
+	slice := &resourceapi.ResourceSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: node + "-gpus"},
+		Spec: resourceapi.ResourceSliceSpec{
+			Driver:   driver,
+			Pool:     resourceapi.ResourcePool{Name: node, Generation: 1, ResourceSliceCount: 1},
+			NodeName: node,
+		},
+	}
+	for name, gpu := range gpus {
+		numa := gpu[0]
+		slice.Spec.Devices = append(slice.Spec.Devices, resourceapi.Device{
+			Name: name,
+			Basic: &resourceapi.BasicDevice{
+				Attributes: map[resourceapi.QualifiedName]resourceapi.DeviceAttribute{"numa": {StringValue: &numa}},
+				Capacity:   map[resourceapi.QualifiedName]resource.Quantity{"memory": resource.MustParse(gpu[1])},
+			},
+		})
+	}
+	return slice
+}
+
+func buildClaim(name string, requests []resourceapi.DeviceRequest, constraints ...resourceapi.DeviceConstraint) *resourceapi.ResourceClaim {
+	return &resourceapi.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: name, UID: types.UID(name), ResourceVersion: "1"},
+		Spec: resourceapi.ResourceClaimSpec{
+			Devices: resourceapi.DeviceClaim{Requests: requests, Constraints: constraints},
+		},
+	}
+}
+
+func buildRequest(name string, count int64, expression string) resourceapi.DeviceRequest {
+	request := resourceapi.DeviceRequest{
+		Name:            name,
+		DeviceClassName: "gpu",
+		AllocationMode:  resourceapi.DeviceAllocationModeExactCount,
+		Count:           count,
+	}
+	if expression != "" {
+		request.Selectors = []resourceapi.DeviceSelector{{CEL: &resourceapi.CELDeviceSelector{Expression: expression}}}
+	}
+	return request
+}
+
+func buildPod(name string, claims ...string) *v1.Pod {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: name, UID: types.UID(name)}}
+	for _, claim := range claims {
+		claim := claim
+		pod.Spec.ResourceClaims = append(pod.Spec.ResourceClaims, v1.PodResourceClaim{Name: claim, ResourceClaimName: &claim})
+	}
+	return pod
+}
+
+// newTestBinder returns a binder with the gpu class and the gpus of n1 and n2.
+func newTestBinder(t *testing.T, claims ...*resourceapi.ResourceClaim) (*Binder, *fake.Clientset) {
+	objects := []runtime.Object{
+		buildClass("gpu", `device.driver == "gpu.example.com"`),
+		buildSlice("n1", map[string][2]string{"gpu-0": {"0", "16Gi"}, "gpu-1": {"1", "32Gi"}, "gpu-2": {"1", "16Gi"}}),
+		buildSlice("n2", map[string][2]string{"gpu-0": {"0", "16Gi"}}),
+	}
+	for _, claim := range claims {
+		objects = append(objects, claim)
+	}
+	client := fake.NewSimpleClientset(objects...)
+	factory := informers.NewSharedInformerFactory(client, 0)
+	resourceInformers := factory.Resource().V1alpha3()
+	binder := NewBinder(klog.Background(), client, resourceInformers.ResourceClaims(), resourceInformers.DeviceClasses(), resourceInformers.ResourceSlices())
+
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	factory.Start(stop)
+	factory.WaitForCacheSync(stop)
+	return binder, client
+}
+
+func allocatedDevices(podClaims *PodClaims) []string {
+	var devices []string
+	for _, claim := range podClaims.Claims {
+		for _, result := range claim.Status.Allocation.Devices.Results {
+			devices = append(devices, result.Request+":"+result.Device)
+		}
+	}
+	return devices
+}
+
+func TestGetPodClaims(t *testing.T) {
+	tests := []struct {
+		name     string
+		claim    *resourceapi.ResourceClaim
+		node     string
+		expected []string
+		err      string
+	}{
+		{
+			name:     "the devices are allocated on the node",
+			claim:    buildClaim("claim", []resourceapi.DeviceRequest{buildRequest("gpus", 2, "")}),
+			node:     "n1",
+			expected: []string{"gpus:gpu-0", "gpus:gpu-1"},
+		},
+		{
+			name:  "the node has not enough devices",
+			claim: buildClaim("claim", []resourceapi.DeviceRequest{buildRequest("gpus", 2, "")}),
+			node:  "n2",
+			err:   "needs 2 devices, 1 of them are available",
+		},
+		{
+			name: "the devices match the selectors of the request",
+			claim: buildClaim("claim", []resourceapi.DeviceRequest{
+				buildRequest("gpu", 1, `device.capacity["gpu.example.com"].memory.compareTo(quantity("32Gi")) >= 0`),
+			}),
+			node:     "n1",
+			expected: []string{"gpu:gpu-1"},
+		},
+		{
+			name: "all the devices matching the request are allocated",
+			claim: buildClaim("claim", []resourceapi.DeviceRequest{{
+				Name:            "gpus",
+				DeviceClassName: "gpu",
+				AllocationMode:  resourceapi.DeviceAllocationModeAll,
+				Selectors: []resourceapi.DeviceSelector{{CEL: &resourceapi.CELDeviceSelector{
+					Expression: `device.attributes["gpu.example.com"].numa == "1"`,
+				}}},
+			}}),
+			node:     "n1",
+			expected: []string{"gpus:gpu-1", "gpus:gpu-2"},
+		},
+		{
+			name: "the devices of the requests have the same attribute",
+			claim: buildClaim("claim",
+				[]resourceapi.DeviceRequest{buildRequest("first", 1, ""), buildRequest("second", 1, "")},
+				resourceapi.DeviceConstraint{MatchAttribute: func() *resourceapi.FullyQualifiedName {
+					name := resourceapi.FullyQualifiedName("gpu.example.com/numa")
+					return &name
+				}()}),
+			node:     "n1",
+			expected: []string{"first:gpu-1", "second:gpu-2"},
+		},
+		{
+			name:  "the device class does not exist",
+			claim: buildClaim("claim", []resourceapi.DeviceRequest{{Name: "fpga", DeviceClassName: "fpga"}}),
+			node:  "n1",
+			err:   "deviceclass fpga of request fpga not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			binder, _ := newTestBinder(t, test.claim)
+			podClaims, err := binder.GetPodClaims(buildPod("p1", "claim"), buildNode(test.node))
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if devices := allocatedDevices(podClaims); strings.Join(devices, ",") != strings.Join(test.expected, ",") {
+				t.Errorf("expected devices %v, got %v", test.expected, devices)
+			}
+			claim := podClaims.Claims[0]
+			if len(claim.Status.ReservedFor) != 1 || claim.Status.ReservedFor[0].UID != "p1" {
+				t.Errorf("expected the claim to be reserved for p1, got %v", claim.Status.ReservedFor)
+			}
+			if matched, _ := nodeMatches(claim.Status.Allocation.NodeSelector, buildNode("n2")); matched {
+				t.Errorf("expected the allocation to be restricted to %s", test.node)
+			}
+		})
+	}
+}
+
+func TestAssumeAndRevertPodClaims(t *testing.T) {
+	all := buildRequest("gpus", 3, "")
+	binder, _ := newTestBinder(t, buildClaim("claim1", []resourceapi.DeviceRequest{all}), buildClaim("claim2", []resourceapi.DeviceRequest{all}))
+	node := buildNode("n1")
+
+	podClaims, err := binder.GetPodClaims(buildPod("p1", "claim1"), node)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := binder.AssumePodClaims(podClaims); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := binder.GetPodClaims(buildPod("p2", "claim2"), node); err == nil {
+		t.Errorf("expected the devices assumed for claim1 not to be allocated to claim2")
+	}
+	// The pods sharing the claim use its allocation.
+	if _, err := binder.GetPodClaims(buildPod("p3", "claim1"), node); err != nil {
+		t.Errorf("expected the pods sharing claim1 to use its devices, got %v", err)
+	}
+	if _, err := binder.GetPodClaims(buildPod("p3", "claim1"), buildNode("n2")); err == nil {
+		t.Errorf("expected claim1 allocated on n1 not to be used on n2")
+	}
+
+	binder.RevertPodClaims(podClaims)
+	if _, err := binder.GetPodClaims(buildPod("p2", "claim2"), node); err != nil {
+		t.Errorf("expected the devices reverted to be allocated to claim2, got %v", err)
+	}
+}
+
+func TestBindPodClaims(t *testing.T) {
+	binder, client := newTestBinder(t, buildClaim("claim", []resourceapi.DeviceRequest{buildRequest("gpu", 1, "")}))
+	pod := buildPod("p1", "claim")
+	podClaims, err := binder.GetPodClaims(pod, buildNode("n2"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := binder.AssumePodClaims(podClaims); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := binder.BindPodClaims(context.TODO(), pod, podClaims); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claim, err := client.ResourceV1alpha3().ResourceClaims("c1").Get(context.TODO(), "claim", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasFinalizer(claim) {
+		t.Errorf("expected the allocated claim to have the finalizer %s", resourceapi.Finalizer)
+	}
+	if claim.Status.Allocation == nil || len(claim.Status.Allocation.Devices.Results) != 1 ||
+		claim.Status.Allocation.Devices.Results[0].Pool != "n2" {
+		t.Errorf("expected the claim to be allocated a gpu of n2, got %v", claim.Status.Allocation)
+	}
+	if !reservedFor(claim, pod) {
+		t.Errorf("expected the claim to be reserved for the pod, got %v", claim.Status.ReservedFor)
+	}
+}
+
+func TestClaimFromTemplate(t *testing.T) {
+	binder, _ := newTestBinder(t)
+	template := "template"
+	pod := buildPod("p1")
+	pod.Spec.ResourceClaims = []v1.PodResourceClaim{{Name: "gpu", ResourceClaimTemplateName: &template}}
+
+	if _, err := binder.GetPodClaims(pod, buildNode("n1")); err == nil || !strings.Contains(err.Error(), "not created yet") {
+		t.Errorf("expected the claim not created yet to fail, got %v", err)
+	}
+	pod.Status.ResourceClaimStatuses = []v1.PodResourceClaimStatus{{Name: "gpu"}}
+	if podClaims, err := binder.GetPodClaims(pod, buildNode("n1")); err != nil || len(podClaims.Claims) != 0 {
+		t.Errorf("expected the pod to need no claim, got %v, %v", podClaims, err)
+	}
+}
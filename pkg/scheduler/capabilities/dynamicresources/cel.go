@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicresources
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	resourceapi "k8s.io/api/resource/v1alpha3"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/library"
+)
+
+// deviceVar is the variable of the device in the CEL selectors.
+const deviceVar = "device"
+
+var (
+	celEnvOnce sync.Once
+	celEnv     *cel.Env
+	celEnvErr  error
+
+	// selectors caches the compiled selectors by expression.
+	selectors sync.Map
+)
+
+// selector is a compiled CEL selector.
+type selector struct {
+	program cel.Program
+	err     error
+}
+
+func getCELEnv() (*cel.Env, error) {
+	celEnvOnce.Do(func() {
+		celEnv, celEnvErr = cel.NewEnv(
+			cel.Variable(deviceVar, cel.DynType),
+			library.Quantity(),
+		)
+	})
+	return celEnv, celEnvErr
+}
+
+// compileSelector returns the program of the CEL expression, compiled once.
+func compileSelector(expression string) (cel.Program, error) {
+	if cached, found := selectors.Load(expression); found {
+		s := cached.(*selector)
+		return s.program, s.err
+	}
+
+	s := &selector{}
+	env, err := getCELEnv()
+	if err != nil {
+		s.err = err
+	} else if ast, issues := env.Compile(expression); issues != nil && issues.Err() != nil {
+		s.err = fmt.Errorf("failed to compile CEL selector %q: %v", expression, issues.Err())
+	} else if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		s.err = fmt.Errorf("CEL selector %q must return a bool, got %v", expression, ast.OutputType())
+	} else {
+		s.program, s.err = env.Program(ast)
+	}
+	selectors.Store(expression, s)
+	return s.program, s.err
+}
+
+// qualifiedName returns the domain and the id of the attribute or capacity name of a device of the driver,
+// the names without domain being in the domain of the driver.
+func qualifiedName(driver, name string) (string, string) {
+	if i := strings.Index(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return driver, name
+}
+
+// deviceValue returns the device as seen by the CEL selectors: device.driver, device.attributes[domain][id]
+// and device.capacity[domain][id]. The version attributes are seen as strings.
+func deviceValue(driver string, device *resourceapi.BasicDevice) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	capacity := map[string]interface{}{}
+	if device != nil {
+		for name, attribute := range device.Attributes {
+			domain, id := qualifiedName(driver, string(name))
+			if _, found := attributes[domain]; !found {
+				attributes[domain] = map[string]interface{}{}
+			}
+			var value interface{}
+			switch {
+			case attribute.IntValue != nil:
+				value = *attribute.IntValue
+			case attribute.BoolValue != nil:
+				value = *attribute.BoolValue
+			case attribute.StringValue != nil:
+				value = *attribute.StringValue
+			case attribute.VersionValue != nil:
+				value = *attribute.VersionValue
+			default:
+				continue
+			}
+			attributes[domain].(map[string]interface{})[id] = value
+		}
+		for name, quantity := range device.Capacity {
+			domain, id := qualifiedName(driver, string(name))
+			if _, found := capacity[domain]; !found {
+				capacity[domain] = map[string]interface{}{}
+			}
+			q := quantity.DeepCopy()
+			capacity[domain].(map[string]interface{})[id] = apiservercel.Quantity{Quantity: &q}
+		}
+	}
+	return map[string]interface{}{
+		"driver":     driver,
+		"attributes": attributes,
+		"capacity":   capacity,
+	}
+}
+
+// matchSelectors returns whether the device matches all the selectors. A selector failing to evaluate on the
+// device, e.g. on a missing attribute, does not match it.
+func matchSelectors(selectors []resourceapi.DeviceSelector, d *device) (bool, error) {
+	var value map[string]interface{}
+	for _, s := range selectors {
+		if s.CEL == nil {
+			continue
+		}
+		program, err := compileSelector(s.CEL.Expression)
+		if err != nil {
+			return false, err
+		}
+		if value == nil {
+			value = deviceValue(d.id.Driver, d.basic)
+		}
+		out, _, err := program.Eval(map[string]interface{}{deviceVar: value})
+		if err != nil {
+			return false, nil
+		}
+		if matched, ok := out.Value().(bool); !ok || !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicresources
+
+import (
+	resourceapi "k8s.io/api/resource/v1alpha3"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/util/assumecache"
+)
+
+// ClaimAssumeCache is an AssumeCache for ResourceClaim objects, the claims allocated by the scheduler are assumed
+// in it until their allocation is written to the API server.
+type ClaimAssumeCache struct {
+	*assumecache.AssumeCache
+}
+
+// NewClaimAssumeCache creates a ResourceClaim assume cache.
+func NewClaimAssumeCache(logger klog.Logger, informer assumecache.Informer) *ClaimAssumeCache {
+	logger = klog.LoggerWithName(logger, "ResourceClaim Cache")
+	return &ClaimAssumeCache{
+		AssumeCache: assumecache.NewAssumeCache(logger, informer, "ResourceClaim", "", nil),
+	}
+}
+
+// GetClaim returns the claim of the key, namespace/name, assumed or from the informer.
+func (c *ClaimAssumeCache) GetClaim(key string) (*resourceapi.ResourceClaim, error) {
+	obj, err := c.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	claim, ok := obj.(*resourceapi.ResourceClaim)
+	if !ok {
+		return nil, &assumecache.WrongTypeError{TypeName: "ResourceClaim", Object: obj}
+	}
+	return claim, nil
+}
+
+// GetAPIClaim returns the claim of the key from the informer.
+func (c *ClaimAssumeCache) GetAPIClaim(key string) (*resourceapi.ResourceClaim, error) {
+	obj, err := c.GetAPIObj(key)
+	if err != nil {
+		return nil, err
+	}
+	claim, ok := obj.(*resourceapi.ResourceClaim)
+	if !ok {
+		return nil, &assumecache.WrongTypeError{TypeName: "ResourceClaim", Object: obj}
+	}
+	return claim, nil
+}
+
+// ListClaims returns all the claims, assumed or from the informer.
+func (c *ClaimAssumeCache) ListClaims() []*resourceapi.ResourceClaim {
+	objs := c.List(nil)
+	claims := make([]*resourceapi.ResourceClaim, 0, len(objs))
+	for _, obj := range objs {
+		if claim, ok := obj.(*resourceapi.ResourceClaim); ok {
+			claims = append(claims, claim)
+		}
+	}
+	return claims
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// ConfAppliedResourceVersionAnnotation is the resourceVersion of the ConfigMap whose
+	// scheduler configuration is applied by the scheduler.
+	ConfAppliedResourceVersionAnnotation = "volcano.sh/scheduler-conf-applied-resource-version"
+	// ConfStatusAnnotation is the result of loading the latest scheduler configuration of the ConfigMap.
+	ConfStatusAnnotation = "volcano.sh/scheduler-conf-status"
+	// ConfMessageAnnotation is the reason the latest scheduler configuration of the ConfigMap is rejected.
+	ConfMessageAnnotation = "volcano.sh/scheduler-conf-message"
+	// ConfLastKnownGoodAnnotation is the latest scheduler configuration of the ConfigMap that was applied.
+	ConfLastKnownGoodAnnotation = "volcano.sh/scheduler-conf-last-known-good"
+	// ConfRollbackAnnotation set to "true" makes the scheduler restore the last known good
+	// configuration into the ConfigMap, the annotation is removed afterwards.
+	ConfRollbackAnnotation = "volcano.sh/scheduler-conf-rollback"
+
+	// ConfApplied is the status of a configuration the scheduler applied.
+	ConfApplied = "Applied"
+	// ConfRejected is the status of a configuration the scheduler rejected, the previous one is kept.
+	ConfRejected = "Rejected"
+)
+
+// configMapConfSource watches the scheduler configuration held by a ConfigMap through the API server,
+// so that a change is applied without waiting for the kubelet to update the mounted file.
+type configMapConfSource struct {
+	namespace string
+	name      string
+	key       string
+	client    kubernetes.Interface
+
+	mutex sync.Mutex
+	// exists is true while the ConfigMap exists, the file configuration is ignored meanwhile.
+	exists bool
+}
+
+func newConfigMapConfSource(configMap, key string, client kubernetes.Interface) *configMapConfSource {
+	// The format is checked with the options.
+	namespace, name, _ := cache.SplitMetaNamespaceKey(configMap)
+	return &configMapConfSource{
+		namespace: namespace,
+		name:      name,
+		key:       key,
+		client:    client,
+	}
+}
+
+func (cs *configMapConfSource) String() string {
+	return fmt.Sprintf("configmap %s/%s", cs.namespace, cs.name)
+}
+
+func (cs *configMapConfSource) setExists(exists bool) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.exists = exists
+}
+
+// Exists returns whether the ConfigMap exists.
+func (cs *configMapConfSource) Exists() bool {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	return cs.exists
+}
+
+// patch merges the data and the annotations into the ConfigMap, a nil annotation is removed.
+func (cs *configMapConfSource) patch(data map[string]interface{}, annotations map[string]interface{}) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	}
+	if data != nil {
+		patch["data"] = data
+	}
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	_, err = cs.client.CoreV1().ConfigMaps(cs.namespace).Patch(context.TODO(), cs.name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
+
+// watchSchedulerConfMap watches the ConfigMap of the scheduler configuration until stopCh is closed.
+func (pc *Scheduler) watchSchedulerConfMap(stopCh <-chan struct{}) {
+	cs := pc.confMap
+	factory := informers.NewSharedInformerFactoryWithOptions(cs.client, 0,
+		informers.WithNamespace(cs.namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", cs.name).String()
+		}))
+	informer := factory.Core().V1().ConfigMaps().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if cm, ok := obj.(*v1.ConfigMap); ok {
+				pc.onSchedulerConfMap(cm)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCM, ok := oldObj.(*v1.ConfigMap)
+			if !ok {
+				return
+			}
+			newCM, ok := newObj.(*v1.ConfigMap)
+			if !ok {
+				return
+			}
+			// Skip the updates of the status annotations written by the scheduler itself.
+			if reflect.DeepEqual(oldCM.Data, newCM.Data) &&
+				oldCM.Annotations[ConfRollbackAnnotation] == newCM.Annotations[ConfRollbackAnnotation] {
+				return
+			}
+			pc.onSchedulerConfMap(newCM)
+		},
+		DeleteFunc: func(obj interface{}) {
+			pc.onSchedulerConfMapDeleted()
+		},
+	})
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+	klog.V(2).Infof("Watching the Scheduler conf in %s", cs)
+}
+
+// onSchedulerConfMap applies the configuration of the ConfigMap, or rolls it back if requested.
+func (pc *Scheduler) onSchedulerConfMap(cm *v1.ConfigMap) {
+	cs := pc.confMap
+	cs.setExists(true)
+
+	if cm.Annotations[ConfRollbackAnnotation] == "true" {
+		pc.rollbackSchedulerConfMap(cm)
+		return
+	}
+
+	config, found := cm.Data[cs.key]
+	if !found {
+		pc.rejectSchedulerConfMap(cm, fmt.Errorf("key %s not found", cs.key))
+		return
+	}
+	actions, plugins, configurations, metricsConf, err := UnmarshalSchedulerConf(strings.TrimSpace(config))
+	if err != nil {
+		pc.rejectSchedulerConfMap(cm, err)
+		return
+	}
+
+	pc.mutex.Lock()
+	pc.actions = actions
+	pc.plugins = plugins
+	pc.configurations = configurations
+	pc.metricsConf = metricsConf
+	pc.mutex.Unlock()
+	pc.cache.SetMetricsConf(metricsConf)
+	metrics.UpdateSchedulerConfReload(metrics.SchedulerConf, true)
+	klog.V(2).Infof("Successfully loaded Scheduler conf of %s at resourceVersion %s", cs, cm.ResourceVersion)
+
+	err = cs.patch(nil, map[string]interface{}{
+		ConfAppliedResourceVersionAnnotation: cm.ResourceVersion,
+		ConfStatusAnnotation:                 ConfApplied,
+		ConfMessageAnnotation:                nil,
+		ConfLastKnownGoodAnnotation:          config,
+	})
+	if err != nil {
+		klog.Errorf("Failed to record the applied Scheduler conf in %s: %v", cs, err)
+	}
+}
+
+// rejectSchedulerConfMap keeps the previous configuration and records why the configuration of the ConfigMap is rejected.
+func (pc *Scheduler) rejectSchedulerConfMap(cm *v1.ConfigMap, err error) {
+	cs := pc.confMap
+	klog.Errorf("Scheduler conf of %s at resourceVersion %s is invalid, using previous configuration: %v", cs, cm.ResourceVersion, err)
+	pc.recordConfReloadFailure(metrics.SchedulerConf, cs.String(), err)
+
+	patchErr := cs.patch(nil, map[string]interface{}{
+		ConfStatusAnnotation:  ConfRejected,
+		ConfMessageAnnotation: fmt.Sprintf("resourceVersion %s: %v", cm.ResourceVersion, err),
+	})
+	if patchErr != nil {
+		klog.Errorf("Failed to record the rejected Scheduler conf in %s: %v", cs, patchErr)
+	}
+}
+
+// rollbackSchedulerConfMap restores the last known good configuration into the ConfigMap,
+// the update of the ConfigMap applies it.
+func (pc *Scheduler) rollbackSchedulerConfMap(cm *v1.ConfigMap) {
+	cs := pc.confMap
+	annotations := map[string]interface{}{ConfRollbackAnnotation: nil}
+	var data map[string]interface{}
+
+	lastKnownGood, found := cm.Annotations[ConfLastKnownGoodAnnotation]
+	if found {
+		klog.V(2).Infof("Rolling back the Scheduler conf of %s to the last known good configuration", cs)
+		data = map[string]interface{}{cs.key: lastKnownGood}
+	} else {
+		err := fmt.Errorf("no last known good configuration to roll back to")
+		klog.Errorf("Failed to roll back the Scheduler conf of %s: %v", cs, err)
+		annotations[ConfStatusAnnotation] = ConfRejected
+		annotations[ConfMessageAnnotation] = err.Error()
+	}
+
+	if err := cs.patch(data, annotations); err != nil {
+		klog.Errorf("Failed to roll back the Scheduler conf of %s: %v", cs, err)
+	}
+}
+
+// onSchedulerConfMapDeleted falls back to the configuration of the file.
+func (pc *Scheduler) onSchedulerConfMapDeleted() {
+	pc.confMap.setExists(false)
+	klog.V(2).Infof("The %s of the Scheduler conf is deleted, falling back to the Scheduler conf file", pc.confMap)
+	pc.loadSchedulerConf()
+	pc.cache.SetMetricsConf(pc.metricsConf)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+)
+
+func TestSchedulerConfMap(t *testing.T) {
+	const key = "volcano-scheduler.conf"
+	goodConf := `
+actions: "enqueue, allocate"
+tiers:
+- plugins:
+  - name: gang
+`
+	badConf := `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gangg
+`
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "volcano-system", Name: "volcano-scheduler-configmap", ResourceVersion: "1"},
+		Data:       map[string]string{key: goodConf},
+	}
+	client := fake.NewSimpleClientset(cm)
+	pc := &Scheduler{
+		cache:   schedcache.NewCustomMockSchedulerCache("volcano", nil, nil, nil, nil, nil, record.NewFakeRecorder(10)),
+		confMap: newConfigMapConfSource("volcano-system/volcano-scheduler-configmap", key, client),
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	getConfigMap := func() *v1.ConfigMap {
+		cm, err := client.CoreV1().ConfigMaps("volcano-system").Get(context.TODO(), "volcano-scheduler-configmap", metav1.GetOptions{})
+		assert.NoError(t, err)
+		return cm
+	}
+	waitFor := func(desc string, condition func() bool) {
+		err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+			return condition(), nil
+		})
+		if err != nil {
+			t.Fatalf("timed out waiting for %s", desc)
+		}
+	}
+	waitForStatus := func(status string) *v1.ConfigMap {
+		var cm *v1.ConfigMap
+		waitFor("status "+status, func() bool {
+			cm = getConfigMap()
+			return cm.Annotations[ConfStatusAnnotation] == status
+		})
+		return cm
+	}
+
+	// The ConfigMap is applied and the file configuration is ignored.
+	pc.watchSchedulerConfMap(stopCh)
+	cm = waitForStatus(ConfApplied)
+	assert.Equal(t, "1", cm.Annotations[ConfAppliedResourceVersionAnnotation])
+	assert.Equal(t, goodConf, cm.Annotations[ConfLastKnownGoodAnnotation])
+	assert.True(t, pc.confMap.Exists())
+	actions, plugins := pc.getSchedulerConf()
+	assert.Equal(t, []string{"enqueue", "allocate"}, actions)
+	assert.Equal(t, []string{"gang"}, plugins)
+
+	// An invalid configuration is rejected and the previous one is kept.
+	cm.Data[key] = badConf
+	cm.ResourceVersion = "2"
+	_, err := client.CoreV1().ConfigMaps("volcano-system").Update(context.TODO(), cm, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	cm = waitForStatus(ConfRejected)
+	assert.Contains(t, cm.Annotations[ConfMessageAnnotation], `unknown plugin "gangg"`)
+	assert.Equal(t, "1", cm.Annotations[ConfAppliedResourceVersionAnnotation])
+	actions, _ = pc.getSchedulerConf()
+	assert.Equal(t, []string{"enqueue", "allocate"}, actions)
+
+	// The rollback restores the last known good configuration into the ConfigMap.
+	cm.Annotations[ConfRollbackAnnotation] = "true"
+	cm.ResourceVersion = "3"
+	_, err = client.CoreV1().ConfigMaps("volcano-system").Update(context.TODO(), cm, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	cm = waitForStatus(ConfApplied)
+	assert.Equal(t, goodConf, cm.Data[key])
+	assert.NotContains(t, cm.Annotations, ConfRollbackAnnotation)
+	assert.NotContains(t, cm.Annotations, ConfMessageAnnotation)
+
+	// Without the ConfigMap the scheduler falls back to the file, the default configuration here.
+	err = client.CoreV1().ConfigMaps("volcano-system").Delete(context.TODO(), "volcano-scheduler-configmap", metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	waitFor("fallback to the file", func() bool { return !pc.confMap.Exists() })
+	actions, _ = pc.getSchedulerConf()
+	assert.Equal(t, []string{"enqueue", "allocate", "backfill"}, actions)
+}
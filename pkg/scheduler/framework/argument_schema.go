@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// ArgumentType is the type of the value of a plugin argument as decoded from YAML.
+type ArgumentType string
+
+const (
+	// IntArgument is an integer, as read by Arguments.GetInt
+	IntArgument ArgumentType = "int"
+	// FloatArgument is a float or an integer, as read by Arguments.GetFloat64
+	FloatArgument ArgumentType = "float"
+	// BoolArgument is a boolean, as read by Arguments.GetBool
+	BoolArgument ArgumentType = "bool"
+	// StringArgument is a string
+	StringArgument ArgumentType = "string"
+	// ListArgument is a list of any values
+	ListArgument ArgumentType = "list"
+	// MapArgument is a map of any values
+	MapArgument ArgumentType = "map"
+	// AnyArgument is a value of any type, it is checked by the plugin itself
+	AnyArgument ArgumentType = "any"
+)
+
+// ArgumentSchema declares the arguments a plugin accepts by key. A key ending with `*`
+// matches every key with the given prefix, e.g. `binpack.resources.*`.
+type ArgumentSchema map[string]ArgumentType
+
+var argumentSchemas = map[string]ArgumentSchema{}
+
+// RegisterPluginArgumentSchema registers the schema the arguments of the plugin are validated with,
+// the arguments of a plugin without schema are not validated.
+func RegisterPluginArgumentSchema(name string, schema ArgumentSchema) {
+	pluginMutex.Lock()
+	defer pluginMutex.Unlock()
+
+	argumentSchemas[name] = schema
+}
+
+// GetPluginArgumentSchema get the argument schema of the plugin by name
+func GetPluginArgumentSchema(name string) (ArgumentSchema, bool) {
+	pluginMutex.RLock()
+	defer pluginMutex.RUnlock()
+
+	schema, found := argumentSchemas[name]
+	return schema, found
+}
+
+// ValidatePluginArguments validates the arguments of the plugin against its schema,
+// unknown keys and values of the wrong type are reported.
+func ValidatePluginArguments(name string, args Arguments) error {
+	schema, found := GetPluginArgumentSchema(name)
+	if !found {
+		return nil
+	}
+	return schema.Validate(args)
+}
+
+// Validate validates the arguments against the schema.
+func (s ArgumentSchema) Validate(args Arguments) error {
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		argType, found := s.lookup(key)
+		if !found {
+			errs = append(errs, fmt.Errorf("unknown argument %q", key))
+			continue
+		}
+		if !argType.matches(args[key]) {
+			errs = append(errs, fmt.Errorf("argument %q must be of type %s, got %T", key, argType, args[key]))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (s ArgumentSchema) lookup(key string) (ArgumentType, bool) {
+	if argType, found := s[key]; found {
+		return argType, true
+	}
+	// The longest prefix wins, e.g. `a.b.*` over `a.*`.
+	var prefix string
+	var argType ArgumentType
+	for pattern, t := range s {
+		if !strings.HasSuffix(pattern, "*") {
+			continue
+		}
+		p := strings.TrimSuffix(pattern, "*")
+		if strings.HasPrefix(key, p) && len(p) >= len(prefix) {
+			prefix, argType = p, t
+		}
+	}
+	return argType, argType != ""
+}
+
+func (t ArgumentType) matches(value interface{}) bool {
+	switch t {
+	case IntArgument:
+		_, ok := value.(int)
+		return ok
+	case FloatArgument:
+		switch value.(type) {
+		case int, float64:
+			return true
+		}
+		return false
+	case BoolArgument:
+		_, ok := value.(bool)
+		return ok
+	case StringArgument:
+		_, ok := value.(string)
+		return ok
+	case ListArgument:
+		_, ok := value.([]interface{})
+		return ok
+	case MapArgument:
+		switch value.(type) {
+		case map[interface{}]interface{}, map[string]interface{}:
+			return true
+		}
+		return false
+	case AnyArgument:
+		return true
+	}
+	return false
+}
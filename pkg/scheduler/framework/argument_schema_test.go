@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+)
+
+func TestArgumentSchemaValidate(t *testing.T) {
+	schema := ArgumentSchema{
+		"weight":           IntArgument,
+		"factor":           FloatArgument,
+		"enable":           BoolArgument,
+		"res":              StringArgument,
+		"res.*":            IntArgument,
+		"res.nvidia.com/*": StringArgument,
+		"strategies":       ListArgument,
+		"thresholds":       MapArgument,
+	}
+
+	tests := []struct {
+		name   string
+		args   Arguments
+		errMsg string
+	}{
+		{
+			name: "valid arguments",
+			args: Arguments{
+				"weight":     10,
+				"factor":     1.2,
+				"enable":     true,
+				"res":        "nvidia.com/gpu",
+				"res.cpu":    2,
+				"strategies": []interface{}{map[interface{}]interface{}{"name": "lowNodeUtilization"}},
+				"thresholds": map[interface{}]interface{}{"cpu": 80},
+			},
+		},
+		{
+			name: "float accepts int",
+			args: Arguments{"factor": 2},
+		},
+		{
+			name:   "unknown argument",
+			args:   Arguments{"wieght": 10},
+			errMsg: `unknown argument "wieght"`,
+		},
+		{
+			name:   "type mismatch",
+			args:   Arguments{"weight": "10"},
+			errMsg: `argument "weight" must be of type int, got string`,
+		},
+		{
+			name:   "longest prefix wins",
+			args:   Arguments{"res.nvidia.com/gpu": 1},
+			errMsg: `argument "res.nvidia.com/gpu" must be of type string, got int`,
+		},
+		{
+			name:   "all errors sorted by key",
+			args:   Arguments{"enable": "true", "bogus": 1},
+			errMsg: `[unknown argument "bogus", argument "enable" must be of type bool, got string]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := schema.Validate(test.args)
+			if test.errMsg == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.errMsg {
+				t.Errorf("expected error %q, got %v", test.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidatePluginArgumentsWithoutSchema(t *testing.T) {
+	if err := ValidatePluginArguments("plugin-without-schema", Arguments{"any": 1}); err != nil {
+		t.Errorf("expected arguments of a plugin without schema not to be validated, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// ElasticScaleUpReason is the reason of the events of the jobs which got elastic pods allocated.
+	ElasticScaleUpReason = "ElasticScaleUp"
+	// ElasticScaleDownReason is the reason of the events of the jobs which got elastic pods evicted.
+	ElasticScaleDownReason = "ElasticScaleDown"
+)
+
+// elasticTasks returns the tasks among the given ones which are elastic in their job, see JobInfo.ElasticTasks.
+func (ssn *Session) elasticTasks(tasks []*api.TaskInfo) map[api.TaskID]bool {
+	elastic := map[api.TaskID]bool{}
+	jobs := map[api.JobID]bool{}
+	for _, task := range tasks {
+		if jobs[task.Job] {
+			continue
+		}
+		jobs[task.Job] = true
+		if job, found := ssn.Jobs[task.Job]; found {
+			for _, et := range job.ElasticTasks() {
+				elastic[et.UID] = true
+			}
+		}
+	}
+	return elastic
+}
+
+// recordElasticScaling reports the jobs whose number of ready tasks above their minAvailable changed
+// during the session, to the object controlling their PodGroup, e.g. the Volcano job. The jobs of a dry run
+// session are not scaled, they are neither counted nor reported.
+func recordElasticScaling(ssn *Session) {
+	if ssn.DryRun() {
+		return
+	}
+	for jobID, before := range ssn.openReadyTaskNum {
+		job, found := ssn.Jobs[jobID]
+		if !found || job.PodGroup == nil {
+			continue
+		}
+		after := job.ReadyTaskNum()
+		minAvailable := job.MinAvailable
+		switch {
+		case after > before && after > minAvailable:
+			scaled := after - max(before, minAvailable)
+			metrics.RegisterElasticScaling(metrics.ElasticScaleUp, scaled)
+			recordElasticEvent(ssn, job, ElasticScaleUpReason,
+				fmt.Sprintf("Scaled up by %d elastic pods to %d ready pods, minAvailable %d", scaled, after, minAvailable))
+		case after < before && before > minAvailable:
+			scaled := before - max(after, minAvailable)
+			metrics.RegisterElasticScaling(metrics.ElasticScaleDown, scaled)
+			recordElasticEvent(ssn, job, ElasticScaleDownReason,
+				fmt.Sprintf("Scaled down by %d elastic pods to %d ready pods, minAvailable %d", scaled, after, minAvailable))
+		}
+	}
+}
+
+func recordElasticEvent(ssn *Session, job *api.JobInfo, reason, msg string) {
+	klog.V(3).Infof("Job <%s/%s>: %s", job.Namespace, job.Name, msg)
+	owner := metav1.GetControllerOfNoCopy(&job.PodGroup.PodGroup)
+	if owner == nil {
+		ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeNormal, reason, msg)
+		return
+	}
+	ssn.recorder.Eventf(&v1.ObjectReference{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Namespace:  job.PodGroup.Namespace,
+		Name:       owner.Name,
+		UID:        owner.UID,
+	}, v1.EventTypeNormal, reason, msg)
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	hashutil "k8s.io/kubernetes/pkg/util/hash"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// equivalenceCacheEnabled enables the equivalence cache of the sessions.
+var equivalenceCacheEnabled = true
+
+// SetEquivalenceCacheEnabled enables or disables the equivalence cache of the sessions opened afterwards,
+// it is enabled by default.
+func SetEquivalenceCacheEnabled(enabled bool) {
+	equivalenceCacheEnabled = enabled
+}
+
+// equivalenceCache shares the predicate and node order results of a node between the tasks of the same
+// equivalence class, that is the tasks of a job with the same pod template. The results of a node are
+// invalidated when a task is allocated, pipelined or evicted on it or the operation is rolled back. The
+// results of all the nodes are invalidated when the task has inter-pod affinity or topology spread
+// constraints, which change the results of the other nodes of its topology domains.
+type equivalenceCache struct {
+	mutex sync.RWMutex
+	// classes are the equivalence classes of the tasks by UID, empty if the results of the task are not shared
+	classes map[api.TaskID]string
+	// nodes are the results of the equivalence classes on the nodes by node name
+	nodes map[string]*nodeEquivalence
+}
+
+// nodeEquivalence are the results of the equivalence classes on a node by class.
+type nodeEquivalence struct {
+	predicates map[string]error
+	scores     map[string]*equivalenceScore
+}
+
+// equivalenceScore is the result of the NodeOrderMapFn of the session.
+type equivalenceScore struct {
+	mapScores  map[string]float64
+	orderScore float64
+}
+
+func newEquivalenceCache(ssn *Session) *equivalenceCache {
+	if !equivalenceCacheEnabled {
+		return nil
+	}
+	ec := &equivalenceCache{
+		classes: map[api.TaskID]string{},
+		nodes:   map[string]*nodeEquivalence{},
+	}
+	ssn.AddEventHandler(&EventHandler{
+		AllocateFunc:   ec.invalidate,
+		DeallocateFunc: ec.invalidate,
+	})
+	return ec
+}
+
+// invalidate drops the results of the node of the task of the event.
+func (ec *equivalenceCache) invalidate(event *Event) {
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+
+	if hasCrossNodeConstraints(event.Task.Pod) {
+		ec.nodes = map[string]*nodeEquivalence{}
+		return
+	}
+	delete(ec.nodes, event.Task.NodeName)
+}
+
+func (ec *equivalenceCache) predicate(class, nodeName string) (error, bool) {
+	ec.mutex.RLock()
+	defer ec.mutex.RUnlock()
+
+	ne, found := ec.nodes[nodeName]
+	if !found {
+		return nil, false
+	}
+	err, found := ne.predicates[class]
+	return err, found
+}
+
+func (ec *equivalenceCache) setPredicate(class, nodeName string, err error) {
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+
+	ec.node(nodeName).predicates[class] = err
+}
+
+func (ec *equivalenceCache) score(class, nodeName string) (*equivalenceScore, bool) {
+	ec.mutex.RLock()
+	defer ec.mutex.RUnlock()
+
+	ne, found := ec.nodes[nodeName]
+	if !found {
+		return nil, false
+	}
+	score, found := ne.scores[class]
+	return score, found
+}
+
+func (ec *equivalenceCache) setScore(class, nodeName string, score *equivalenceScore) {
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+
+	ec.node(nodeName).scores[class] = score
+}
+
+// node returns the results of the node, the caller must hold the lock.
+func (ec *equivalenceCache) node(nodeName string) *nodeEquivalence {
+	ne, found := ec.nodes[nodeName]
+	if !found {
+		ne = &nodeEquivalence{
+			predicates: map[string]error{},
+			scores:     map[string]*equivalenceScore{},
+		}
+		ec.nodes[nodeName] = ne
+	}
+	return ne
+}
+
+// equivalenceClass returns the equivalence class of the task for its results on the node, it is empty if
+// the results are not shared, e.g. the equivalence cache is disabled or the node is not the one of the session.
+func (ssn *Session) equivalenceClass(task *api.TaskInfo, node *api.NodeInfo) string {
+	ec := ssn.equivalence
+	if ec == nil || ssn.Nodes[node.Name] != node {
+		return ""
+	}
+
+	ec.mutex.RLock()
+	class, found := ec.classes[task.UID]
+	ec.mutex.RUnlock()
+	if found {
+		return class
+	}
+
+	if ssn.taskEquivalent(task) {
+		class = podEquivalenceClass(task)
+	}
+	ec.mutex.Lock()
+	ec.classes[task.UID] = class
+	ec.mutex.Unlock()
+	return class
+}
+
+// taskEquivalent returns false if a plugin whose predicate or node order is enabled does not share its
+// results for the task with its equivalence class.
+func (ssn *Session) taskEquivalent(task *api.TaskInfo) bool {
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if !isEnabled(plugin.EnabledPredicate) && !isEnabled(plugin.EnabledNodeOrder) {
+				continue
+			}
+			efn, found := ssn.equivalentFns[plugin.Name]
+			if !found {
+				continue
+			}
+			if !efn(task) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// equivalencePod holds the fields of a pod the scheduling depends on, the pods of a job with the same
+// fields are in the same equivalence class.
+type equivalencePod struct {
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	Spec        v1.PodSpec
+}
+
+// podEquivalenceClass returns the equivalence class of the task, it is empty if the results of the task
+// are not shared, e.g. it has inter-pod affinity, topology spread constraints or claims of its own.
+func podEquivalenceClass(task *api.TaskInfo) string {
+	pod := task.Pod
+	if pod == nil || hasCrossNodeConstraints(pod) {
+		return ""
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil || volume.Ephemeral != nil {
+			return ""
+		}
+	}
+	if len(pod.Spec.ResourceClaims) != 0 {
+		return ""
+	}
+
+	ep := &equivalencePod{
+		Namespace:   pod.Namespace,
+		Labels:      withoutTaskIndex(pod.Labels),
+		Annotations: withoutTaskIndex(pod.Annotations),
+		Spec:        *pod.Spec.DeepCopy(),
+	}
+	// The environment, hostname and subdomain of the pods of a job differ by their index.
+	ep.Spec.Hostname, ep.Spec.Subdomain = "", ""
+	for i := range ep.Spec.InitContainers {
+		ep.Spec.InitContainers[i].Env = nil
+	}
+	for i := range ep.Spec.Containers {
+		ep.Spec.Containers[i].Env = nil
+	}
+
+	hash := fnv.New64a()
+	hashutil.DeepHashObject(hash, ep)
+	return fmt.Sprintf("%s/%x", task.Job, hash.Sum64())
+}
+
+func withoutTaskIndex(m map[string]string) map[string]string {
+	if _, found := m[batch.TaskIndex]; !found {
+		return m
+	}
+	ret := make(map[string]string, len(m))
+	for k, v := range m {
+		if k != batch.TaskIndex {
+			ret[k] = v
+		}
+	}
+	return ret
+}
+
+// hasCrossNodeConstraints returns true if the pod has inter-pod affinity or topology spread constraints.
+func hasCrossNodeConstraints(pod *v1.Pod) bool {
+	if pod == nil {
+		return false
+	}
+	if affinity := pod.Spec.Affinity; affinity != nil && (affinity.PodAffinity != nil || affinity.PodAntiAffinity != nil) {
+		return true
+	}
+	return len(pod.Spec.TopologySpreadConstraints) != 0
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestPodEquivalenceClass(t *testing.T) {
+	buildTask := func(name, index, cpu string) *api.TaskInfo {
+		pod := util.BuildPod("c1", name, "", v1.PodPending, api.BuildResourceList(cpu, "1G"), "pg1", map[string]string{batch.TaskIndex: index}, nil)
+		pod.Spec.Hostname = name
+		pod.Spec.Containers[0].Env = []v1.EnvVar{{Name: "VK_TASK_INDEX", Value: index}}
+		return api.NewTaskInfo(pod)
+	}
+
+	p0 := buildTask("p0", "0", "1")
+	p1 := buildTask("p1", "1", "1")
+	assert.NotEmpty(t, podEquivalenceClass(p0))
+	assert.Equal(t, podEquivalenceClass(p0), podEquivalenceClass(p1), "the pods of the same template differing by their index")
+	assert.NotEqual(t, podEquivalenceClass(p0), podEquivalenceClass(buildTask("p2", "2", "2")), "the pods requesting different resources")
+
+	affinity := buildTask("p3", "3", "1")
+	affinity.Pod.Spec.Affinity = &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{}}
+	assert.Empty(t, podEquivalenceClass(affinity), "the pod with inter-pod affinity")
+
+	claim := buildTask("p4", "4", "1")
+	claim.Pod.Spec.Volumes = []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{
+		PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data-p4"},
+	}}}
+	assert.Empty(t, podEquivalenceClass(claim), "the pod with a persistent volume claim")
+}
+
+func TestEquivalenceCache(t *testing.T) {
+	scherCache := cache.NewDefaultMockSchedulerCache("test-scheduler")
+	for _, name := range []string{"n1", "n2"} {
+		scherCache.AddOrUpdateNode(util.BuildNode(name, api.BuildResourceList("4", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	}
+	scherCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 3, nil, schedulingv1.PodGroupInqueue))
+	scherCache.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+	scherCache.AddPod(util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+	scherCache.AddPod(util.BuildPod("c1", "p3", "", v1.PodPending, api.BuildResourceList("2", "1G"), "pg1", nil, nil))
+
+	enabled := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{Name: "fake", EnabledPredicate: &enabled, EnabledNodeOrder: &enabled}}}}
+	ssn := OpenSession(scherCache, tiers, nil)
+	defer CloseSession(ssn)
+
+	predicates, scores := map[string]int{}, map[string]int{}
+	ssn.AddPredicateFn("fake", func(task *api.TaskInfo, node *api.NodeInfo) error {
+		predicates[node.Name]++
+		if node.Name == "n2" {
+			return api.NewFitError(task, node, "fake")
+		}
+		return nil
+	})
+	ssn.AddNodeOrderFn("fake", func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
+		scores[node.Name]++
+		return 10, nil
+	})
+	shared := true
+	ssn.AddEquivalentFn("fake", func(*api.TaskInfo) bool {
+		return shared
+	})
+
+	tasks := map[string]*api.TaskInfo{}
+	for _, task := range ssn.Jobs["c1/pg1"].Tasks {
+		tasks[task.Name] = task
+	}
+	run := func(names ...string) {
+		for _, name := range names {
+			for _, node := range []string{"n1", "n2"} {
+				err := ssn.PredicateFn(tasks[name], ssn.Nodes[node])
+				if node == "n2" {
+					assert.EqualError(t, err, "task c1/"+name+" on node n2 fit failed: fake")
+				} else {
+					assert.NoError(t, err)
+				}
+				_, score, err := ssn.NodeOrderMapFn(tasks[name], ssn.Nodes[node])
+				assert.NoError(t, err)
+				assert.Equal(t, 10.0, score)
+			}
+		}
+	}
+
+	// p1 and p2 share their results, p3 requests different resources.
+	run("p1", "p2")
+	assert.Equal(t, map[string]int{"n1": 1, "n2": 1}, predicates)
+	assert.Equal(t, map[string]int{"n1": 1, "n2": 1}, scores)
+	run("p3")
+	assert.Equal(t, map[string]int{"n1": 2, "n2": 2}, predicates)
+
+	// The allocation on n1 invalidates its results only.
+	stmt := NewStatement(ssn)
+	assert.NoError(t, stmt.Allocate(tasks["p1"], ssn.Nodes["n1"]))
+	run("p2")
+	assert.Equal(t, map[string]int{"n1": 3, "n2": 2}, predicates)
+	assert.Equal(t, map[string]int{"n1": 3, "n2": 2}, scores)
+
+	// So does its rollback.
+	stmt.Discard()
+	run("p2")
+	assert.Equal(t, map[string]int{"n1": 4, "n2": 2}, predicates)
+
+	// The reservation set within the session applies to the cached results.
+	ssn.SetReservation(&api.Reservation{Job: "c1/pg2", Nodes: map[string]bool{"n1": true}})
+	assert.EqualError(t, ssn.PredicateFn(tasks["p2"], ssn.Nodes["n1"]), "task c1/p2 on node n1 fit failed: node is reserved for job c1/pg2")
+	ssn.SetReservation(nil)
+	assert.NoError(t, ssn.PredicateFn(tasks["p2"], ssn.Nodes["n1"]))
+	assert.Equal(t, map[string]int{"n1": 4, "n2": 2}, predicates)
+
+	// A node which is not the one of the session, e.g. a clone, is not cached.
+	assert.NoError(t, ssn.PredicateFn(tasks["p2"], ssn.Nodes["n1"].Clone()))
+	assert.Equal(t, map[string]int{"n1": 5, "n2": 2}, predicates)
+
+	// The results are not shared once a plugin tells so, the class of a task is kept in the session.
+	shared = false
+	ssn.equivalence.classes = map[api.TaskID]string{}
+	run("p1", "p2")
+	assert.Equal(t, map[string]int{"n1": 7, "n2": 4}, predicates)
+}
+
+func TestEquivalenceCacheDisabled(t *testing.T) {
+	SetEquivalenceCacheEnabled(false)
+	defer SetEquivalenceCacheEnabled(true)
+
+	scherCache := cache.NewDefaultMockSchedulerCache("test-scheduler")
+	scherCache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	scherCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1.PodGroupInqueue))
+	scherCache.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+	scherCache.AddPod(util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+
+	enabled := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{Name: "fake", EnabledPredicate: &enabled}}}}
+	ssn := OpenSession(scherCache, tiers, nil)
+	defer CloseSession(ssn)
+
+	predicates := 0
+	ssn.AddPredicateFn("fake", func(task *api.TaskInfo, node *api.NodeInfo) error {
+		predicates++
+		return nil
+	})
+	for _, task := range ssn.Jobs["c1/pg1"].Tasks {
+		assert.NoError(t, ssn.PredicateFn(task, ssn.Nodes["n1"]))
+	}
+	assert.Nil(t, ssn.equivalence)
+	assert.Equal(t, 2, predicates)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package framework
 
 import (
+	"context"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -24,11 +25,33 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/cache"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/util/tracing"
 )
 
 // OpenSession start the session
 func OpenSession(cache cache.Cache, tiers []conf.Tier, configurations []conf.Configuration) *Session {
+	return OpenSessionWithContext(context.Background(), cache, tiers, configurations)
+}
+
+// OpenSessionWithContext start the session in the trace context of ctx, the spans of the session
+// and of its actions, plugins and statements are children of the span of ctx.
+func OpenSessionWithContext(ctx context.Context, cache cache.Cache, tiers []conf.Tier, configurations []conf.Configuration) *Session {
+	ssn := openSession(cache)
+	ssn.ctx = ctx
+	return openPlugins(ssn, tiers, configurations)
+}
+
+// OpenDryRunSession start a session whose decisions are not applied to the cluster, e.g. to
+// evaluate a candidate configuration. The cache is expected to discard binds and evictions,
+// plugins skip their own side effects and the session is left out of the decision trace.
+func OpenDryRunSession(cache cache.Cache, tiers []conf.Tier, configurations []conf.Configuration) *Session {
 	ssn := openSession(cache)
+	ssn.dryRun = true
+	ssn.tracer = nil
+	return openPlugins(ssn, tiers, configurations)
+}
+
+func openPlugins(ssn *Session, tiers []conf.Tier, configurations []conf.Configuration) *Session {
 	ssn.Tiers = tiers
 	ssn.Configurations = configurations
 	ssn.NodeMap = GenerateNodeMapAndSlice(ssn.Nodes)
@@ -42,19 +65,29 @@ func OpenSession(cache cache.Cache, tiers []conf.Tier, configurations []conf.Con
 				plugin := pb(plugin.Arguments)
 				ssn.plugins[plugin.Name()] = plugin
 				onSessionOpenStart := time.Now()
+				_, span := ssn.startSpan("OnSessionOpen", tracing.PluginKey.String(plugin.Name()))
 				plugin.OnSessionOpen(ssn)
+				span.End()
 				metrics.UpdatePluginDuration(plugin.Name(), metrics.OnSessionOpen, metrics.Duration(onSessionOpenStart))
 			}
 		}
 	}
+	// The binds of a dry run are discarded, the plugins of the live session bind the tasks.
+	if !ssn.dryRun {
+		ssn.cache.SetBindFns(ssn.BindFns())
+	}
 	return ssn
 }
 
 // CloseSession close the session
 func CloseSession(ssn *Session) {
+	ssn.finishTrace()
+
 	for _, plugin := range ssn.plugins {
 		onSessionCloseStart := time.Now()
+		_, span := ssn.startSpan("OnSessionClose", tracing.PluginKey.String(plugin.Name()))
 		plugin.OnSessionClose(ssn)
+		span.End()
 		metrics.UpdatePluginDuration(plugin.Name(), metrics.OnSessionClose, metrics.Duration(onSessionCloseStart))
 	}
 
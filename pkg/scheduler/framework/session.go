@@ -19,6 +19,7 @@ package framework
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -38,9 +39,11 @@ import (
 	vcclient "volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/capabilities/dynamicresources"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
 	"volcano.sh/volcano/pkg/scheduler/util"
+	"volcano.sh/volcano/pkg/util/tracing"
 )
 
 // Session information for the current session
@@ -59,6 +62,9 @@ type Session struct {
 	// podGroupStatus cache podgroup status during schedule
 	// This should not be mutated after initiated
 	podGroupStatus map[api.JobID]scheduling.PodGroupStatus
+	// openReadyTaskNum is the number of ready tasks of the jobs when the session opened,
+	// the elastic scaling of the jobs is reported when it closes.
+	openReadyTaskNum map[api.JobID]int32
 
 	Jobs           map[api.JobID]*api.JobInfo
 	Nodes          map[string]*api.NodeInfo
@@ -76,6 +82,10 @@ type Session struct {
 	Configurations []conf.Configuration
 	NodeList       []*api.NodeInfo
 
+	// nodeIndex prunes the candidate nodes of a task before the predicates.
+	nodeIndex *api.NodeIndex
+	// nodeIndexFilters are the node index filters equivalent to the predicates of the plugins.
+	nodeIndexFilters    map[string]api.NodeIndexFilter
 	plugins             map[string]Plugin
 	eventHandlers       []*EventHandler
 	jobOrderFns         map[string]api.CompareFn
@@ -106,6 +116,24 @@ type Session struct {
 	reservedNodesFns  map[string]api.ReservedNodesFn
 	victimTasksFns    map[string][]api.VictimTasksFn
 	jobStarvingFns    map[string]api.ValidateFn
+	// victimFilterFns restrict the victims of the preemption and the reclaim whatever the tier deciding them.
+	victimFilterFns map[string]api.EvictableFn
+	// bindFns bind the tasks they handle in place of the scheduler.
+	bindFns map[string]api.BindFn
+	// equivalentFns tell whether the results of the plugins for a task are shared by its equivalence class.
+	equivalentFns map[string]api.EquivalentFn
+
+	// tracer collects the decision trace of the session, it is nil if the trace is disabled.
+	tracer *sessionTracer
+	// dryRun is true if the decisions of the session are not applied to the cluster.
+	dryRun bool
+	// ctx holds the current span of the session, the spans of the session are started as its children.
+	ctx context.Context
+	// reservation keeps the other jobs off the nodes locked for a starving job, it may be nil.
+	reservation *api.Reservation
+	// equivalence shares the predicate and node order results between the tasks of the same equivalence class,
+	// it is nil if the equivalence cache is disabled.
+	equivalence *equivalenceCache
 }
 
 func openSession(cache cache.Cache) *Session {
@@ -117,10 +145,12 @@ func openSession(cache cache.Cache) *Session {
 		recorder:        cache.EventRecorder(),
 		cache:           cache,
 		informerFactory: cache.SharedInformerFactory(),
+		ctx:             context.Background(),
 
-		TotalResource:  api.EmptyResource(),
-		TotalGuarantee: api.EmptyResource(),
-		podGroupStatus: map[api.JobID]scheduling.PodGroupStatus{},
+		TotalResource:    api.EmptyResource(),
+		TotalGuarantee:   api.EmptyResource(),
+		podGroupStatus:   map[api.JobID]scheduling.PodGroupStatus{},
+		openReadyTaskNum: map[api.JobID]int32{},
 
 		Jobs:           map[api.JobID]*api.JobInfo{},
 		Nodes:          map[string]*api.NodeInfo{},
@@ -136,6 +166,7 @@ func openSession(cache cache.Cache) *Session {
 		clusterOrderFns:     map[string]api.CompareFn{},
 		predicateFns:        map[string]api.PredicateFn{},
 		prePredicateFns:     map[string]api.PrePredicateFn{},
+		nodeIndexFilters:    map[string]api.NodeIndexFilter{},
 		bestNodeFns:         map[string]api.BestNodeFn{},
 		nodeOrderFns:        map[string]api.NodeOrderFn{},
 		batchNodeOrderFns:   map[string]api.BatchNodeOrderFn{},
@@ -154,8 +185,13 @@ func openSession(cache cache.Cache) *Session {
 		targetJobFns:        map[string]api.TargetJobFn{},
 		reservedNodesFns:    map[string]api.ReservedNodesFn{},
 		victimTasksFns:      map[string][]api.VictimTasksFn{},
+		victimFilterFns:     map[string]api.EvictableFn{},
+		bindFns:             map[string]api.BindFn{},
 		jobStarvingFns:      map[string]api.ValidateFn{},
+		equivalentFns:       map[string]api.EquivalentFn{},
 	}
+	ssn.tracer = newSessionTracer(ssn)
+	ssn.equivalence = newEquivalenceCache(ssn)
 
 	snapshot := cache.Snapshot()
 
@@ -164,6 +200,7 @@ func openSession(cache cache.Cache) *Session {
 		if job.PodGroup != nil {
 			ssn.podGroupStatus[job.UID] = *job.PodGroup.Status.DeepCopy()
 		}
+		ssn.openReadyTaskNum[job.UID] = job.ReadyTaskNum()
 
 		if vjr := ssn.JobValid(job); vjr != nil {
 			if !vjr.Pass {
@@ -185,6 +222,10 @@ func openSession(cache cache.Cache) *Session {
 		}
 	}
 	ssn.NodeList = util.GetNodeList(snapshot.Nodes, snapshot.NodeList)
+	ssn.nodeIndex = snapshot.NodeIndex
+	if ssn.nodeIndex == nil {
+		ssn.nodeIndex = api.NewNodeIndex(ssn.NodeList)
+	}
 	ssn.Nodes = snapshot.Nodes
 	ssn.CSINodesStatus = snapshot.CSINodesStatus
 	ssn.RevocableNodes = snapshot.RevocableNodes
@@ -299,6 +340,7 @@ func closeSession(ssn *Session) {
 	ju.UpdateAll()
 
 	updateQueueStatus(ssn)
+	recordElasticScaling(ssn)
 
 	ssn.Jobs = nil
 	ssn.Nodes = nil
@@ -309,6 +351,7 @@ func closeSession(ssn *Session) {
 	ssn.queueOrderFns = nil
 	ssn.clusterOrderFns = nil
 	ssn.NodeList = nil
+	ssn.nodeIndex = nil
 	ssn.TotalResource = nil
 
 	klog.V(3).Infof("Close Session %v", ssn.UID)
@@ -357,6 +400,20 @@ func jobStatus(ssn *Session, jobInfo *api.JobInfo) scheduling.PodGroupStatus {
 	return status
 }
 
+// CandidateNodes returns the nodes the task may fit on according to the node index, pruned with the given
+// filters and the filters equivalent to the enabled predicates.
+func (ssn *Session) CandidateNodes(task *api.TaskInfo, filters api.NodeIndexFilter) []*api.NodeInfo {
+	if ssn.nodeIndex == nil {
+		return ssn.NodeList
+	}
+	return ssn.nodeIndex.Candidates(task, filters|ssn.NodeIndexFilters())
+}
+
+// SetPrunedNodeErrors sets the fit errors of the nodes CandidateNodes pruned with the same filters in fitErrors.
+func (ssn *Session) SetPrunedNodeErrors(task *api.TaskInfo, filters api.NodeIndexFilter, fitErrors *api.FitErrors) {
+	ssn.nodeIndex.SetPrunedNodeErrors(task, filters|ssn.NodeIndexFilters(), fitErrors)
+}
+
 // GetUnschedulableAndUnresolvableNodesForTask filter out those node that has UnschedulableAndUnresolvable
 func (ssn *Session) GetUnschedulableAndUnresolvableNodesForTask(task *api.TaskInfo) []*api.NodeInfo {
 	fitErrors, ok1 := ssn.Jobs[task.Job]
@@ -460,6 +517,7 @@ func (ssn *Session) Pipeline(task *api.TaskInfo, hostname string) error {
 				task.Namespace, task.Name, hostname, ssn.UID, err)
 			return err
 		}
+		ssn.nodeIndex.UpdateNode(node)
 		klog.V(3).Infof("After pipelined Task <%v/%v> to Node <%v>: idle <%v>, used <%v>, releasing <%v>",
 			task.Namespace, task.Name, node.Name, node.Idle, node.Used, node.Releasing)
 	} else {
@@ -496,8 +554,22 @@ func (ssn *Session) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 		}
 	}()
 
+	podClaims, err := ssn.cache.GetPodClaims(task, nodeInfo.Node)
+	if err != nil {
+		return err
+	}
+	if err := ssn.cache.AllocateClaims(task, podClaims); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			ssn.cache.RevertClaims(task, podClaims)
+		}
+	}()
+
 	task.Pod.Spec.NodeName = hostname
 	task.PodVolumes = podVolumes
+	task.PodClaims = podClaims
 
 	// Only update status in session
 	job, found := ssn.Jobs[task.Job]
@@ -521,6 +593,7 @@ func (ssn *Session) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 				task.Namespace, task.Name, hostname, ssn.UID, err)
 			return err
 		}
+		ssn.nodeIndex.UpdateNode(node)
 		klog.V(3).Infof("After allocated Task <%v/%v> to Node <%v>: idle <%v>, used <%v>, releasing <%v>",
 			task.Namespace, task.Name, node.Name, node.Idle, node.Used, node.Releasing)
 	} else {
@@ -548,12 +621,14 @@ func (ssn *Session) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 		}
 	} else {
 		ssn.cache.RevertVolumes(task, podVolumes)
+		ssn.cache.RevertClaims(task, podClaims)
 	}
 
 	return nil
 }
 
 func (ssn *Session) dispatch(task *api.TaskInfo) error {
+	task.TraceParent = tracing.TraceParent(ssn.ctx)
 	if err := ssn.cache.AddBindTask(task); err != nil {
 		return err
 	}
@@ -602,6 +677,7 @@ func (ssn *Session) Evict(reclaimee *api.TaskInfo, reason string) error {
 				reclaimee.Namespace, reclaimee.Name, ssn.UID, err)
 			return err
 		}
+		ssn.nodeIndex.UpdateNode(node)
 	}
 
 	for _, eh := range ssn.eventHandlers {
@@ -655,6 +731,34 @@ func (ssn *Session) UpdateSchedulerNumaInfo(AllocatedSets map[string]api.ResNuma
 	ssn.cache.UpdateSchedulerNumaInfo(AllocatedSets)
 }
 
+// SetReservation sets the reservation of nodes the predicates of the session enforce, nil removes it.
+func (ssn *Session) SetReservation(reservation *api.Reservation) {
+	ssn.reservation = reservation
+}
+
+// Reservation returns the reservation of nodes of the session, it may be nil.
+func (ssn *Session) Reservation() *api.Reservation {
+	return ssn.reservation
+}
+
+// reservationPredicate keeps the task off the node if the node is reserved for another job.
+func (ssn *Session) reservationPredicate(task *api.TaskInfo, node *api.NodeInfo) error {
+	if !ssn.reservation.Locks(ssn.Jobs[task.Job], node.Name, time.Now()) {
+		return nil
+	}
+	return api.NewFitErrWithStatus(task, node, &api.Status{
+		Code:   api.UnschedulableAndUnresolvable,
+		Reason: fmt.Sprintf("node is reserved for job %s", ssn.reservation.Job),
+		Plugin: "reserve",
+	})
+}
+
+// DryRun returns whether the decisions of the session are not applied to the cluster,
+// plugins must not write to the cluster in a dry run session.
+func (ssn Session) DryRun() bool {
+	return ssn.dryRun
+}
+
 // KubeClient returns the kubernetes client
 func (ssn Session) KubeClient() kubernetes.Interface {
 	return ssn.kubeClient
@@ -675,6 +779,12 @@ func (ssn Session) InformerFactory() informers.SharedInformerFactory {
 	return ssn.informerFactory
 }
 
+// GetPodClaims returns the resource claims of the task allocated on the node, and an error if they may not be
+// allocated on it. It returns nil if the task has no resource claims.
+func (ssn Session) GetPodClaims(task *api.TaskInfo, node *api.NodeInfo) (*dynamicresources.PodClaims, error) {
+	return ssn.cache.GetPodClaims(task, node.Node)
+}
+
 // RecordPodGroupEvent records podGroup events
 func (ssn Session) RecordPodGroupEvent(podGroup *api.PodGroup, eventType, reason, msg string) {
 	if podGroup == nil {
@@ -17,11 +17,14 @@ limitations under the License.
 package framework
 
 import (
+	"fmt"
+
 	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/trace"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
@@ -75,6 +78,12 @@ func (ssn *Session) AddPredicateFn(name string, pf api.PredicateFn) {
 	ssn.predicateFns[name] = pf
 }
 
+// AddNodeIndexFilter adds the node index filters equivalent to the predicates of the plugin,
+// the candidate nodes of a task are pruned with them before the predicates run.
+func (ssn *Session) AddNodeIndexFilter(name string, filters api.NodeIndexFilter) {
+	ssn.nodeIndexFilters[name] |= filters
+}
+
 // AddPrePredicateFn add PrePredicate function
 func (ssn *Session) AddPrePredicateFn(name string, pf api.PrePredicateFn) {
 	ssn.prePredicateFns[name] = pf
@@ -155,6 +164,22 @@ func (ssn *Session) AddJobStarvingFns(name string, fn api.ValidateFn) {
 	ssn.jobStarvingFns[name] = fn
 }
 
+// AddVictimFilterFn add victimFilterFn function
+func (ssn *Session) AddVictimFilterFn(name string, fn api.EvictableFn) {
+	ssn.victimFilterFns[name] = fn
+}
+
+// AddBindFn add bindFn function
+func (ssn *Session) AddBindFn(name string, fn api.BindFn) {
+	ssn.bindFns[name] = fn
+}
+
+// AddEquivalentFn add equivalentFn function, the plugins whose predicate or node order results depend on
+// more than the pod template of the task and the node, e.g. on the placement of the other tasks of its job, add it.
+func (ssn *Session) AddEquivalentFn(name string, fn api.EquivalentFn) {
+	ssn.equivalentFns[name] = fn
+}
+
 // Reclaimable invoke reclaimable function of the plugins
 func (ssn *Session) Reclaimable(reclaimer *api.TaskInfo, reclaimees []*api.TaskInfo) []*api.TaskInfo {
 	var victims []*api.TaskInfo
@@ -197,7 +222,7 @@ func (ssn *Session) Reclaimable(reclaimer *api.TaskInfo, reclaimees []*api.TaskI
 		}
 		// Plugins in this tier made decision if victims is not nil
 		if victims != nil {
-			return victims
+			return ssn.filterVictims(reclaimer, victims)
 		}
 	}
 
@@ -247,13 +272,40 @@ func (ssn *Session) Preemptable(preemptor *api.TaskInfo, preemptees []*api.TaskI
 		}
 		// Plugins in this tier made decision if victims is not nil
 		if victims != nil {
-			return victims
+			return ssn.filterVictims(preemptor, victims)
 		}
 	}
 
 	return victims
 }
 
+// filterVictims removes the victims rejected by the victim filter functions of the plugins.
+func (ssn *Session) filterVictims(preemptor *api.TaskInfo, victims []*api.TaskInfo) []*api.TaskInfo {
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			ff, found := ssn.victimFilterFns[plugin.Name]
+			if !found || len(victims) == 0 {
+				continue
+			}
+			victims, _ = ff(preemptor, victims)
+		}
+	}
+	return victims
+}
+
+// BindFns returns the bind functions of the plugins in the order of their tiers.
+func (ssn *Session) BindFns() []api.BindFn {
+	var fns []api.BindFn
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if fn, found := ssn.bindFns[plugin.Name]; found {
+				fns = append(fns, fn)
+			}
+		}
+	}
+	return fns
+}
+
 // Overused invoke overused function of the plugins
 func (ssn *Session) Overused(queue *api.QueueInfo) bool {
 	for _, tier := range ssn.Tiers {
@@ -266,6 +318,7 @@ func (ssn *Session) Overused(queue *api.QueueInfo) bool {
 				continue
 			}
 			if of(queue) {
+				ssn.traceQueueVeto(queue, plugin.Name, trace.Overused, fmt.Sprintf("queue <%s> is overused", queue.Name))
 				return true
 			}
 		}
@@ -306,6 +359,10 @@ func (ssn *Session) Allocatable(queue *api.QueueInfo, candidate *api.TaskInfo) b
 				continue
 			}
 			if !af(queue, candidate) {
+				if job, found := ssn.Jobs[candidate.Job]; found {
+					ssn.traceJobVeto(job, plugin.Name, trace.Allocatable,
+						fmt.Sprintf("queue <%s> can not allocate task <%s/%s>", queue.Name, candidate.Namespace, candidate.Name))
+				}
 				return false
 			}
 		}
@@ -316,6 +373,7 @@ func (ssn *Session) Allocatable(queue *api.QueueInfo, candidate *api.TaskInfo) b
 
 // JobReady invoke jobready function of the plugins
 func (ssn *Session) JobReady(obj interface{}) bool {
+	ssn.traceJobVisit(obj)
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledJobReady) {
@@ -370,6 +428,7 @@ func (ssn *Session) JobPipelined(obj interface{}) bool {
 // JobStarving invoke jobStarving function of the plugins
 // Check if job still need more resource
 func (ssn *Session) JobStarving(obj interface{}) bool {
+	ssn.traceJobVisit(obj)
 	var hasFound bool
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
@@ -397,6 +456,7 @@ func (ssn *Session) JobStarving(obj interface{}) bool {
 
 // JobValid invoke jobvalid function of the plugins
 func (ssn *Session) JobValid(obj interface{}) *api.ValidateResult {
+	ssn.traceJobVisit(obj)
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
 			jrf, found := ssn.jobValidFns[plugin.Name]
@@ -405,6 +465,7 @@ func (ssn *Session) JobValid(obj interface{}) *api.ValidateResult {
 			}
 
 			if vr := jrf(obj); vr != nil && !vr.Pass {
+				ssn.traceJobVeto(obj, plugin.Name, trace.JobValid, fmt.Sprintf("%s: %s", vr.Reason, vr.Message))
 				return vr
 			}
 		}
@@ -415,6 +476,7 @@ func (ssn *Session) JobValid(obj interface{}) *api.ValidateResult {
 
 // JobEnqueueable invoke jobEnqueueableFns function of the plugins
 func (ssn *Session) JobEnqueueable(obj interface{}) bool {
+	ssn.traceJobVisit(obj)
 	var hasFound bool
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
@@ -426,8 +488,14 @@ func (ssn *Session) JobEnqueueable(obj interface{}) bool {
 				continue
 			}
 
+			ssn.takeVetoReason(plugin.Name)
 			res := fn(obj)
+			reason := ssn.takeVetoReason(plugin.Name)
 			if res < 0 {
+				if reason == "" {
+					reason = "job is not enqueueable"
+				}
+				ssn.traceJobVeto(obj, plugin.Name, trace.JobEnqueueable, reason)
 				return false
 			}
 			if res > 0 {
@@ -446,6 +514,7 @@ func (ssn *Session) JobEnqueueable(obj interface{}) bool {
 
 // JobEnqueued invoke jobEnqueuedFns function of the plugins
 func (ssn *Session) JobEnqueued(obj interface{}) {
+	ssn.traceJobVisit(obj)
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledJobEnqueued) {
@@ -645,8 +714,29 @@ func (ssn *Session) TaskOrderFn(l, r interface{}) bool {
 	return helpers.CompareTask(lv, rv)
 }
 
-// PredicateFn invoke predicate function of the plugins
+// PredicateFn invoke predicate function of the plugins, the result is shared by the tasks
+// of the same equivalence class on the node. The reservation of the session is not shared,
+// it changes within the session.
 func (ssn *Session) PredicateFn(task *api.TaskInfo, node *api.NodeInfo) error {
+	if err := ssn.reservationPredicate(task, node); err != nil {
+		return err
+	}
+	class := ssn.equivalenceClass(task, node)
+	if class == "" {
+		return ssn.predicateFn(task, node)
+	}
+	if err, found := ssn.equivalence.predicate(class, node.Name); found {
+		if fitErr, ok := err.(*api.FitError); ok {
+			return api.NewFitErrWithStatus(task, node, fitErr.Status...)
+		}
+		return err
+	}
+	err := ssn.predicateFn(task, node)
+	ssn.equivalence.setPredicate(class, node.Name, err)
+	return err
+}
+
+func (ssn *Session) predicateFn(task *api.TaskInfo, node *api.NodeInfo) error {
 	for _, tier := range ssn.Tiers {
 		for _, plugin := range tier.Plugins {
 			if !isEnabled(plugin.EnabledPredicate) {
@@ -665,6 +755,20 @@ func (ssn *Session) PredicateFn(task *api.TaskInfo, node *api.NodeInfo) error {
 	return nil
 }
 
+// NodeIndexFilters returns the node index filters of the plugins whose predicates are enabled
+func (ssn *Session) NodeIndexFilters() api.NodeIndexFilter {
+	var filters api.NodeIndexFilter
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if !isEnabled(plugin.EnabledPredicate) {
+				continue
+			}
+			filters |= ssn.nodeIndexFilters[plugin.Name]
+		}
+	}
+	return filters
+}
+
 // PrePredicateFn invoke predicate function of the plugins
 func (ssn *Session) PrePredicateFn(task *api.TaskInfo) error {
 	for _, tier := range ssn.Tiers {
@@ -756,8 +860,24 @@ func isEnabled(enabled *bool) bool {
 	return enabled != nil && *enabled
 }
 
-// NodeOrderMapFn invoke node order function of the plugins
+// NodeOrderMapFn invoke node order function of the plugins, the scores are shared by the tasks
+// of the same equivalence class on the node so the callers must not change the returned map.
 func (ssn *Session) NodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[string]float64, float64, error) {
+	class := ssn.equivalenceClass(task, node)
+	if class == "" {
+		return ssn.nodeOrderMapFn(task, node)
+	}
+	if score, found := ssn.equivalence.score(class, node.Name); found {
+		return score.mapScores, score.orderScore, nil
+	}
+	nodeScoreMap, priorityScore, err := ssn.nodeOrderMapFn(task, node)
+	if err == nil {
+		ssn.equivalence.setScore(class, node.Name, &equivalenceScore{mapScores: nodeScoreMap, orderScore: priorityScore})
+	}
+	return nodeScoreMap, priorityScore, err
+}
+
+func (ssn *Session) nodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[string]float64, float64, error) {
 	nodeScoreMap := map[string]float64{}
 	var priorityScore float64
 	for _, tier := range ssn.Tiers {
@@ -808,12 +928,18 @@ func (ssn *Session) NodeOrderReduceFn(task *api.TaskInfo, pluginNodeScoreMap map
 }
 
 // BuildVictimsPriorityQueue returns a priority queue with victims sorted by:
+// the elastic tasks of the jobs first, see JobInfo.ElasticTasks
 // if victims has same job id, sorted by !ssn.TaskOrderFn
 // if victims has different job id, sorted by !ssn.JobOrderFn
 func (ssn *Session) BuildVictimsPriorityQueue(victims []*api.TaskInfo, preemptor *api.TaskInfo) *util.PriorityQueue {
+	elastic := ssn.elasticTasks(victims)
 	victimsQueue := util.NewPriorityQueue(func(l, r interface{}) bool {
 		lv := l.(*api.TaskInfo)
 		rv := r.(*api.TaskInfo)
+		// The elastic tasks are evicted before the ones the jobs need to keep running.
+		if elastic[lv.UID] != elastic[rv.UID] {
+			return elastic[lv.UID]
+		}
 		if lv.Job == rv.Job {
 			return !ssn.TaskOrderFn(l, r)
 		}
@@ -19,10 +19,12 @@ package framework
 import (
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/util/tracing"
 )
 
 // Operation type
@@ -77,6 +79,7 @@ func (s *Statement) Evict(reclaimee *api.TaskInfo, reason string) error {
 				reclaimee.Namespace, reclaimee.Name, reclaimee.NodeName, err.Error())
 			return err
 		}
+		s.ssn.nodeIndex.UpdateNode(node)
 	}
 
 	for _, eh := range s.ssn.eventHandlers {
@@ -128,6 +131,7 @@ func (s *Statement) unevict(reclaimee *api.TaskInfo) error {
 				reclaimee.Namespace, reclaimee.Name, reclaimee.NodeName, err.Error())
 			return err
 		}
+		s.ssn.nodeIndex.UpdateNode(node)
 	}
 
 	for _, eh := range s.ssn.eventHandlers {
@@ -167,6 +171,7 @@ func (s *Statement) Pipeline(task *api.TaskInfo, hostname string, evictionOccurr
 				task.Namespace, task.Name, hostname, s.ssn.UID, err)
 			errInfos = append(errInfos, err)
 		}
+		s.ssn.nodeIndex.UpdateNode(node)
 		klog.V(3).Infof("After pipelined Task <%v/%v> to Node <%v>: idle <%v>, used <%v>, releasing <%v>",
 			task.Namespace, task.Name, node.Name, node.Idle, node.Used, node.Releasing)
 	} else {
@@ -222,6 +227,7 @@ func (s *Statement) UnPipeline(task *api.TaskInfo) error {
 			klog.Errorf("Failed to remove task <%v/%v> to node <%v> when unpipeline in Session <%v>: %v",
 				task.Namespace, task.Name, task.NodeName, s.ssn.UID, err)
 		}
+		s.ssn.nodeIndex.UpdateNode(node)
 		klog.V(3).Infof("After unpipelined Task <%v/%v> to Node <%v>: idle <%v>, used <%v>, releasing <%v>",
 			task.Namespace, task.Name, node.Name, node.Idle, node.Used, node.Releasing)
 	} else {
@@ -264,8 +270,20 @@ func (s *Statement) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 		errInfos = append(errInfos, err)
 	}
 
+	podClaims, err := s.ssn.cache.GetPodClaims(task, nodeInfo.Node)
+	if err != nil {
+		klog.Errorf("Failed to get resource claims for task %v/%v on node %v when allocating in Session <%v>: %v",
+			task.Namespace, task.Name, hostname, s.ssn.UID, err)
+		errInfos = append(errInfos, err)
+	} else if err := s.ssn.cache.AllocateClaims(task, podClaims); err != nil {
+		klog.Errorf("Failed to allocate resource claims for task %v/%v on node %v when allocating in Session <%v>: %v",
+			task.Namespace, task.Name, hostname, s.ssn.UID, err)
+		errInfos = append(errInfos, err)
+	}
+
 	task.Pod.Spec.NodeName = hostname
 	task.PodVolumes = podVolumes
+	task.PodClaims = podClaims
 
 	// Only update status in session
 	job, found := s.ssn.Jobs[task.Job]
@@ -289,6 +307,7 @@ func (s *Statement) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 				task.Namespace, task.Name, hostname, s.ssn.UID, err)
 			errInfos = append(errInfos, err)
 		}
+		s.ssn.nodeIndex.UpdateNode(node)
 		klog.V(3).Infof("After allocated Task <%v/%v> to Node <%v>: idle <%v>, used <%v>, releasing <%v>",
 			task.Namespace, task.Name, node.Name, node.Idle, node.Used, node.Releasing)
 	} else {
@@ -357,6 +376,7 @@ func (s *Statement) allocate(task *api.TaskInfo) error {
 // unallocate the pod for task
 func (s *Statement) unallocate(task *api.TaskInfo) error {
 	s.ssn.cache.RevertVolumes(task, task.PodVolumes)
+	s.ssn.cache.RevertClaims(task, task.PodClaims)
 
 	// Update status in session
 	job, found := s.ssn.Jobs[task.Job]
@@ -376,6 +396,7 @@ func (s *Statement) unallocate(task *api.TaskInfo) error {
 		if err != nil {
 			klog.Errorf("Failed to remove Task <%v> on node <%v> when unallocating: %s", task.Name, task.NodeName, err.Error())
 		}
+		s.ssn.nodeIndex.UpdateNode(node)
 	}
 
 	for _, eh := range s.ssn.eventHandlers {
@@ -416,9 +437,33 @@ func (s *Statement) Discard() {
 	}
 }
 
+// spanAttributes returns the span attributes of the job the statement allocates or pipelines the tasks of,
+// or of the job of the first operation if the statement only evicts tasks.
+func (s *Statement) spanAttributes() []attribute.KeyValue {
+	if len(s.operations) == 0 {
+		return nil
+	}
+	task := s.operations[0].task
+	for _, op := range s.operations {
+		if op.name != Evict {
+			task = op.task
+			break
+		}
+	}
+	job, found := s.ssn.Jobs[task.Job]
+	if !found {
+		return nil
+	}
+	return append(jobAttributes(job), tracing.OperationsKey.Int(len(s.operations)))
+}
+
 // Commit operation for evict and pipeline
 func (s *Statement) Commit() {
 	klog.V(3).Info("Committing operations ...")
+	ctx, span := s.ssn.startSpan("Statement.Commit", s.spanAttributes()...)
+	defer span.End()
+	traceParent := tracing.TraceParent(ctx)
+
 	for _, op := range s.operations {
 		op.task.ClearLastTxContext()
 		switch op.name {
@@ -430,6 +475,7 @@ func (s *Statement) Commit() {
 		case Pipeline:
 			s.pipeline(op.task)
 		case Allocate:
+			op.task.TraceParent = traceParent
 			err := s.allocate(op.task)
 			if err != nil {
 				if e := s.unallocate(op.task); e != nil {
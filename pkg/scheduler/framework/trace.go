@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"sort"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/trace"
+)
+
+// traceRecorder receives the decision trace of every closed session, nil disables the trace.
+var traceRecorder *trace.Recorder
+
+// SetTraceRecorder sets the recorder the decision trace of every session is added to,
+// a nil recorder disables the decision trace.
+func SetTraceRecorder(r *trace.Recorder) {
+	traceRecorder = r
+}
+
+// sessionTracer collects the decision trace of a session.
+type sessionTracer struct {
+	mutex sync.Mutex
+	trace *trace.SessionTrace
+	// action is the action currently executed in the session.
+	action      string
+	jobs        map[api.JobID]*trace.JobTrace
+	queueVetoes map[api.QueueID][]*trace.Veto
+	// vetoReasons are the reasons the plugins gave for their latest vote, keyed by plugin.
+	vetoReasons map[string]string
+}
+
+func newSessionTracer(ssn *Session) *sessionTracer {
+	if traceRecorder == nil {
+		return nil
+	}
+	return &sessionTracer{
+		trace: &trace.SessionTrace{
+			Session:   string(ssn.UID),
+			StartTime: metav1.Now(),
+		},
+		jobs:        map[api.JobID]*trace.JobTrace{},
+		queueVetoes: map[api.QueueID][]*trace.Veto{},
+		vetoReasons: map[string]string{},
+	}
+}
+
+// RecordVetoReason records why the plugin votes against the job it is called for, the reason is
+// reported with the veto in the decision trace if the vote rejects the job.
+func (ssn *Session) RecordVetoReason(plugin, reason string) {
+	st := ssn.tracer
+	if st == nil {
+		return
+	}
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.vetoReasons[plugin] = reason
+}
+
+// takeVetoReason returns and forgets the reason the plugin gave for its latest vote.
+func (ssn *Session) takeVetoReason(plugin string) string {
+	st := ssn.tracer
+	if st == nil {
+		return ""
+	}
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	reason := st.vetoReasons[plugin]
+	delete(st.vetoReasons, plugin)
+	return reason
+}
+
+// StartAction marks the beginning of the given action in the session,
+// the decision trace attributes the following job visits and vetoes to it.
+func (ssn *Session) StartAction(name string) {
+	st := ssn.tracer
+	if st == nil {
+		return
+	}
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.action = name
+	st.trace.Actions = append(st.trace.Actions, name)
+}
+
+// jobTrace returns the trace of the job, the caller must hold the lock.
+func (st *sessionTracer) jobTrace(job *api.JobInfo) *trace.JobTrace {
+	jt, found := st.jobs[job.UID]
+	if !found {
+		jt = &trace.JobTrace{
+			Namespace: job.Namespace,
+			Name:      job.Name,
+			Queue:     string(job.Queue),
+		}
+		st.jobs[job.UID] = jt
+	}
+	return jt
+}
+
+// traceJobVisit records that the current action visited the job.
+func (ssn *Session) traceJobVisit(obj interface{}) {
+	st := ssn.tracer
+	job, ok := obj.(*api.JobInfo)
+	if st == nil || !ok {
+		return
+	}
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	jt := st.jobTrace(job)
+	if st.action != "" && (len(jt.Actions) == 0 || jt.Actions[len(jt.Actions)-1] != st.action) {
+		jt.Actions = append(jt.Actions, st.action)
+	}
+}
+
+// traceJobVeto records that the plugin rejected the job at the extension point.
+func (ssn *Session) traceJobVeto(obj interface{}, plugin, extensionPoint, reason string) {
+	st := ssn.tracer
+	job, ok := obj.(*api.JobInfo)
+	if st == nil || !ok {
+		return
+	}
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	jt := st.jobTrace(job)
+	jt.Vetoes = appendVeto(jt.Vetoes, &trace.Veto{
+		Action:         st.action,
+		Plugin:         plugin,
+		ExtensionPoint: extensionPoint,
+		Reason:         reason,
+	})
+}
+
+// traceQueueVeto records that the plugin rejected the queue at the extension point,
+// the veto is reported for every job of the queue left with pending tasks.
+func (ssn *Session) traceQueueVeto(queue *api.QueueInfo, plugin, extensionPoint, reason string) {
+	st := ssn.tracer
+	if st == nil || queue == nil {
+		return
+	}
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	st.queueVetoes[queue.UID] = appendVeto(st.queueVetoes[queue.UID], &trace.Veto{
+		Action:         st.action,
+		Plugin:         plugin,
+		ExtensionPoint: extensionPoint,
+		Reason:         reason,
+	})
+}
+
+// appendVeto appends the veto unless the same veto is already recorded,
+// e.g. when allocatable rejects every task of a job.
+func appendVeto(vetoes []*trace.Veto, veto *trace.Veto) []*trace.Veto {
+	for _, v := range vetoes {
+		if *v == *veto {
+			return vetoes
+		}
+	}
+	return append(vetoes, veto)
+}
+
+// finishTrace completes the decision trace with the final state of the jobs and adds it
+// to the recorder, it must be called before the plugins are closed.
+func (ssn *Session) finishTrace() {
+	st := ssn.tracer
+	if st == nil || traceRecorder == nil {
+		return
+	}
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	for _, job := range ssn.Jobs {
+		jt := st.jobTrace(job)
+		pending := len(job.TaskStatusIndex[api.Pending]) != 0
+		if pending {
+			for _, veto := range st.queueVetoes[job.Queue] {
+				jt.Vetoes = appendVeto(jt.Vetoes, veto)
+			}
+			if job.PodGroup != nil {
+				jt.Message = job.FitError()
+			}
+		}
+
+		for taskID, fitErrors := range job.NodesFitErrors {
+			task, found := job.Tasks[taskID]
+			if !found || fitErrors == nil {
+				continue
+			}
+			jt.Tasks = append(jt.Tasks, newTaskTrace(task, fitErrors))
+		}
+		sort.Slice(jt.Tasks, func(i, j int) bool {
+			return jt.Tasks[i].Name < jt.Tasks[j].Name
+		})
+	}
+
+	for _, jt := range st.jobs {
+		st.trace.Jobs = append(st.trace.Jobs, jt)
+	}
+	sort.Slice(st.trace.Jobs, func(i, j int) bool {
+		if st.trace.Jobs[i].Namespace != st.trace.Jobs[j].Namespace {
+			return st.trace.Jobs[i].Namespace < st.trace.Jobs[j].Namespace
+		}
+		return st.trace.Jobs[i].Name < st.trace.Jobs[j].Name
+	})
+	st.trace.EndTime = metav1.Now()
+
+	traceRecorder.Add(st.trace)
+}
+
+func newTaskTrace(task *api.TaskInfo, fitErrors *api.FitErrors) *trace.TaskTrace {
+	tt := &trace.TaskTrace{
+		Name:       task.Name,
+		Reasons:    map[string]int{},
+		NodeErrors: map[string][]string{},
+	}
+
+	nodes := fitErrors.Nodes()
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		reasons := nodes[name].Reasons()
+		for _, reason := range reasons {
+			tt.Reasons[reason]++
+		}
+		if len(tt.NodeErrors) < trace.MaxNodeErrors {
+			tt.NodeErrors[name] = reasons
+		}
+	}
+	if len(nodes) == 0 {
+		tt.Reasons[fitErrors.Error()]++
+	}
+	return tt
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/trace"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestSessionDecisionTrace(t *testing.T) {
+	recorder := trace.NewRecorder(2)
+	SetTraceRecorder(recorder)
+	defer SetTraceRecorder(nil)
+
+	scherCache := cache.NewDefaultMockSchedulerCache("test-scheduler")
+	scherCache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("1", "1Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	scherCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue))
+	scherCache.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("2", "1G"), "pg1", nil, nil))
+	scherCache.AddPod(util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", nil, nil))
+
+	enabled := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{Name: "fake", EnabledOverused: &enabled}}}}
+	ssn := OpenSession(scherCache, tiers, nil)
+	ssn.AddJobValidFn("fake", func(obj interface{}) *api.ValidateResult {
+		if obj.(*api.JobInfo).Name == "pg2" {
+			return &api.ValidateResult{Pass: false, Reason: "NotEnoughPods", Message: "not enough valid pods"}
+		}
+		return nil
+	})
+	ssn.AddOverusedFn("fake", func(obj interface{}) bool { return true })
+
+	ssn.StartAction("allocate")
+	for _, job := range ssn.Jobs {
+		ssn.JobValid(job)
+		for _, task := range job.TaskStatusIndex[api.Pending] {
+			if task.Name != "p1" {
+				continue
+			}
+			fe := api.NewFitErrors()
+			fe.SetNodeError("n1", api.NewFitError(task, ssn.Nodes["n1"], "Insufficient cpu"))
+			job.NodesFitErrors[task.UID] = fe
+		}
+	}
+	ssn.Overused(ssn.Queues["q1"])
+	CloseSession(ssn)
+
+	traces := recorder.Traces()
+	if !assert.Equal(t, 1, len(traces)) {
+		return
+	}
+	assert.Equal(t, []string{"allocate"}, traces[0].Actions)
+
+	pg1 := traces[0].Job("c1", "pg1")
+	if assert.NotNil(t, pg1) {
+		assert.Equal(t, []string{"allocate"}, pg1.Actions)
+		assert.Equal(t, []*trace.Veto{{Action: "allocate", Plugin: "fake", ExtensionPoint: trace.Overused, Reason: "queue <q1> is overused"}}, pg1.Vetoes)
+		if assert.Equal(t, 1, len(pg1.Tasks)) {
+			assert.Equal(t, "p1", pg1.Tasks[0].Name)
+			assert.Equal(t, map[string]int{"Insufficient cpu": 1}, pg1.Tasks[0].Reasons)
+			assert.Equal(t, map[string][]string{"n1": {"Insufficient cpu"}}, pg1.Tasks[0].NodeErrors)
+		}
+		assert.NotEmpty(t, pg1.Message)
+	}
+
+	pg2 := traces[0].Job("c1", "pg2")
+	if assert.NotNil(t, pg2) && assert.Equal(t, 2, len(pg2.Vetoes)) {
+		assert.Equal(t, &trace.Veto{Action: "allocate", Plugin: "fake", ExtensionPoint: trace.JobValid, Reason: "NotEnoughPods: not enough valid pods"}, pg2.Vetoes[0])
+	}
+}
+
+func TestJobEnqueueableVetoReason(t *testing.T) {
+	recorder := trace.NewRecorder(1)
+	SetTraceRecorder(recorder)
+	defer SetTraceRecorder(nil)
+
+	scherCache := cache.NewDefaultMockSchedulerCache("test-scheduler")
+	scherCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupPending))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1.PodGroupPending))
+
+	enabled := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{Name: "quota", EnabledJobEnqueued: &enabled}, {Name: "silent", EnabledJobEnqueued: &enabled}}}}
+	ssn := OpenSession(scherCache, tiers, nil)
+	ssn.AddJobEnqueueableFn("quota", func(obj interface{}) int {
+		if obj.(*api.JobInfo).Name == "pg1" {
+			ssn.RecordVetoReason("quota", "queue <q1> resource quota insufficient")
+			return -1
+		}
+		return 0
+	})
+	ssn.AddJobEnqueueableFn("silent", func(obj interface{}) int {
+		return -1
+	})
+
+	ssn.StartAction("enqueue")
+	assert.False(t, ssn.JobEnqueueable(ssn.Jobs["c1/pg1"]))
+	assert.False(t, ssn.JobEnqueueable(ssn.Jobs["c1/pg2"]))
+	CloseSession(ssn)
+
+	traces := recorder.Traces()
+	if !assert.Equal(t, 1, len(traces)) {
+		return
+	}
+	assert.Equal(t, []*trace.Veto{{Action: "enqueue", Plugin: "quota", ExtensionPoint: trace.JobEnqueueable, Reason: "queue <q1> resource quota insufficient"}},
+		traces[0].Job("c1", "pg1").Vetoes)
+	assert.Equal(t, []*trace.Veto{{Action: "enqueue", Plugin: "silent", ExtensionPoint: trace.JobEnqueueable, Reason: "job is not enqueueable"}},
+		traces[0].Job("c1", "pg2").Vetoes)
+}
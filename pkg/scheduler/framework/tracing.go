@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/util/tracing"
+)
+
+// ExecuteAction executes the action in the session. The action is traced in a span of its own,
+// the statements it commits are traced as children of the span.
+func ExecuteAction(ssn *Session, action Action) {
+	ctx, span := ssn.startSpan("Action.Execute", tracing.ActionKey.String(action.Name()))
+	defer span.End()
+	sessionCtx := ssn.ctx
+	ssn.ctx = ctx
+	defer func() { ssn.ctx = sessionCtx }()
+
+	ssn.StartAction(action.Name())
+	action.Execute(ssn)
+}
+
+// startSpan starts a span as a child of the current span of the session, dry run sessions are not traced.
+func (ssn *Session) startSpan(name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ssn.dryRun {
+		return ssn.ctx, noop.Span{}
+	}
+	return tracing.Start(ssn.ctx, name, attrs...)
+}
+
+// jobAttributes returns the span attributes of the job and its queue.
+func jobAttributes(job *api.JobInfo) []attribute.KeyValue {
+	return tracing.JobAttributes(job.Namespace+"/"+job.Name, string(job.Queue))
+}
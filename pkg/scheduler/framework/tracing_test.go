@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/util"
+	"volcano.sh/volcano/pkg/util/tracing"
+)
+
+type tracedPlugin struct{}
+
+func (tp *tracedPlugin) Name() string            { return "traced" }
+func (tp *tracedPlugin) OnSessionOpen(*Session)  {}
+func (tp *tracedPlugin) OnSessionClose(*Session) {}
+
+// allocateAction allocates all the pending tasks of the session to n1 in one statement.
+type allocateAction struct {
+	allocated []*api.TaskInfo
+}
+
+func (aa *allocateAction) Name() string  { return "allocate" }
+func (aa *allocateAction) Initialize()   {}
+func (aa *allocateAction) UnInitialize() {}
+func (aa *allocateAction) Execute(ssn *Session) {
+	stmt := NewStatement(ssn)
+	for _, job := range ssn.Jobs {
+		for _, task := range job.TaskStatusIndex[api.Pending] {
+			if err := stmt.Allocate(task, ssn.Nodes["n1"]); err == nil {
+				aa.allocated = append(aa.allocated, task)
+			}
+		}
+	}
+	stmt.Commit()
+}
+
+func TestSessionSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	RegisterPluginBuilder("traced", func(Arguments) Plugin { return &tracedPlugin{} })
+	defer CleanupPluginBuilders()
+
+	scherCache := cache.NewDefaultMockSchedulerCache("test-scheduler")
+	scherCache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	scherCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue))
+	scherCache.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+
+	ctx, root := provider.Tracer("test").Start(context.Background(), "runOnce")
+	ssn := OpenSessionWithContext(ctx, scherCache, []conf.Tier{{Plugins: []conf.PluginOption{{Name: "traced"}}}}, nil)
+	action := &allocateAction{}
+	ExecuteAction(ssn, action)
+	CloseSession(ssn)
+	root.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	for _, name := range []string{"OnSessionOpen", "Action.Execute", "OnSessionClose"} {
+		assert.Contains(t, spans, name)
+		assert.Equal(t, root.SpanContext().SpanID(), spans[name].Parent().SpanID(), "%s is a child of the session span", name)
+	}
+	assert.Contains(t, spans["OnSessionOpen"].Attributes(), tracing.PluginKey.String("traced"))
+	assert.Contains(t, spans["Action.Execute"].Attributes(), tracing.ActionKey.String("allocate"))
+
+	commit := spans["Statement.Commit"]
+	assert.Equal(t, spans["Action.Execute"].SpanContext().SpanID(), commit.Parent().SpanID(), "the statement is a child of the action span")
+	assert.Contains(t, commit.Attributes(), tracing.JobKey.String("c1/pg1"))
+	assert.Contains(t, commit.Attributes(), tracing.QueueKey.String("q1"))
+
+	assert.Len(t, action.allocated, 1)
+	bound := trace.SpanContextFromContext(tracing.ContextWithTraceParent(context.Background(), action.allocated[0].TraceParent))
+	assert.Equal(t, commit.SpanContext().SpanID(), bound.SpanID(), "the task is bound in the trace of the statement")
+}
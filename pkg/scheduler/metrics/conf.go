@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+)
+
+const (
+	// SchedulerConf labels the metrics of the scheduler configuration
+	SchedulerConf = "scheduler"
+	// ShadowSchedulerConf labels the metrics of the shadow scheduler configuration
+	ShadowSchedulerConf = "shadow"
+
+	// ConfReloadSucceeded labels the reloads applying the configuration
+	ConfReloadSucceeded = "succeeded"
+	// ConfReloadFailed labels the reloads rejecting the configuration, the previous one is kept
+	ConfReloadFailed = "failed"
+)
+
+var (
+	confReloads = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "scheduler_conf_reloads_total",
+			Help:      "Total number of scheduler configuration reloads, by configuration and result",
+		}, []string{"conf", "result"},
+	)
+
+	confValid = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "scheduler_conf_valid",
+			Help:      "Whether the latest reload of the scheduler configuration succeeded (1) or failed (0)",
+		}, []string{"conf"},
+	)
+)
+
+// UpdateSchedulerConfReload records the result of reloading the given configuration
+func UpdateSchedulerConfReload(conf string, succeeded bool) {
+	if succeeded {
+		confReloads.WithLabelValues(conf, ConfReloadSucceeded).Inc()
+		confValid.WithLabelValues(conf).Set(1)
+		return
+	}
+	confReloads.WithLabelValues(conf, ConfReloadFailed).Inc()
+	confValid.WithLabelValues(conf).Set(0)
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+)
+
+const (
+	// ElasticScaleUp labels the elastic pods allocated to the jobs
+	ElasticScaleUp = "up"
+	// ElasticScaleDown labels the elastic pods evicted from the jobs
+	ElasticScaleDown = "down"
+)
+
+var elasticScaledPods = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: VolcanoNamespace,
+		Name:      "elastic_scaled_pods_total",
+		Help:      "Total number of pods above the minAvailable of the jobs allocated or evicted, by direction",
+	}, []string{"direction"},
+)
+
+// RegisterElasticScaling records the elastic pods allocated to or evicted from a job
+func RegisterElasticScaling(direction string, pods int32) {
+	elasticScaledPods.WithLabelValues(direction).Add(float64(pods))
+}
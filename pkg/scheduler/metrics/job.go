@@ -37,6 +37,14 @@ var (
 			Help:      "Number of retry counts for one job",
 		}, []string{"job_id"},
 	)
+
+	jobDeadlineMissPredicted = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "job_deadline_miss_predicted",
+			Help:      "Whether one job with a deadline is predicted to miss it given the current capacity",
+		}, []string{"job_ns", "job_id"},
+	)
 )
 
 // UpdateJobShare records share for one job
@@ -49,6 +57,15 @@ func RegisterJobRetries(jobID string) {
 	jobRetryCount.WithLabelValues(jobID).Inc()
 }
 
+// UpdateJobDeadlineMissPredicted records whether one job is predicted to miss its deadline
+func UpdateJobDeadlineMissPredicted(jobNs, jobID string, miss bool) {
+	value := 0.0
+	if miss {
+		value = 1
+	}
+	jobDeadlineMissPredicted.WithLabelValues(jobNs, jobID).Set(value)
+}
+
 // DeleteJobMetrics delete all metrics related to the job
 func DeleteJobMetrics(jobName, queue, namespace string) {
 	e2eJobSchedulingDuration.DeleteLabelValues(jobName, queue, namespace)
@@ -57,4 +74,5 @@ func DeleteJobMetrics(jobName, queue, namespace string) {
 	unscheduleTaskCount.DeleteLabelValues(jobName)
 	jobShare.DeleteLabelValues(namespace, jobName)
 	jobRetryCount.DeleteLabelValues(jobName)
+	jobDeadlineMissPredicted.DeleteLabelValues(namespace, jobName)
 }
@@ -45,6 +45,14 @@ var (
 			Help:      "Weighted share for one namespace",
 		}, []string{"namespace_name"},
 	)
+
+	namespaceEffectiveShare = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "namespace_effective_share",
+			Help:      "Share for one namespace accounting for its decayed usage, relative to its fair share",
+		}, []string{"namespace_name"},
+	)
 )
 
 // UpdateNamespaceShare records share for one namespace
@@ -61,3 +69,8 @@ func UpdateNamespaceWeight(namespaceName string, weight int64) {
 func UpdateNamespaceWeightedShare(namespaceName string, weightedShare float64) {
 	namespaceWeightedShare.WithLabelValues(namespaceName).Set(weightedShare)
 }
+
+// UpdateNamespaceEffectiveShare records the effective share for one namespace
+func UpdateNamespaceEffectiveShare(namespaceName string, effectiveShare float64) {
+	namespaceEffectiveShare.WithLabelValues(namespaceName).Set(effectiveShare)
+}
@@ -93,6 +93,54 @@ var (
 			Help:      "If one queue is overused",
 		}, []string{"queue_name"},
 	)
+
+	queueDecayedShare = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "queue_decayed_share",
+			Help:      "Average share for one queue over time, the older usage being decayed",
+		}, []string{"queue_name"},
+	)
+
+	queueEffectiveShare = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "queue_effective_share",
+			Help:      "Share for one queue accounting for its decayed usage, relative to its fair share",
+		}, []string{"queue_name"},
+	)
+
+	queueInqueueResource = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "queue_inqueue_resource",
+			Help:      "Resources of the inqueue jobs for one queue, by resource, the cpu in millicores",
+		}, []string{"queue_name", "resource"},
+	)
+
+	queueOvercommitCapacity = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "queue_overcommit_capacity",
+			Help:      "Idle resources of the cluster overcommitted by the factors of one queue, which the overcommit inqueue resources with the job are compared with, by resource, the cpu in millicores",
+		}, []string{"queue_name", "resource"},
+	)
+
+	overcommitInqueueResource = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "overcommit_inqueue_resource",
+			Help:      "Resources of the inqueue jobs of all the queues, which with the job are compared with the overcommit capacity of the queue of the job, by resource, the cpu in millicores",
+		}, []string{"resource"},
+	)
+
+	queueEvictions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "queue_evictions_total",
+			Help:      "Total number of pods evicted by the preemption and the reclaim, by queue of the preemptor and queue of the victim",
+		}, []string{"preemptor_queue", "victim_queue"},
+	)
 )
 
 // UpdateQueueAllocated records allocated resources for one queue
@@ -134,6 +182,29 @@ func UpdateQueueOverused(queueName string, overused bool) {
 	queueOverused.WithLabelValues(queueName).Set(value)
 }
 
+// UpdateQueueFairShare records the decayed and effective shares for one queue
+func UpdateQueueFairShare(queueName string, decayedShare, effectiveShare float64) {
+	queueDecayedShare.WithLabelValues(queueName).Set(decayedShare)
+	queueEffectiveShare.WithLabelValues(queueName).Set(effectiveShare)
+}
+
+// UpdateQueueOvercommit records the inqueue resource and the overcommit capacity of the resource for one queue
+func UpdateQueueOvercommit(queueName, resourceName string, inqueue, capacity float64) {
+	queueInqueueResource.WithLabelValues(queueName, resourceName).Set(inqueue)
+	queueOvercommitCapacity.WithLabelValues(queueName, resourceName).Set(capacity)
+}
+
+// UpdateOvercommitInqueue records the inqueue resource of all the queues the overcommit capacity of a queue is
+// compared with
+func UpdateOvercommitInqueue(resourceName string, inqueue float64) {
+	overcommitInqueueResource.WithLabelValues(resourceName).Set(inqueue)
+}
+
+// RegisterQueueEviction records a pod of the victim queue evicted for a pod of the preemptor queue
+func RegisterQueueEviction(preemptorQueue, victimQueue string) {
+	queueEvictions.WithLabelValues(preemptorQueue, victimQueue).Inc()
+}
+
 // DeleteQueueMetrics delete all metrics related to the queue
 func DeleteQueueMetrics(queueName string) {
 	queueAllocatedMilliCPU.DeleteLabelValues(queueName)
@@ -145,4 +216,10 @@ func DeleteQueueMetrics(queueName string) {
 	queueShare.DeleteLabelValues(queueName)
 	queueWeight.DeleteLabelValues(queueName)
 	queueOverused.DeleteLabelValues(queueName)
+	queueDecayedShare.DeleteLabelValues(queueName)
+	queueEffectiveShare.DeleteLabelValues(queueName)
+	queueInqueueResource.DeletePartialMatch(prometheus.Labels{"queue_name": queueName})
+	queueOvercommitCapacity.DeletePartialMatch(prometheus.Labels{"queue_name": queueName})
+	queueEvictions.DeletePartialMatch(prometheus.Labels{"preemptor_queue": queueName})
+	queueEvictions.DeletePartialMatch(prometheus.Labels{"victim_queue": queueName})
 }
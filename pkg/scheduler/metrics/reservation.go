@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+)
+
+const (
+	// ReservationCreated labels the reservations of nodes made for a starving job
+	ReservationCreated = "created"
+	// ReservationSatisfied labels the reservations released because the job is ready
+	ReservationSatisfied = "satisfied"
+	// ReservationExpired labels the reservations released because they timed out
+	ReservationExpired = "expired"
+	// ReservationCancelled labels the reservations released because the job is gone or does not need them anymore
+	ReservationCancelled = "cancelled"
+)
+
+var (
+	reservations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "reservations_total",
+			Help:      "Total number of node reservations for starving jobs, by result",
+		}, []string{"result"},
+	)
+
+	reservedNodes = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "reserved_nodes",
+			Help:      "Number of nodes currently reserved for a starving job",
+		},
+	)
+
+	reservationBackfilledTasks = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "reservation_backfilled_tasks_total",
+			Help:      "Total number of tasks of other jobs allocated on reserved nodes because they finish before the reservation is satisfied",
+		},
+	)
+
+	reservationDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "reservation_duration_seconds",
+			Help:      "Time the nodes stayed reserved for a starving job, by result",
+			Buckets:   prometheus.ExponentialBuckets(10, 2, 12),
+		}, []string{"result"},
+	)
+)
+
+// RegisterReservationCreated records a reservation of the given number of nodes
+func RegisterReservationCreated(nodes int) {
+	reservations.WithLabelValues(ReservationCreated).Inc()
+	reservedNodes.Set(float64(nodes))
+}
+
+// RegisterReservationReleased records the release of a reservation with the given result
+func RegisterReservationReleased(result string, duration time.Duration) {
+	reservations.WithLabelValues(result).Inc()
+	reservationDuration.WithLabelValues(result).Observe(duration.Seconds())
+	reservedNodes.Set(0)
+}
+
+// RegisterReservationBackfilledTasks records the tasks of other jobs allocated on reserved nodes
+func RegisterReservationBackfilledTasks(count int) {
+	reservationBackfilledTasks.Add(float64(count))
+}
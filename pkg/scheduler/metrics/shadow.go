@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+)
+
+const (
+	// LiveSession labels the metrics of the session running the live configuration
+	LiveSession = "live"
+	// ShadowSession labels the metrics of the dry run session running the shadow configuration
+	ShadowSession = "shadow"
+)
+
+var (
+	shadowJobsStarted = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "shadow_jobs_started",
+			Help:      "Number of jobs with tasks bound in the latest session, by live or shadow configuration",
+		}, []string{"session"},
+	)
+
+	shadowPreemptions = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "shadow_preemptions",
+			Help:      "Number of tasks evicted in the latest session, by live or shadow configuration",
+		}, []string{"session"},
+	)
+
+	shadowPlacementDivergence = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "shadow_placement_divergence_ratio",
+			Help:      "Ratio of the tasks bound in the latest session that the live and shadow configuration place differently",
+		},
+	)
+
+	shadowSessions = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoNamespace,
+			Name:      "shadow_sessions_total",
+			Help:      "Total number of sessions run with the shadow configuration",
+		},
+	)
+)
+
+// UpdateShadowSessionResult records the decisions of a session of the given kind, live or shadow
+func UpdateShadowSessionResult(session string, jobsStarted, preemptions int) {
+	shadowJobsStarted.WithLabelValues(session).Set(float64(jobsStarted))
+	shadowPreemptions.WithLabelValues(session).Set(float64(preemptions))
+}
+
+// UpdateShadowPlacementDivergence records the placement divergence of the live and shadow session
+func UpdateShadowPlacementDivergence(ratio float64) {
+	shadowPlacementDivergence.Set(ratio)
+	shadowSessions.Inc()
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides a fake metrics client for unit tests, the tests set the metrics of the nodes
+// which are returned as they are by the client.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/client-go/rest"
+
+	"volcano.sh/volcano/pkg/scheduler/metrics/source"
+)
+
+// MetricsType is the metrics type selecting the fake metrics client in the metrics configuration.
+const MetricsType = "fake"
+
+// MetricsClient returns the node metrics set by the tests.
+type MetricsClient struct {
+	sync.Mutex
+
+	// Metrics are the metrics of the nodes by name
+	Metrics map[string]*source.NodeMetrics
+}
+
+// make sure MetricsClient implements source.MetricsClient interface
+var _ source.MetricsClient = new(MetricsClient)
+
+// NewMetricsClient returns a fake metrics client without metrics.
+func NewMetricsClient() *MetricsClient {
+	return &MetricsClient{Metrics: map[string]*source.NodeMetrics{}}
+}
+
+// Register registers the client for the fake metrics type, the tests registering it are expected to call
+// Unregister when done.
+func Register(client *MetricsClient) {
+	source.RegisterMetricsClient(MetricsType, func(_ *rest.Config, _ map[string]string) (source.MetricsClient, error) {
+		return client, nil
+	})
+}
+
+// Unregister unregisters the fake metrics client.
+func Unregister() {
+	source.UnregisterMetricsClient(MetricsType)
+}
+
+// SetNodeMetrics sets the metrics of the node.
+func (c *MetricsClient) SetNodeMetrics(nodeName string, metrics *source.NodeMetrics) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.Metrics[nodeName] = metrics
+}
+
+// NodesMetricsAvg fills the metrics of the nodes which have been set, the others are left as they are.
+func (c *MetricsClient) NodesMetricsAvg(_ context.Context, nodeMetricsMap map[string]*source.NodeMetrics) error {
+	c.Lock()
+	defer c.Unlock()
+
+	for nodeName := range nodeMetricsMap {
+		metrics, found := c.Metrics[nodeName]
+		if !found {
+			continue
+		}
+		nodeMetrics := *metrics
+		if metrics.Resources != nil {
+			nodeMetrics.Resources = make(map[string]float64, len(metrics.Resources))
+			for name, usage := range metrics.Resources {
+				nodeMetrics.Resources[name] = usage
+			}
+		}
+		nodeMetricsMap[nodeName] = &nodeMetrics
+	}
+	return nil
+}
@@ -19,6 +19,9 @@ package source
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/client-go/rest"
@@ -30,30 +33,87 @@ const (
 	Metrics_Type_Prometheus_Adaptor = "prometheus_adaptor"
 	Metrics_Tpye_Prometheus         = "prometheus"
 	Metrics_Type_Elasticsearch      = "elasticsearch"
+	Metrics_Type_OpenMetrics        = "openmetrics"
+	Metrics_Type_HTTP               = "http"
 )
 
 type NodeMetrics struct {
 	MetricsTime time.Time
 	CPU         float64
 	Memory      float64
+	// Resources is the usage of other resources by name, like network, disk IO or GPU utilization.
+	Resources map[string]float64
 }
 
 type MetricsClient interface {
 	NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error
 }
 
+// MetricsClientBuilder builds the metrics client of a metrics type from the metrics configuration.
+type MetricsClientBuilder func(restConfig *rest.Config, metricsConf map[string]string) (MetricsClient, error)
+
+var (
+	metricsClientMutex    sync.RWMutex
+	metricsClientBuilders = map[string]MetricsClientBuilder{}
+)
+
+func init() {
+	RegisterMetricsClient(Metrics_Type_Elasticsearch, func(_ *rest.Config, conf map[string]string) (MetricsClient, error) {
+		return NewElasticsearchMetricsClient(conf)
+	})
+	RegisterMetricsClient(Metrics_Tpye_Prometheus, func(_ *rest.Config, conf map[string]string) (MetricsClient, error) {
+		return NewPrometheusMetricsClient(conf)
+	})
+	RegisterMetricsClient(Metrics_Type_Prometheus_Adaptor, func(restConfig *rest.Config, _ map[string]string) (MetricsClient, error) {
+		return NewCustomMetricsClient(restConfig)
+	})
+	RegisterMetricsClient(Metrics_Type_OpenMetrics, func(_ *rest.Config, conf map[string]string) (MetricsClient, error) {
+		return NewOpenMetricsClient(conf)
+	})
+	RegisterMetricsClient(Metrics_Type_HTTP, func(_ *rest.Config, conf map[string]string) (MetricsClient, error) {
+		return NewHTTPMetricsClient(conf)
+	})
+}
+
+// RegisterMetricsClient registers the builder of the metrics client of the given metrics type,
+// the type is selected by the `type` of the metrics configuration.
+func RegisterMetricsClient(metricsType string, builder MetricsClientBuilder) {
+	metricsClientMutex.Lock()
+	defer metricsClientMutex.Unlock()
+
+	metricsClientBuilders[metricsType] = builder
+}
+
+// UnregisterMetricsClient removes the builder of the metrics client of the given metrics type.
+func UnregisterMetricsClient(metricsType string) {
+	metricsClientMutex.Lock()
+	defer metricsClientMutex.Unlock()
+
+	delete(metricsClientBuilders, metricsType)
+}
+
+// MetricsTypes returns the sorted registered metrics types.
+func MetricsTypes() []string {
+	metricsClientMutex.RLock()
+	defer metricsClientMutex.RUnlock()
+
+	types := make([]string, 0, len(metricsClientBuilders))
+	for metricsType := range metricsClientBuilders {
+		types = append(types, metricsType)
+	}
+	sort.Strings(types)
+	return types
+}
+
 func NewMetricsClient(restConfig *rest.Config, metricsConf map[string]string) (MetricsClient, error) {
 	klog.V(3).Infof("New metrics client begin, metricsConf is %v", metricsConf)
 	metricsType := metricsConf["type"]
-	if metricsType == Metrics_Type_Elasticsearch {
-		return NewElasticsearchMetricsClient(metricsConf)
-	} else if metricsType == Metrics_Tpye_Prometheus {
-		return NewPrometheusMetricsClient(metricsConf)
-	} else if metricsType == Metrics_Type_Prometheus_Adaptor {
-		return NewCustomMetricsClient(restConfig)
-	} else {
+	metricsClientMutex.RLock()
+	builder, found := metricsClientBuilders[metricsType]
+	metricsClientMutex.RUnlock()
+	if !found {
 		return nil, fmt.Errorf("data cannot be collected from the %s monitoring system. "+
-			"The supported monitoring systems are %s, %s, and %s",
-			metricsType, Metrics_Type_Elasticsearch, Metrics_Tpye_Prometheus, Metrics_Type_Prometheus_Adaptor)
+			"The supported monitoring systems are %s", metricsType, strings.Join(MetricsTypes(), ", "))
 	}
+	return builder(restConfig, metricsConf)
 }
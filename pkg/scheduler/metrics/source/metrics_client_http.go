@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// HTTPNodesMetrics is the document served by the endpoint of the HTTP metrics client.
+type HTTPNodesMetrics struct {
+	Nodes []HTTPNodeMetrics `json:"nodes"`
+}
+
+// HTTPNodeMetrics is the average usage of a node in percent.
+type HTTPNodeMetrics struct {
+	Name string `json:"name"`
+	// Timestamp is the time of the metrics, the time of the request if omitted.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+	CPU       float64    `json:"cpu"`
+	Memory    float64    `json:"memory"`
+	// Resources is the usage of other resources by name, like network, disk IO or GPU utilization.
+	Resources map[string]float64 `json:"resources,omitempty"`
+}
+
+// HTTPMetricsClient gets the metrics of all nodes from a JSON document served over HTTP, so that any
+// monitoring system can be plugged in by an adapter serving HTTPNodesMetrics. The configuration is:
+//
+//	type: http
+//	address: http://metrics-adapter.monitoring/nodes
+//	http.bearerToken: <token>
+type HTTPMetricsClient struct {
+	address     string
+	bearerToken string
+	client      *http.Client
+}
+
+func NewHTTPMetricsClient(conf map[string]string) (*HTTPMetricsClient, error) {
+	address := conf["address"]
+	if len(address) == 0 {
+		return nil, errors.New("metrics address is empty")
+	}
+	return &HTTPMetricsClient{
+		address:     address,
+		bearerToken: conf["http.bearerToken"],
+		client:      newMetricsHTTPClient(conf),
+	}, nil
+}
+
+// NodesMetricsAvg gets the metrics of the nodes, the nodes missing in the document are removed
+// from nodeMetricsMap so that their previous metrics are kept until they expire.
+func (h *HTTPMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	klog.V(4).Infof("Get node metrics from HTTP endpoint: %s", h.address)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.address, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if len(h.bearerToken) != 0 {
+		req.Header.Set("Authorization", "Bearer "+h.bearerToken)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from %s", resp.Status, h.address)
+	}
+
+	var nodesMetrics HTTPNodesMetrics
+	if err := json.NewDecoder(resp.Body).Decode(&nodesMetrics); err != nil {
+		return fmt.Errorf("failed to decode the metrics from %s: %v", h.address, err)
+	}
+
+	now := time.Now()
+	found := make(map[string]bool, len(nodesMetrics.Nodes))
+	for _, node := range nodesMetrics.Nodes {
+		if _, ok := nodeMetricsMap[node.Name]; !ok {
+			continue
+		}
+		nodeMetrics := &NodeMetrics{
+			MetricsTime: now,
+			CPU:         node.CPU,
+			Memory:      node.Memory,
+			Resources:   node.Resources,
+		}
+		if node.Timestamp != nil {
+			nodeMetrics.MetricsTime = *node.Timestamp
+		}
+		nodeMetricsMap[node.Name] = nodeMetrics
+		found[node.Name] = true
+	}
+	for nodeName := range nodeMetricsMap {
+		if !found[nodeName] {
+			klog.V(4).Infof("No metrics of node %s from %s", nodeName, h.address)
+			delete(nodeMetricsMap, nodeName)
+		}
+	}
+	return nil
+}
+
+const (
+	// metricsRequestTimeout bounds a single request of the metrics clients.
+	metricsRequestTimeout = 30 * time.Second
+	// metricsIdleConnTimeout closes the idle connections to the monitoring system.
+	metricsIdleConnTimeout = 90 * time.Second
+)
+
+var (
+	// metricsTransports are shared by the metrics clients by TLS configuration. A client is created for
+	// every collection of the metrics, its connections are reused by the next one.
+	metricsTransports     = map[bool]*http.Transport{}
+	metricsTransportsLock sync.Mutex
+)
+
+// newMetricsHTTPClient returns the HTTP client of the metrics clients talking to the monitoring system directly.
+func newMetricsHTTPClient(conf map[string]string) *http.Client {
+	insecureSkipVerify := conf["tls.insecureSkipVerify"] == "true"
+	return &http.Client{
+		Transport: metricsTransport(insecureSkipVerify),
+		Timeout:   metricsRequestTimeout,
+	}
+}
+
+// metricsTransport returns the transport shared by the metrics clients with the TLS configuration.
+func metricsTransport(insecureSkipVerify bool) *http.Transport {
+	metricsTransportsLock.Lock()
+	defer metricsTransportsLock.Unlock()
+
+	if transport, found := metricsTransports[insecureSkipVerify]; found {
+		return transport
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+	transport.IdleConnTimeout = metricsIdleConnTimeout
+	metricsTransports[insecureSkipVerify] = transport
+	return transport
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestHTTPMetricsClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"nodes": [
+			{"name": "n1", "timestamp": "2024-05-01T10:00:00Z", "cpu": 30.5, "memory": 40, "resources": {"gpu": 75, "diskio": 10}},
+			{"name": "n2", "cpu": 10, "memory": 20},
+			{"name": "unknown", "cpu": 99, "memory": 99}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewMetricsClient(nil, map[string]string{
+		"type":             Metrics_Type_HTTP,
+		"address":          server.URL,
+		"http.bearerToken": "secret",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	nodeMetricsMap := map[string]*NodeMetrics{"n1": {}, "n2": {}, "n3": {}}
+	if err := client.NodesMetricsAvg(context.TODO(), nodeMetricsMap); err != nil {
+		t.Fatalf("Failed to get the metrics: %v", err)
+	}
+
+	// n3 is missing in the document, its previous metrics are kept.
+	if len(nodeMetricsMap) != 2 {
+		t.Errorf("Expected the metrics of n1 and n2 only, got %v", nodeMetricsMap)
+	}
+	expected := &NodeMetrics{
+		MetricsTime: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		CPU:         30.5,
+		Memory:      40,
+		Resources:   map[string]float64{"gpu": 75, "diskio": 10},
+	}
+	if n1 := nodeMetricsMap["n1"]; !reflect.DeepEqual(n1, expected) {
+		t.Errorf("Expected metrics %+v of n1, got %+v", expected, n1)
+	}
+	if n2 := nodeMetricsMap["n2"]; n2.MetricsTime.IsZero() || n2.CPU != 10 || n2.Memory != 20 {
+		t.Errorf("Unexpected metrics of n2: %+v", n2)
+	}
+
+	client, _ = NewHTTPMetricsClient(map[string]string{"address": server.URL})
+	if err := client.NodesMetricsAvg(context.TODO(), map[string]*NodeMetrics{"n1": {}}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected the unauthorized error, got %v", err)
+	}
+}
+
+type fakeMetricsClient struct{}
+
+func (f *fakeMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	return nil
+}
+
+func TestMetricsHTTPClientTransport(t *testing.T) {
+	client := newMetricsHTTPClient(map[string]string{})
+	if client.Timeout != metricsRequestTimeout {
+		t.Errorf("Expected the request timeout %v, got %v", metricsRequestTimeout, client.Timeout)
+	}
+	// The clients are created for every collection, they share the transport of their TLS configuration.
+	if other := newMetricsHTTPClient(map[string]string{"tls.insecureSkipVerify": "false"}); other.Transport != client.Transport {
+		t.Errorf("Expected the clients with the same TLS configuration to share the transport")
+	}
+	insecure := newMetricsHTTPClient(map[string]string{"tls.insecureSkipVerify": "true"})
+	if insecure.Transport == client.Transport {
+		t.Errorf("Expected the clients with another TLS configuration not to share the transport")
+	}
+	transport := insecure.Transport.(*http.Transport)
+	if !transport.TLSClientConfig.InsecureSkipVerify || transport.IdleConnTimeout != metricsIdleConnTimeout || transport.Proxy == nil {
+		t.Errorf("Unexpected transport %+v", transport)
+	}
+}
+
+func TestRegisterMetricsClient(t *testing.T) {
+	if _, err := NewMetricsClient(nil, map[string]string{"type": "fake"}); err == nil {
+		t.Errorf("Expected an error for the unregistered type")
+	}
+
+	RegisterMetricsClient("fake", func(_ *rest.Config, _ map[string]string) (MetricsClient, error) {
+		return &fakeMetricsClient{}, nil
+	})
+	client, err := NewMetricsClient(nil, map[string]string{"type": "fake"})
+	if err != nil {
+		t.Fatalf("Failed to create the registered client: %v", err)
+	}
+	if _, ok := client.(*fakeMetricsClient); !ok {
+		t.Errorf("Expected the fake client, got %T", client)
+	}
+	if !reflect.DeepEqual(MetricsTypes(), []string{"elasticsearch", "fake", "http", "openmetrics", "prometheus", "prometheus_adaptor"}) {
+		t.Errorf("Unexpected metrics types %v", MetricsTypes())
+	}
+}
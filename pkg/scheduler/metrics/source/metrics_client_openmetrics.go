@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// openMetricsNodePlaceholder is replaced by the node name in the address of the OpenMetrics endpoint
+	openMetricsNodePlaceholder = "{node}"
+	// openMetricsResourcePrefix prefixes the keys of the selectors of the named resources
+	openMetricsResourcePrefix = "openmetrics.resources."
+
+	defaultOpenMetricsConcurrency = 16
+)
+
+// OpenMetricsClient scrapes the OpenMetrics or Prometheus text endpoint of every node directly, e.g. a node exporter.
+// The Prometheus text format is requested, the OpenMetrics endpoints serve it by content negotiation.
+// The usage of a resource is the average of the samples matching its selector, so the metrics are expected
+// to be gauges of the utilization in percent. The configuration is:
+//
+//	type: openmetrics
+//	address: http://{node}:9100/metrics
+//	openmetrics.cpu: node_cpu_utilization
+//	openmetrics.memory: node_memory_utilization
+//	openmetrics.resources.gpu: node_gpu_utilization{vendor="nvidia"}
+type OpenMetricsClient struct {
+	address     string
+	cpu         *metricSelector
+	memory      *metricSelector
+	resources   map[string]*metricSelector
+	concurrency int
+	client      *http.Client
+}
+
+func NewOpenMetricsClient(conf map[string]string) (*OpenMetricsClient, error) {
+	address := conf["address"]
+	if len(address) == 0 {
+		return nil, errors.New("metrics address is empty")
+	}
+	if !strings.Contains(address, openMetricsNodePlaceholder) {
+		return nil, fmt.Errorf("metrics address %s does not contain the node placeholder %s", address, openMetricsNodePlaceholder)
+	}
+
+	o := &OpenMetricsClient{
+		address:     address,
+		resources:   map[string]*metricSelector{},
+		concurrency: defaultOpenMetricsConcurrency,
+		client:      newMetricsHTTPClient(conf),
+	}
+	var err error
+	if o.cpu, err = parseOptionalMetricSelector(conf["openmetrics.cpu"]); err != nil {
+		return nil, err
+	}
+	if o.memory, err = parseOptionalMetricSelector(conf["openmetrics.memory"]); err != nil {
+		return nil, err
+	}
+	for key, value := range conf {
+		if !strings.HasPrefix(key, openMetricsResourcePrefix) {
+			continue
+		}
+		if o.resources[strings.TrimPrefix(key, openMetricsResourcePrefix)], err = parseMetricSelector(value); err != nil {
+			return nil, err
+		}
+	}
+	if o.cpu == nil && o.memory == nil && len(o.resources) == 0 {
+		return nil, errors.New("no metric selector is configured")
+	}
+	if concurrency := conf["openmetrics.concurrency"]; len(concurrency) != 0 {
+		if o.concurrency, err = strconv.Atoi(concurrency); err != nil || o.concurrency <= 0 {
+			return nil, fmt.Errorf("invalid openmetrics.concurrency %s", concurrency)
+		}
+	}
+	return o, nil
+}
+
+// NodesMetricsAvg scrapes the nodes in parallel, the nodes which cannot be scraped are removed
+// from nodeMetricsMap so that their previous metrics are kept until they expire.
+func (o *OpenMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	nodeNames := make([]string, 0, len(nodeMetricsMap))
+	for nodeName := range nodeMetricsMap {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	var mutex sync.Mutex
+	workqueue.ParallelizeUntil(ctx, o.concurrency, len(nodeNames), func(i int) {
+		nodeName := nodeNames[i]
+		nodeMetrics, err := o.NodeMetrics(ctx, nodeName)
+		mutex.Lock()
+		defer mutex.Unlock()
+		if err != nil {
+			klog.Errorf("Failed to scrape the metrics of node %s: %v", nodeName, err)
+			delete(nodeMetricsMap, nodeName)
+			return
+		}
+		nodeMetricsMap[nodeName] = nodeMetrics
+	})
+	return ctx.Err()
+}
+
+// NodeMetrics scrapes the metrics of the node.
+func (o *OpenMetricsClient) NodeMetrics(ctx context.Context, nodeName string) (*NodeMetrics, error) {
+	address := strings.ReplaceAll(o.address, openMetricsNodePlaceholder, nodeName)
+	klog.V(4).Infof("Get node metrics from OpenMetrics endpoint: %s", address)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The text parser only understands the Prometheus text format.
+	req.Header.Set("Accept", "text/plain;version=0.0.4")
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeMetrics := &NodeMetrics{MetricsTime: time.Now()}
+	if o.cpu != nil {
+		nodeMetrics.CPU, _ = o.cpu.average(families)
+	}
+	if o.memory != nil {
+		nodeMetrics.Memory, _ = o.memory.average(families)
+	}
+	for name, selector := range o.resources {
+		if value, found := selector.average(families); found {
+			if nodeMetrics.Resources == nil {
+				nodeMetrics.Resources = map[string]float64{}
+			}
+			nodeMetrics.Resources[name] = value
+		}
+	}
+	return nodeMetrics, nil
+}
+
+// metricSelector selects the samples of a metric by name and label values, like `name{label="value"}`.
+type metricSelector struct {
+	name   string
+	labels map[string]string
+}
+
+func parseOptionalMetricSelector(selector string) (*metricSelector, error) {
+	if len(selector) == 0 {
+		return nil, nil
+	}
+	return parseMetricSelector(selector)
+}
+
+func parseMetricSelector(selector string) (*metricSelector, error) {
+	selector = strings.TrimSpace(selector)
+	ms := &metricSelector{name: selector, labels: map[string]string{}}
+	open := strings.Index(selector, "{")
+	if open < 0 {
+		if len(selector) == 0 {
+			return nil, errors.New("empty metric selector")
+		}
+		return ms, nil
+	}
+	if !strings.HasSuffix(selector, "}") || open == 0 {
+		return nil, fmt.Errorf("invalid metric selector %s", selector)
+	}
+	ms.name = strings.TrimSpace(selector[:open])
+	matchers := strings.TrimSpace(selector[open+1 : len(selector)-1])
+	for len(matchers) > 0 {
+		eq := strings.Index(matchers, "=")
+		if eq <= 0 {
+			return nil, fmt.Errorf("invalid metric selector %s", selector)
+		}
+		label := strings.TrimSpace(matchers[:eq])
+		rest := strings.TrimSpace(matchers[eq+1:])
+		prefix, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric selector %s: %v", selector, err)
+		}
+		value, _ := strconv.Unquote(prefix)
+		ms.labels[label] = value
+		matchers = strings.TrimPrefix(strings.TrimSpace(rest[len(prefix):]), ",")
+		matchers = strings.TrimSpace(matchers)
+	}
+	return ms, nil
+}
+
+// average returns the average value of the samples matching the selector, and whether any does.
+func (ms *metricSelector) average(families map[string]*dto.MetricFamily) (float64, bool) {
+	family, found := families[ms.name]
+	if !found {
+		return 0, false
+	}
+	var sum float64
+	var count int
+	for _, metric := range family.GetMetric() {
+		if !ms.matches(metric) {
+			continue
+		}
+		switch {
+		case metric.Gauge != nil:
+			sum += metric.Gauge.GetValue()
+		case metric.Counter != nil:
+			sum += metric.Counter.GetValue()
+		case metric.Untyped != nil:
+			sum += metric.Untyped.GetValue()
+		default:
+			continue
+		}
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+func (ms *metricSelector) matches(metric *dto.Metric) bool {
+	matched := 0
+	for _, pair := range metric.GetLabel() {
+		if value, found := ms.labels[pair.GetName()]; found {
+			if value != pair.GetValue() {
+				return false
+			}
+			matched++
+		}
+	}
+	return matched == len(ms.labels)
+}
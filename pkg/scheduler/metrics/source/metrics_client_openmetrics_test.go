@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const nodeExporterMetrics = `# HELP node_cpu_utilization CPU utilization in percent.
+# TYPE node_cpu_utilization gauge
+node_cpu_utilization{cpu="0"} 40
+node_cpu_utilization{cpu="1"} 60
+# HELP node_memory_utilization Memory utilization in percent.
+# TYPE node_memory_utilization gauge
+node_memory_utilization 70.5
+# TYPE node_gpu_utilization gauge
+node_gpu_utilization{vendor="nvidia",gpu="0"} 90
+node_gpu_utilization{vendor="nvidia",gpu="1"} 70
+node_gpu_utilization{vendor="other",gpu="2"} 0
+# TYPE node_network_receive_utilization gauge
+node_network_receive_utilization{device="eth0"} 12
+# EOF
+`
+
+func TestOpenMetricsClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The OpenMetrics format must not be negotiated, the client parses the Prometheus text format only.
+		if accept := r.Header.Get("Accept"); strings.Contains(accept, "openmetrics") {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		switch r.URL.Path {
+		case "/n1/metrics":
+			w.Write([]byte(nodeExporterMetrics))
+		case "/n2/metrics":
+			w.Write([]byte(strings.Split(nodeExporterMetrics, "# HELP node_memory_utilization")[0]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewMetricsClient(nil, map[string]string{
+		"type":                          Metrics_Type_OpenMetrics,
+		"address":                       server.URL + "/{node}/metrics",
+		"openmetrics.cpu":               "node_cpu_utilization",
+		"openmetrics.memory":            "node_memory_utilization",
+		"openmetrics.resources.gpu":     `node_gpu_utilization{vendor="nvidia"}`,
+		"openmetrics.resources.network": `node_network_receive_utilization{device = "eth0", }`,
+		"openmetrics.resources.disk":    "node_disk_utilization",
+		"openmetrics.concurrency":       "2",
+		"tls.insecureSkipVerify":        "true",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	nodeMetricsMap := map[string]*NodeMetrics{"n1": {}, "n2": {}, "n3": {}}
+	if err := client.NodesMetricsAvg(context.TODO(), nodeMetricsMap); err != nil {
+		t.Fatalf("Failed to get the metrics: %v", err)
+	}
+
+	// n3 cannot be scraped, its previous metrics are kept.
+	if _, found := nodeMetricsMap["n3"]; found || len(nodeMetricsMap) != 2 {
+		t.Errorf("Expected the metrics of n1 and n2 only, got %v", nodeMetricsMap)
+	}
+	n1 := nodeMetricsMap["n1"]
+	if n1.MetricsTime.IsZero() || n1.CPU != 50 || n1.Memory != 70.5 {
+		t.Errorf("Unexpected metrics of n1: %+v", n1)
+	}
+	if expected := map[string]float64{"gpu": 80, "network": 12}; !reflect.DeepEqual(n1.Resources, expected) {
+		t.Errorf("Expected resources %v of n1, got %v", expected, n1.Resources)
+	}
+	n2 := nodeMetricsMap["n2"]
+	if n2.CPU != 50 || n2.Memory != 0 || n2.Resources != nil {
+		t.Errorf("Unexpected metrics of n2: %+v", n2)
+	}
+}
+
+func TestNewOpenMetricsClientErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		conf map[string]string
+	}{
+		{
+			name: "no address",
+			conf: map[string]string{"openmetrics.cpu": "cpu"},
+		},
+		{
+			name: "no node placeholder",
+			conf: map[string]string{"address": "http://localhost:9100/metrics", "openmetrics.cpu": "cpu"},
+		},
+		{
+			name: "no selector",
+			conf: map[string]string{"address": "http://{node}:9100/metrics"},
+		},
+		{
+			name: "invalid selector",
+			conf: map[string]string{"address": "http://{node}:9100/metrics", "openmetrics.cpu": `cpu{mode=idle}`},
+		},
+		{
+			name: "invalid concurrency",
+			conf: map[string]string{"address": "http://{node}:9100/metrics", "openmetrics.cpu": "cpu", "openmetrics.concurrency": "0"},
+		},
+	}
+	for _, test := range tests {
+		if _, err := NewOpenMetricsClient(test.conf); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestParseMetricSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		expected *metricSelector
+	}{
+		{
+			selector: "node_load1",
+			expected: &metricSelector{name: "node_load1", labels: map[string]string{}},
+		},
+		{
+			selector: `gpu{vendor="nvidia", model="a,b"}`,
+			expected: &metricSelector{name: "gpu", labels: map[string]string{"vendor": "nvidia", "model": "a,b"}},
+		},
+	}
+	for _, test := range tests {
+		selector, err := parseMetricSelector(test.selector)
+		if err != nil {
+			t.Errorf("Failed to parse %s: %v", test.selector, err)
+			continue
+		}
+		if !reflect.DeepEqual(selector, test.expected) {
+			t.Errorf("Expected %+v for %s, got %+v", test.expected, test.selector, selector)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+)
+
+const (
+	// TriggerPeriod labels the scheduling cycles started by the schedule period
+	TriggerPeriod = "period"
+	// TriggerPodGroupAdded labels the scheduling cycles started by a new PodGroup
+	TriggerPodGroupAdded = "podgroup_added"
+	// TriggerPodDeleted labels the scheduling cycles started by a deleted pod releasing resources
+	TriggerPodDeleted = "pod_deleted"
+	// TriggerNodeAdded labels the scheduling cycles started by a new node
+	TriggerNodeAdded = "node_added"
+	// TriggerQueueChanged labels the scheduling cycles started by a queue added, updated or deleted
+	TriggerQueueChanged = "queue_changed"
+)
+
+var scheduleTriggers = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: VolcanoNamespace,
+		Name:      "schedule_triggers_total",
+		Help:      "Total number of scheduling cycles started, by trigger reason; a cycle triggered by several events counts for each reason",
+	}, []string{"reason"},
+)
+
+// RegisterScheduleTrigger records a scheduling cycle started for the given reason
+func RegisterScheduleTrigger(reason string) {
+	scheduleTriggers.WithLabelValues(reason).Inc()
+}
@@ -49,6 +49,15 @@ const (
 	resourceFmt = "%s[%d]"
 )
 
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	BinpackWeight:                framework.IntArgument,
+	BinpackCPU:                   framework.IntArgument,
+	BinpackMemory:                framework.IntArgument,
+	BinpackResources:             framework.StringArgument,
+	BinpackResourcesPrefix + "*": framework.IntArgument,
+}
+
 type priorityWeight struct {
 	BinPackingWeight    int
 	BinPackingCPU       int
@@ -189,12 +189,14 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddJobEnqueueableFn(cp.Name(), func(obj interface{}) int {
 		if !readyToSchedule {
 			klog.V(3).Infof("Capacity plugin failed to check queue's hierarchical structure!")
+			ssn.RecordVetoReason(cp.Name(), "the hierarchical structure of the queues is invalid")
 			return util.Reject
 		}
 
 		job := obj.(*api.JobInfo)
 		queueID := job.Queue
 		if hierarchyEnabled && !cp.isLeafQueue(queueID) {
+			ssn.RecordVetoReason(cp.Name(), fmt.Sprintf("queue <%s> is not a leaf queue", queueID))
 			return util.Reject
 		}
 
@@ -225,6 +227,8 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 			return util.Permit
 		}
 		ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeNormal, string(scheduling.PodGroupUnschedulableType), "queue resource quota insufficient")
+		ssn.RecordVetoReason(cp.Name(), fmt.Sprintf("queue <%s> resource quota insufficient: capability <%s>, allocated <%s>, inqueue <%s>, job min resources <%s>",
+			queue.Name, attr.realCapability, attr.allocated, attr.inqueue, minReq))
 		return util.Reject
 	})
 
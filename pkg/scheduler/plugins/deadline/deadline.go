@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadline
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin
+	PluginName = "deadline"
+
+	// UrgentSlackKey is the argument of the slack below which the jobs predicted to meet their deadline
+	// are enqueued whatever the other plugins vote, like the sla waiting time
+	UrgentSlackKey = "deadline.urgentSlack"
+
+	// DeadlineMissPredicted is the PodGroup condition of the jobs predicted to miss their deadline
+	DeadlineMissPredicted scheduling.PodGroupConditionType = "DeadlineMissPredicted"
+
+	defaultUrgentSlack = 5 * time.Minute
+)
+
+/*
+   The deadline and the expected runtime of a job are given by its PodGroup annotations:
+
+   metadata:
+     annotations:
+       volcano.sh/deadline: 2024-06-01T18:00:00Z
+       volcano.sh/expected-runtime: 2h
+
+   actions: "enqueue, allocate, backfill, preempt"
+   tiers:
+   - plugins:
+     - name: priority
+     - name: gang
+     - name: deadline
+       arguments:
+         deadline.urgentSlack: 5m
+*/
+
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	UrgentSlackKey: framework.StringArgument,
+}
+
+type deadlinePlugin struct {
+	// Arguments given for deadline plugin
+	pluginArguments framework.Arguments
+	urgentSlack     time.Duration
+
+	jobs map[api.JobID]*deadlineInfo
+}
+
+// New function returns deadline plugin object
+func New(arguments framework.Arguments) framework.Plugin {
+	dp := &deadlinePlugin{
+		pluginArguments: arguments,
+		urgentSlack:     defaultUrgentSlack,
+	}
+	if value, ok := arguments[UrgentSlackKey].(string); ok {
+		if slack, err := time.ParseDuration(value); err != nil || slack < 0 {
+			klog.Warningf("Could not parse argument: %s for key %s, with err %v", value, UrgentSlackKey, err)
+		} else {
+			dp.urgentSlack = slack
+		}
+	}
+	return dp
+}
+
+func (dp *deadlinePlugin) Name() string {
+	return PluginName
+}
+
+func (dp *deadlinePlugin) OnSessionOpen(ssn *framework.Session) {
+	klog.V(4).Infof("Enter deadline plugin ...")
+	defer klog.V(4).Infof("Leaving deadline plugin.")
+
+	dp.jobs = predict(ssn.Jobs, ssn.Nodes, time.Now())
+	for uid, info := range dp.jobs {
+		if info.miss {
+			job := ssn.Jobs[uid]
+			klog.V(3).Infof("Job <%s/%s> is predicted to miss its deadline %v, expected completion %v",
+				job.Namespace, job.Name, info.deadline, info.completion)
+		}
+	}
+
+	// The jobs predicted to meet their deadline are ordered first, the ones with the least slack first.
+	jobOrderFn := func(l, r interface{}) int {
+		lv := l.(*api.JobInfo)
+		rv := r.(*api.JobInfo)
+		li, ri := dp.jobs[lv.UID], dp.jobs[rv.UID]
+		lFeasible := li != nil && !li.miss
+		rFeasible := ri != nil && !ri.miss
+		if lFeasible != rFeasible {
+			if lFeasible {
+				return -1
+			}
+			return 1
+		}
+		if !lFeasible || li.slack == ri.slack {
+			return 0
+		}
+		if li.slack < ri.slack {
+			return -1
+		}
+		return 1
+	}
+	ssn.AddJobOrderFn(dp.Name(), jobOrderFn)
+
+	// The jobs predicted to miss their deadline do not preempt others for nothing.
+	jobStarvingFn := func(obj interface{}) bool {
+		job := obj.(*api.JobInfo)
+		if info := dp.jobs[job.UID]; info != nil && info.miss {
+			return false
+		}
+		return job.IsStarving()
+	}
+	ssn.AddJobStarvingFns(dp.Name(), jobStarvingFn)
+
+	jobEnqueueableFn := func(obj interface{}) int {
+		job := obj.(*api.JobInfo)
+		info := dp.jobs[job.UID]
+		if info == nil || info.miss || info.slack > dp.urgentSlack {
+			return util.Abstain
+		}
+		return util.Permit
+	}
+	ssn.AddJobEnqueueableFn(dp.Name(), jobEnqueueableFn)
+}
+
+func (dp *deadlinePlugin) OnSessionClose(ssn *framework.Session) {
+	for uid, info := range dp.jobs {
+		job, found := ssn.Jobs[uid]
+		if !found || job.PodGroup == nil {
+			continue
+		}
+		if !ssn.DryRun() {
+			metrics.UpdateJobDeadlineMissPredicted(job.Namespace, job.Name, info.miss)
+		}
+
+		jc := &scheduling.PodGroupCondition{
+			Type:               DeadlineMissPredicted,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			TransitionID:       string(ssn.UID),
+			Reason:             "DeadlineMissPredicted",
+			Message:            fmt.Sprintf("job needs %v of runtime and can not complete before its deadline %v with the current capacity", info.runtime, info.deadline.Format(time.RFC3339)),
+		}
+		if !info.miss {
+			if !hasCondition(job, DeadlineMissPredicted) {
+				continue
+			}
+			jc.Status = v1.ConditionFalse
+			jc.Reason = "DeadlineExpectedToBeMet"
+			jc.Message = fmt.Sprintf("job is expected to complete before its deadline %v", info.deadline.Format(time.RFC3339))
+		}
+		if err := ssn.UpdatePodGroupCondition(job, jc); err != nil {
+			klog.Errorf("Failed to update job <%s/%s> condition: %v", job.Namespace, job.Name, err)
+		}
+	}
+	dp.jobs = nil
+}
+
+func hasCondition(job *api.JobInfo, conditionType scheduling.PodGroupConditionType) bool {
+	for _, c := range job.PodGroup.Status.Conditions {
+		if c.Type == conditionType {
+			return true
+		}
+	}
+	return false
+}
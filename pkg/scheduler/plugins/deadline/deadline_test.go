@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadline
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+func buildPodGroup(name string, phase schedulingv1.PodGroupPhase, deadline time.Time, runtime string) *schedulingv1.PodGroup {
+	pg := util.BuildPodGroup(name, "c1", "c1", 1, nil, phase)
+	pg.Annotations = map[string]string{}
+	if !deadline.IsZero() {
+		pg.Annotations[api.JobDeadline] = deadline.Format(time.RFC3339)
+	}
+	if runtime != "" {
+		pg.Annotations[api.JobExpectedRuntime] = runtime
+	}
+	return pg
+}
+
+// buildTest returns a node of 4 CPUs taken for 30 more minutes by a running job, and jobs of 4 CPUs waiting:
+// pg-a and pg-b may run one after the other before their deadline, pg-c may not.
+func buildTest(now time.Time) uthelper.TestCommonStruct {
+	running := util.BuildPod("c1", "running", "n1", v1.PodRunning, api.BuildResourceList("4", "1Gi"), "pg-running", nil, nil)
+	started := metav1.NewTime(now.Add(-30 * time.Minute))
+	running.Status.StartTime = &started
+
+	return uthelper.TestCommonStruct{
+		Name: "deadline",
+		PodGroups: []*schedulingv1.PodGroup{
+			buildPodGroup("pg-running", schedulingv1.PodGroupRunning, time.Time{}, "1h"),
+			buildPodGroup("pg-a", schedulingv1.PodGroupInqueue, now.Add(2*time.Hour+10*time.Minute), "1h"),
+			buildPodGroup("pg-b", schedulingv1.PodGroupInqueue, now.Add(time.Hour+5*time.Minute), "30m"),
+			buildPodGroup("pg-c", schedulingv1.PodGroupInqueue, now.Add(45*time.Minute), "1h"),
+			buildPodGroup("pg-d", schedulingv1.PodGroupInqueue, time.Time{}, ""),
+		},
+		Pods: []*v1.Pod{
+			running,
+			util.BuildPod("c1", "a", "", v1.PodPending, api.BuildResourceList("4", "1Gi"), "pg-a", nil, nil),
+			util.BuildPod("c1", "b", "", v1.PodPending, api.BuildResourceList("4", "1Gi"), "pg-b", nil, nil),
+			util.BuildPod("c1", "c", "", v1.PodPending, api.BuildResourceList("4", "1Gi"), "pg-c", nil, nil),
+			util.BuildPod("c1", "d", "", v1.PodPending, api.BuildResourceList("4", "1Gi"), "pg-d", nil, nil),
+		},
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+		},
+		Queues: []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+	}
+}
+
+func TestPredict(t *testing.T) {
+	now := time.Now()
+	test := buildTest(now)
+	test.Plugins = map[string]framework.PluginBuilder{gang.PluginName: gang.New}
+	ssn := test.RegisterSession([]conf.Tier{{Plugins: []conf.PluginOption{{Name: gang.PluginName}}}}, nil)
+	defer test.Close()
+
+	infos := predict(ssn.Jobs, ssn.Nodes, now)
+	if len(infos) != 3 {
+		t.Fatalf("expected the prediction of the 3 jobs with a deadline, got %d", len(infos))
+	}
+	expected := map[string]struct {
+		completion time.Duration
+		miss       bool
+	}{
+		// pg-c has the least slack but misses its deadline, pg-b starts when the running job finishes,
+		// then pg-a starts after it.
+		"c1/pg-b": {completion: time.Hour},
+		"c1/pg-a": {completion: 2 * time.Hour},
+		"c1/pg-c": {completion: 90 * time.Minute, miss: true},
+	}
+	for uid, e := range expected {
+		info := infos[api.JobID(uid)]
+		if info == nil {
+			t.Fatalf("expected the prediction of %s", uid)
+		}
+		if info.miss != e.miss {
+			t.Errorf("expected %s miss %v, got %v", uid, e.miss, info.miss)
+		}
+		if got := info.completion.Sub(now); got != e.completion {
+			t.Errorf("expected %s to complete in %v, got %v", uid, e.completion, got)
+		}
+	}
+}
+
+// naiveEarliestStart checks the starts in time order against the idle resources at every time in their runtime.
+func naiveEarliestStart(tl *timeline, need *api.Resource, runtime time.Duration) (time.Time, bool) {
+	free := func(t time.Time) *api.Resource {
+		free := tl.idle.Clone()
+		for _, e := range tl.events {
+			if e.time.After(t) {
+				continue
+			}
+			if e.release {
+				free.Add(e.resource)
+			} else {
+				free.SubWithoutAssert(e.resource)
+			}
+		}
+		return free
+	}
+
+	candidates := []time.Time{tl.now}
+	for _, e := range tl.events {
+		if e.release && e.time.After(tl.now) {
+			candidates = append(candidates, e.time)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Before(candidates[j])
+	})
+	for _, start := range candidates {
+		fits := need.LessEqual(free(start), api.Zero)
+		for _, e := range tl.events {
+			if fits && !e.release && e.time.After(start) && e.time.Before(start.Add(runtime)) {
+				fits = need.LessEqual(free(e.time), api.Zero)
+			}
+		}
+		if fits {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func TestEarliestStart(t *testing.T) {
+	now := time.Now()
+	r := rand.New(rand.NewSource(1))
+	cpu := func(n int) *api.Resource {
+		return api.NewResource(api.BuildResourceList(fmt.Sprint(n), "0"))
+	}
+	for i := 0; i < 200; i++ {
+		tl := &timeline{now: now, idle: cpu(r.Intn(4))}
+		for j := r.Intn(8); j > 0; j-- {
+			tl.events = append(tl.events, capacityEvent{
+				time:     now.Add(time.Duration(r.Intn(10)-2) * time.Minute),
+				resource: cpu(1 + r.Intn(3)),
+				release:  true,
+			})
+		}
+		tl.sortEvents()
+		for j := r.Intn(6); j > 0; j-- {
+			tl.take(cpu(1+r.Intn(3)), now.Add(time.Duration(r.Intn(10))*time.Minute), time.Duration(1+r.Intn(5))*time.Minute)
+		}
+
+		need, runtime := cpu(1+r.Intn(4)), time.Duration(1+r.Intn(6))*time.Minute
+		expectedStart, expectedFound := naiveEarliestStart(tl, need, runtime)
+		start, found := tl.earliestStart(need, runtime)
+		if found != expectedFound || !start.Equal(expectedStart) {
+			t.Fatalf("case %d: expected the start %v (%v), got %v (%v)", i, expectedStart.Sub(now), expectedFound, start.Sub(now), found)
+		}
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledJobOrder:    &trueValue,
+					EnabledJobStarving: &trueValue,
+				},
+			},
+		},
+	}
+	test := buildTest(time.Now())
+	test.Plugins = map[string]framework.PluginBuilder{PluginName: New}
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+
+	jobs := map[string]*api.JobInfo{}
+	for _, job := range ssn.Jobs {
+		jobs[job.Name] = job
+	}
+	order := []string{"pg-b", "pg-a", "pg-c"}
+	for i := 0; i < len(order)-1; i++ {
+		if !ssn.JobOrderFn(jobs[order[i]], jobs[order[i+1]]) {
+			t.Errorf("expected %s to be ordered before %s", order[i], order[i+1])
+		}
+	}
+	if !ssn.JobOrderFn(jobs["pg-a"], jobs["pg-d"]) {
+		t.Errorf("expected pg-a to be ordered before pg-d without deadline")
+	}
+	if ssn.JobStarving(jobs["pg-c"]) {
+		t.Errorf("expected pg-c predicted to miss its deadline not to be starving")
+	}
+	if !ssn.JobStarving(jobs["pg-a"]) {
+		t.Errorf("expected pg-a to be starving")
+	}
+
+	dp := New(nil).(*deadlinePlugin)
+	dp.OnSessionOpen(ssn)
+	dp.OnSessionClose(ssn)
+	for name, expected := range map[string]bool{"pg-a": false, "pg-b": false, "pg-c": true, "pg-d": false} {
+		if got := hasCondition(jobs[name], DeadlineMissPredicted); got != expected {
+			t.Errorf("expected %s to have the %s condition %v, got %v", name, DeadlineMissPredicted, expected, got)
+		}
+	}
+}
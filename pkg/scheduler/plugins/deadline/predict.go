@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deadline
+
+import (
+	"sort"
+	"time"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// deadlineInfo is the deadline of a job and its predicted completion.
+type deadlineInfo struct {
+	deadline time.Time
+	// runtime is the expected runtime of the job, zero if it is not declared.
+	runtime time.Duration
+	// slack is the time the job may still wait before starting to meet its deadline.
+	slack time.Duration
+	// completion is the predicted completion time of the job, zero if it may never start with the capacity.
+	completion time.Time
+	miss       bool
+}
+
+// capacityEvent is resources taken or released at a time.
+type capacityEvent struct {
+	time     time.Time
+	resource *api.Resource
+	release  bool
+}
+
+// timeline is the idle resources of the cluster over time, given the expected end of the running tasks
+// and the predicted start of the waiting jobs.
+type timeline struct {
+	now  time.Time
+	idle *api.Resource
+	// events are sorted by time.
+	events []capacityEvent
+}
+
+// capacityPoint is a time the idle resources change at.
+type capacityPoint struct {
+	time time.Time
+	// free is the idle resources from the time on.
+	free          *api.Resource
+	release, take bool
+}
+
+// sortEvents sorts the events by time.
+func (tl *timeline) sortEvents() {
+	sort.SliceStable(tl.events, func(i, j int) bool {
+		return tl.events[i].time.Before(tl.events[j].time)
+	})
+}
+
+// points returns the times from now on the idle resources change at, the first one is now.
+func (tl *timeline) points() []capacityPoint {
+	free := tl.idle.Clone()
+	points := []capacityPoint{{time: tl.now}}
+	for _, e := range tl.events {
+		last := &points[len(points)-1]
+		if e.time.After(last.time) {
+			last.free = free.Clone()
+			points = append(points, capacityPoint{time: e.time})
+			last = &points[len(points)-1]
+		}
+		if e.release {
+			free.Add(e.resource)
+			last.release = true
+		} else {
+			free.SubWithoutAssert(e.resource)
+			last.take = true
+		}
+	}
+	points[len(points)-1].free = free
+	return points
+}
+
+// earliestStart returns the earliest time the resources are idle for the runtime, and false if they never are.
+// The job may start now or when resources are released. The times are swept once: if the resources are taken
+// during the runtime of a start, the later starts up to that time are skipped as their runtime covers it too.
+func (tl *timeline) earliestStart(need *api.Resource, runtime time.Duration) (time.Time, bool) {
+	points := tl.points()
+	for i := 0; i < len(points); {
+		if (i > 0 && !points[i].release) || !need.LessEqual(points[i].free, api.Zero) {
+			i++
+			continue
+		}
+		start := points[i].time
+		end := start.Add(runtime)
+		// The resources taken later by the jobs predicted before must stay idle until the end of the runtime.
+		j := i + 1
+		for ; j < len(points) && points[j].time.Before(end); j++ {
+			if points[j].take && !need.LessEqual(points[j].free, api.Zero) {
+				break
+			}
+		}
+		if j == len(points) || !points[j].time.Before(end) {
+			return start, true
+		}
+		i = j + 1
+	}
+	return time.Time{}, false
+}
+
+// take records the resources taken from the start for the runtime, keeping the events sorted.
+func (tl *timeline) take(need *api.Resource, start time.Time, runtime time.Duration) {
+	tl.insert(capacityEvent{time: start, resource: need})
+	tl.insert(capacityEvent{time: start.Add(runtime), resource: need, release: true})
+}
+
+func (tl *timeline) insert(e capacityEvent) {
+	i := sort.Search(len(tl.events), func(i int) bool {
+		return tl.events[i].time.After(e.time)
+	})
+	tl.events = append(tl.events, capacityEvent{})
+	copy(tl.events[i+1:], tl.events[i:])
+	tl.events[i] = e
+}
+
+// predict predicts the completion of the jobs with a deadline. The waiting jobs are started in the order of
+// their slack as soon as the idle resources fit them, the running tasks releasing their resources after the
+// expected runtime of their job; the jobs predicted to miss their deadline do not take resources.
+func predict(jobs map[api.JobID]*api.JobInfo, nodes map[string]*api.NodeInfo, now time.Time) map[api.JobID]*deadlineInfo {
+	tl := &timeline{now: now, idle: api.EmptyResource()}
+	for _, node := range nodes {
+		if node.Ready() {
+			tl.idle.Add(node.FutureIdle())
+		}
+	}
+
+	infos := map[api.JobID]*deadlineInfo{}
+	var waiting []*api.JobInfo
+	for _, job := range jobs {
+		runtime, hasRuntime := job.ExpectedRuntime()
+		if hasRuntime {
+			for _, task := range job.Tasks {
+				if api.AllocatedStatus(task.Status) {
+					tl.events = append(tl.events, capacityEvent{time: endTime(task, runtime, now), resource: task.Resreq, release: true})
+				}
+			}
+		}
+
+		deadline, found := job.Deadline()
+		if !found {
+			continue
+		}
+		info := &deadlineInfo{deadline: deadline, runtime: runtime, slack: deadline.Sub(now) - runtime}
+		infos[job.UID] = info
+		if job.IsReady() {
+			// The job started with its earliest task.
+			start := now
+			for _, task := range job.Tasks {
+				if api.AllocatedStatus(task.Status) && task.Pod != nil && task.Pod.Status.StartTime != nil &&
+					task.Pod.Status.StartTime.Time.Before(start) {
+					start = task.Pod.Status.StartTime.Time
+				}
+			}
+			info.completion = start.Add(runtime)
+			info.miss = info.completion.After(deadline)
+			continue
+		}
+		waiting = append(waiting, job)
+	}
+
+	tl.sortEvents()
+
+	sort.Slice(waiting, func(i, j int) bool {
+		l, r := infos[waiting[i].UID], infos[waiting[j].UID]
+		if l.slack != r.slack {
+			return l.slack < r.slack
+		}
+		return waiting[i].UID < waiting[j].UID
+	})
+	for _, job := range waiting {
+		info := infos[job.UID]
+		need := api.EmptyResource()
+		for _, task := range job.TaskStatusIndex[api.Pending] {
+			if !task.SchGated {
+				need.Add(task.Resreq)
+			}
+		}
+		start, found := tl.earliestStart(need, info.runtime)
+		if !found {
+			info.miss = true
+			continue
+		}
+		info.completion = start.Add(info.runtime)
+		if info.miss = info.completion.After(info.deadline); !info.miss {
+			tl.take(need, start, info.runtime)
+		}
+	}
+	return infos
+}
+
+// endTime returns when the task is expected to finish given the runtime, a task running longer than expected
+// is expected to finish now.
+func endTime(task *api.TaskInfo, runtime time.Duration, now time.Time) time.Time {
+	start := now
+	if task.Pod != nil && task.Pod.Status.StartTime != nil {
+		start = task.Pod.Status.StartTime.Time
+	}
+	if end := start.Add(runtime); end.After(now) {
+		return end
+	}
+	return now
+}
@@ -19,9 +19,12 @@ package deviceshare
 import (
 	"context"
 	"math"
-	"reflect"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/klog/v2"
 	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
 
@@ -46,11 +49,24 @@ const (
 	ScheduleWeight         = "deviceshare.ScheduleWeight"
 )
 
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	GPUSharingPredicate:    framework.BoolArgument,
+	NodeLockEnable:         framework.BoolArgument,
+	GPUNumberPredicate:     framework.BoolArgument,
+	VGPUEnable:             framework.BoolArgument,
+	SchedulePolicyArgument: framework.StringArgument,
+	ScheduleWeight:         framework.IntArgument,
+}
+
 type deviceSharePlugin struct {
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
 	schedulePolicy  string
 	scheduleWeight  int
+	// dryRunClient holds copies of the pods and nodes the devices are allocated on in a dry run session,
+	// the devices are assigned by patching the copies.
+	dryRunClient *fake.Clientset
 }
 
 // New return priority plugin
@@ -65,7 +81,7 @@ func (dp *deviceSharePlugin) Name() string {
 }
 
 func enablePredicate(dsp *deviceSharePlugin) {
-	// Checks whether predicate.GPUSharingEnable is provided or not, if given, modifies the value in predicateEnable struct.
+	// Checks whether deviceshare.GPUSharingEnable is provided or not, if given, modifies the value in predicateEnable struct.
 	nodeLockEnable := false
 	args := dsp.pluginArguments
 	args.GetBool(&gpushare.GpuSharingEnable, GPUSharingPredicate)
@@ -100,9 +116,13 @@ func createStatus(code int, reason string) *api.Status {
 
 func getDeviceScore(ctx context.Context, pod *v1.Pod, node *api.NodeInfo, schedulePolicy string) (int64, *k8sframework.Status) {
 	s := float64(0)
-	for _, devices := range node.Others {
-		if devices.(api.Devices).HasDeviceRequest(pod) {
-			ns := devices.(api.Devices).ScoreNode(pod, schedulePolicy)
+	for _, val := range devices.RegisteredDrivers() {
+		dev, ok := node.Others[val].(api.Devices)
+		if !ok || devices.IsNil(dev) {
+			continue
+		}
+		if dev.HasDeviceRequest(pod) {
+			ns := dev.ScoreNode(pod, schedulePolicy)
 			s += ns
 		}
 	}
@@ -110,14 +130,41 @@ func getDeviceScore(ctx context.Context, pod *v1.Pod, node *api.NodeInfo, schedu
 	return int64(math.Floor(s + 0.5)), nil
 }
 
+// kubeClient returns the client the devices of the pod on the node are assigned with. In a dry run session
+// it is a fake client holding copies of the pod and the node, the session must not patch them.
+func (dp *deviceSharePlugin) kubeClient(ssn *framework.Session, pod *v1.Pod, node *api.NodeInfo) kubernetes.Interface {
+	if !ssn.DryRun() {
+		return ssn.KubeClient()
+	}
+	if dp.dryRunClient == nil {
+		dp.dryRunClient = fake.NewSimpleClientset()
+	}
+	podCopy := pod.DeepCopy()
+	if podCopy.Annotations == nil {
+		// The devices are assigned with JSON patches adding annotations.
+		podCopy.Annotations = map[string]string{}
+	}
+	objects := []runtime.Object{podCopy}
+	if node.Node != nil {
+		// The node is locked with an annotation if the node lock is enabled.
+		objects = append(objects, node.Node.DeepCopy())
+	}
+	for _, obj := range objects {
+		if err := dp.dryRunClient.Tracker().Add(obj); err != nil && !apierrors.IsAlreadyExists(err) {
+			klog.Errorf("Failed to add %T to the client of the dry run session: %v", obj, err)
+		}
+	}
+	return dp.dryRunClient
+}
+
 func (dp *deviceSharePlugin) OnSessionOpen(ssn *framework.Session) {
 	// Register event handlers to update task info in PodLister & nodeMap
 	ssn.AddPredicateFn(dp.Name(), func(task *api.TaskInfo, node *api.NodeInfo) error {
 		predicateStatus := make([]*api.Status, 0)
 		// Check PredicateWithCache
-		for _, val := range api.RegisteredDevices {
+		for _, val := range devices.RegisteredDrivers() {
 			if dev, ok := node.Others[val].(api.Devices); ok {
-				if reflect.ValueOf(dev).IsNil() {
+				if devices.IsNil(dev) {
 					// TODO When a pod requests a device of the current type, but the current node does not have such a device, an error is thrown
 					if dev == nil || dev.HasDeviceRequest(task.Pod) {
 						predicateStatus = append(predicateStatus, &api.Status{
@@ -163,9 +210,52 @@ func (dp *deviceSharePlugin) OnSessionOpen(ssn *framework.Session) {
 			// TODO: we should use a seperate plugin for devices, and seperate them from predicates and nodeOrder plugin.
 			nodeScore := float64(score) * float64(dp.scheduleWeight)
 			klog.V(5).Infof("Node: %s, task<%s/%s> Device Score weight %d, score: %f", node.Name, task.Namespace, task.Name, dp.scheduleWeight, nodeScore)
+			return nodeScore, nil
 		}
 		return 0, nil
 	})
+
+	// Register event handlers to allocate and release the devices of the tasks, the devices are assigned by
+	// patching the pod, in a dry run session a copy of it
+	ssn.AddEventHandler(&framework.EventHandler{
+		AllocateFunc: func(event *framework.Event) {
+			pod := event.Task.Pod
+			node, found := ssn.Nodes[event.Task.NodeName]
+			if !found {
+				klog.Errorf("Failed to get node %s info from cache", event.Task.NodeName)
+				return
+			}
+			for _, val := range devices.RegisteredDrivers() {
+				dev, ok := node.Others[val].(api.Devices)
+				if !ok || devices.IsNil(dev) || !dev.HasDeviceRequest(pod) {
+					continue
+				}
+				if err := dev.Allocate(dp.kubeClient(ssn, pod, node), pod); err != nil {
+					klog.Errorf("Device %s allocate failed for pod %s/%s, err:%s", val, pod.Namespace, pod.Name, err.Error())
+					return
+				}
+			}
+		},
+		DeallocateFunc: func(event *framework.Event) {
+			pod := event.Task.Pod
+			node, found := ssn.Nodes[event.Task.NodeName]
+			if !found {
+				klog.Errorf("Failed to get node %s info from cache", event.Task.NodeName)
+				return
+			}
+			for _, val := range devices.RegisteredDrivers() {
+				dev, ok := node.Others[val].(api.Devices)
+				if !ok || devices.IsNil(dev) || !dev.HasDeviceRequest(pod) {
+					continue
+				}
+				// deallocate pod device id
+				if err := dev.Release(dp.kubeClient(ssn, pod, node), pod); err != nil {
+					klog.Errorf("Device %s release failed for pod %s/%s, err:%s", val, pod.Namespace, pod.Name, err.Error())
+					return
+				}
+			}
+		},
+	})
 }
 
 func (dp *deviceSharePlugin) OnSessionClose(ssn *framework.Session) {}
@@ -17,17 +17,34 @@ limitations under the License.
 package deviceshare
 
 import (
+	"context"
+	"os"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/devices/fake"
+	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/gpushare"
 	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/vgpu"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
 func TestArguments(t *testing.T) {
 	framework.RegisterPluginBuilder(PluginName, New)
 	defer framework.CleanupPluginBuilders()
@@ -128,3 +145,203 @@ func TestVgpuScore(t *testing.T) {
 	}
 
 }
+
+func buildFakeDeviceNode(name string, count string) *v1.Node {
+	node := util.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+	node.Annotations = map[string]string{fake.DeviceCountAnnotation: count}
+	return node
+}
+
+func buildFakeDevicePod(name, nodeName string, phase v1.PodPhase, request string) *v1.Pod {
+	pod := util.BuildPod("c1", name, nodeName, phase, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+	pod.Spec.Containers[0].Resources.Limits = v1.ResourceList{}
+	addResource(pod.Spec.Containers[0].Resources.Limits, fake.DeviceResource, request)
+	return pod
+}
+
+func TestFakeDevices(t *testing.T) {
+	fake.Register()
+	defer fake.Unregister()
+
+	pg1 := util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	queue1 := util.BuildQueue("q1", 1, nil)
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		policy          string
+		expectAllocated map[string]int
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the node without free devices is filtered out",
+				Plugins:   map[string]framework.PluginBuilder{PluginName: New},
+				PodGroups: []*schedulingv1beta1.PodGroup{pg1},
+				Queues:    []*schedulingv1beta1.Queue{queue1},
+				Pods: []*v1.Pod{
+					buildFakeDevicePod("p1", "n1", v1.PodRunning, "1"),
+					buildFakeDevicePod("p2", "", v1.PodPending, "1"),
+				},
+				Nodes: []*v1.Node{
+					buildFakeDeviceNode("n1", "1"),
+					buildFakeDeviceNode("n2", "1"),
+					util.BuildNode("n3", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+				},
+				ExpectBindMap:  map[string]string{"c1/p2": "n2"},
+				ExpectBindsNum: 1,
+			},
+			expectAllocated: map[string]int{"n2": 1},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the binpack policy prefers the node with the fewer free devices",
+				Plugins:   map[string]framework.PluginBuilder{PluginName: New},
+				PodGroups: []*schedulingv1beta1.PodGroup{pg1},
+				Queues:    []*schedulingv1beta1.Queue{queue1},
+				Pods: []*v1.Pod{
+					buildFakeDevicePod("p1", "n2", v1.PodRunning, "2"),
+					buildFakeDevicePod("p2", "", v1.PodPending, "1"),
+				},
+				Nodes: []*v1.Node{
+					buildFakeDeviceNode("n1", "4"),
+					buildFakeDeviceNode("n2", "4"),
+				},
+				ExpectBindMap:  map[string]string{"c1/p2": "n2"},
+				ExpectBindsNum: 1,
+			},
+			policy:          fake.BinpackPolicy,
+			expectAllocated: map[string]int{"n2": 1},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the spread policy prefers the node with the more free devices",
+				Plugins:   map[string]framework.PluginBuilder{PluginName: New},
+				PodGroups: []*schedulingv1beta1.PodGroup{pg1},
+				Queues:    []*schedulingv1beta1.Queue{queue1},
+				Pods: []*v1.Pod{
+					buildFakeDevicePod("p1", "n1", v1.PodRunning, "2"),
+					buildFakeDevicePod("p2", "", v1.PodPending, "1"),
+				},
+				Nodes: []*v1.Node{
+					buildFakeDeviceNode("n1", "4"),
+					buildFakeDeviceNode("n2", "4"),
+				},
+				ExpectBindMap:  map[string]string{"c1/p2": "n2"},
+				ExpectBindsNum: 1,
+			},
+			policy:          fake.SpreadPolicy,
+			expectAllocated: map[string]int{"n2": 1},
+		},
+	}
+
+	trueValue := true
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			arguments := framework.Arguments{}
+			if len(test.policy) > 0 {
+				arguments[SchedulePolicyArgument] = test.policy
+				arguments[ScheduleWeight] = 10
+			}
+			tiers := []conf.Tier{
+				{
+					Plugins: []conf.PluginOption{
+						{
+							Name:             PluginName,
+							EnabledPredicate: &trueValue,
+							EnabledNodeOrder: &trueValue,
+							Arguments:        arguments,
+						},
+					},
+				},
+			}
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{allocate.New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+			for name, node := range ssn.Nodes {
+				dev, ok := node.Others[fake.DeviceName].(*fake.Devices)
+				if !ok {
+					t.Fatalf("node %s should keep the fake devices, but got %T", name, node.Others[fake.DeviceName])
+				}
+				allocated := 0
+				if dev != nil {
+					for _, n := range dev.Allocated {
+						allocated += n
+					}
+				}
+				if allocated != test.expectAllocated[name] {
+					t.Errorf("node %s should have %d fake devices allocated, but got %d", name, test.expectAllocated[name], allocated)
+				}
+			}
+		})
+	}
+}
+
+func TestGPUSharingDryRun(t *testing.T) {
+	framework.RegisterPluginBuilder(PluginName, New)
+	defer framework.CleanupPluginBuilders()
+	// The devices are enabled in package variables, the previous tests enable the vgpu.
+	vgpuEnable := vgpu.VGPUEnable
+	vgpu.VGPUEnable = false
+	defer func() {
+		gpushare.GpuSharingEnable = false
+		vgpu.VGPUEnable = vgpuEnable
+	}()
+
+	node := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+	node.Status.Capacity[gpushare.VolcanoGPUResource] = resource.MustParse("1000")
+	node.Status.Capacity[gpushare.VolcanoGPUNumber] = resource.MustParse("1")
+	binder := util.NewFakeBinder(10)
+	sc := cache.NewCustomMockSchedulerCache("volcano", binder, nil, nil, nil, nil, nil)
+	sc.AddOrUpdateNode(node)
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	for _, name := range []string{"p1", "p2"} {
+		pg := util.BuildPodGroup(name, "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+		sc.AddPodGroupV1beta1(pg)
+		pod := util.BuildPod("c1", name, "", v1.PodPending, api.BuildResourceList("1", "1Gi"), name, nil, nil)
+		pod.Spec.Containers[0].Resources.Limits = v1.ResourceList{}
+		addResource(pod.Spec.Containers[0].Resources.Limits, gpushare.VolcanoGPUResource, "600")
+		sc.AddPod(pod)
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	sc.Run(stopCh)
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:             PluginName,
+					EnabledPredicate: &trueValue,
+					Arguments:        framework.Arguments{GPUSharingPredicate: true},
+				},
+			},
+		},
+	}
+	conf.EnabledActionMap = map[string]bool{"allocate": true}
+	ssn := framework.OpenDryRunSession(sc, tiers, nil)
+	defer framework.CloseSession(ssn)
+	allocate.New().Execute(ssn)
+
+	// The first pod holds 600 of the 1000 of the GPU, so the second one does not fit anymore.
+	dev := ssn.Nodes["n1"].Others[gpushare.DeviceName].(*gpushare.GPUDevices)
+	if len(dev.Device[0].PodMap) != 1 {
+		t.Fatalf("the GPU should be allocated to one pod in the dry run session, but got %d", len(dev.Device[0].PodMap))
+	}
+	select {
+	case <-binder.Channel:
+	case <-time.After(time.Second):
+		t.Fatalf("the first pod should be bound")
+	}
+	// The GPU is assigned by patching a copy of the pod, the pods of the cluster are not patched.
+	for _, pod := range dev.Device[0].PodMap {
+		if len(pod.Annotations[gpushare.GPUIndex]) == 0 {
+			t.Errorf("the GPU index should be assigned to the copy of the pod %s", pod.Name)
+		}
+		if _, err := ssn.KubeClient().CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{}); err == nil {
+			t.Errorf("the pod %s should not be created with the client of the cache", pod.Name)
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package extender
 
-import "volcano.sh/volcano/pkg/scheduler/api"
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
 
 type OnSessionOpenRequest struct {
+	Session        types.UID
 	Jobs           map[api.JobID]*api.JobInfo
 	Nodes          map[string]*api.NodeInfo
 	Queues         map[api.QueueID]*api.QueueInfo
@@ -13,7 +18,9 @@ type OnSessionOpenRequest struct {
 
 type OnSessionOpenResponse struct{}
 
-type OnSessionCloseRequest struct{}
+type OnSessionCloseRequest struct {
+	Session types.UID
+}
 type OnSessionCloseResponse struct{}
 
 type PredicateRequest struct {
@@ -71,3 +78,32 @@ type JobReadyRequest struct {
 type JobReadyResponse struct {
 	Status bool `json:"status"`
 }
+
+type JobValidRequest struct {
+	Jobs []*api.JobInfo `json:"jobs"`
+}
+
+type JobValidResponse struct {
+	// Results is the validation of the jobs, the jobs without result are valid.
+	Results map[api.JobID]*api.ValidateResult `json:"results"`
+}
+
+type JobOrderRequest struct {
+	Jobs []*api.JobInfo `json:"jobs"`
+}
+
+type JobOrderResponse struct {
+	// Jobs is the jobs in order, the jobs not given are ordered by the other plugins.
+	Jobs []api.JobID `json:"jobs"`
+}
+
+type BindRequest struct {
+	Task *api.TaskInfo `json:"task"`
+	Node string        `json:"node"`
+}
+
+type BindResponse struct {
+	// Bound is false if the extender let the scheduler bind the task.
+	Bound        bool   `json:"bound"`
+	ErrorMessage string `json:"errorMessage"`
+}
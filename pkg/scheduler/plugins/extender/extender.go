@@ -17,14 +17,12 @@ limitations under the License.
 package extender
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
-	"strings"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
@@ -38,8 +36,18 @@ const (
 
 	// ExtenderURLPrefix is the key for providing extender endpoint address
 	ExtenderURLPrefix = "extender.urlPrefix"
+	// ExtenderGRPCAddress is the key for providing the address of an extender reached over gRPC instead of http
+	ExtenderGRPCAddress = "extender.grpcAddress"
 	// ExtenderHTTPTimeout is the timeout for extender http calls
 	ExtenderHTTPTimeout = "extender.httpTimeout"
+	// ExtenderVerbTimeouts is the timeout of the verbs, overriding extender.httpTimeout, by verb name
+	ExtenderVerbTimeouts = "extender.verbTimeouts"
+	// ExtenderMaxConnections is the number of connections kept to the extender
+	ExtenderMaxConnections = "extender.maxConnections"
+	// ExtenderFailureThreshold is the number of calls failing in a row stopping the calls to the extender, 0 to never stop
+	ExtenderFailureThreshold = "extender.failureThreshold"
+	// ExtenderCircuitOpenDuration is how long the calls to the extender are stopped after too many failures
+	ExtenderCircuitOpenDuration = "extender.circuitOpenDuration"
 	// ExtenderOnSessionOpenVerb is the verb of OnSessionOpen method
 	ExtenderOnSessionOpenVerb = "extender.onSessionOpenVerb"
 	// ExtenderOnSessionCloseVerb is the verb of OnSessionClose method
@@ -58,28 +66,95 @@ const (
 	ExtenderJobEnqueueableVerb = "extender.jobEnqueueableVerb"
 	// ExtenderJobReadyVerb is the verb of JobReady method
 	ExtenderJobReadyVerb = "extender.jobReadyVerb"
+	// ExtenderJobValidVerb is the verb of the JobValid method validating all the jobs of the session at once
+	ExtenderJobValidVerb = "extender.jobValidVerb"
+	// ExtenderJobOrderVerb is the verb of the JobOrder method ordering all the jobs of the session at once
+	ExtenderJobOrderVerb = "extender.jobOrderVerb"
+	// ExtenderBindVerb is the verb of the Bind method binding the tasks in place of the scheduler
+	ExtenderBindVerb = "extender.bindVerb"
+	// ExtenderBindResources is the resources whose tasks are bound by the extender, all the tasks if it is empty
+	ExtenderBindResources = "extender.bindResources"
 	// ExtenderIgnorable indicates whether the extender can ignore unexpected errors
 	ExtenderIgnorable = "extender.ignorable"
+
+	defaultMaxConnections      = 2
+	defaultFailureThreshold    = 5
+	defaultCircuitOpenDuration = 30 * time.Second
 )
 
+// The names of the verbs, which are the keys of their timeout in extender.verbTimeouts.
+const (
+	onSessionOpenVerb  = "onSessionOpen"
+	onSessionCloseVerb = "onSessionClose"
+	predicateVerb      = "predicate"
+	prioritizeVerb     = "prioritize"
+	preemptableVerb    = "preemptable"
+	reclaimableVerb    = "reclaimable"
+	queueOverusedVerb  = "queueOverused"
+	jobEnqueueableVerb = "jobEnqueueable"
+	jobReadyVerb       = "jobReady"
+	jobValidVerb       = "jobValid"
+	jobOrderVerb       = "jobOrder"
+	bindVerb           = "bind"
+)
+
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	ExtenderURLPrefix:           framework.StringArgument,
+	ExtenderGRPCAddress:         framework.StringArgument,
+	ExtenderHTTPTimeout:         framework.StringArgument,
+	ExtenderVerbTimeouts:        framework.MapArgument,
+	ExtenderMaxConnections:      framework.IntArgument,
+	ExtenderFailureThreshold:    framework.IntArgument,
+	ExtenderCircuitOpenDuration: framework.StringArgument,
+	ExtenderOnSessionOpenVerb:   framework.StringArgument,
+	ExtenderOnSessionCloseVerb:  framework.StringArgument,
+	ExtenderPredicateVerb:       framework.StringArgument,
+	ExtenderPrioritizeVerb:      framework.StringArgument,
+	ExtenderPreemptableVerb:     framework.StringArgument,
+	ExtenderReclaimableVerb:     framework.StringArgument,
+	ExtenderQueueOverusedVerb:   framework.StringArgument,
+	ExtenderJobEnqueueableVerb:  framework.StringArgument,
+	ExtenderJobReadyVerb:        framework.StringArgument,
+	ExtenderJobValidVerb:        framework.StringArgument,
+	ExtenderJobOrderVerb:        framework.StringArgument,
+	ExtenderBindVerb:            framework.StringArgument,
+	ExtenderBindResources:       framework.ListArgument,
+	ExtenderIgnorable:           framework.BoolArgument,
+}
+
 type extenderConfig struct {
-	urlPrefix          string
-	httpTimeout        time.Duration
-	onSessionOpenVerb  string
-	onSessionCloseVerb string
-	predicateVerb      string
-	prioritizeVerb     string
-	preemptableVerb    string
-	reclaimableVerb    string
-	queueOverusedVerb  string
-	jobEnqueueableVerb string
-	jobReadyVerb       string
-	ignorable          bool
+	urlPrefix           string
+	grpcAddress         string
+	httpTimeout         time.Duration
+	verbTimeouts        map[string]time.Duration
+	maxConnections      int
+	failureThreshold    int
+	circuitOpenDuration time.Duration
+	onSessionOpenVerb   string
+	onSessionCloseVerb  string
+	predicateVerb       string
+	prioritizeVerb      string
+	preemptableVerb     string
+	reclaimableVerb     string
+	queueOverusedVerb   string
+	jobEnqueueableVerb  string
+	jobReadyVerb        string
+	jobValidVerb        string
+	jobOrderVerb        string
+	bindVerb            string
+	bindResources       []v1.ResourceName
+	ignorable           bool
 }
 
 type extenderPlugin struct {
-	client http.Client
-	config *extenderConfig
+	endpoint *endpoint
+	config   *extenderConfig
+
+	// validResults is the validation of the jobs of the session by the extender.
+	validResults map[api.JobID]*api.ValidateResult
+	// jobRanks is the rank of the jobs of the session in the order of the extender.
+	jobRanks map[api.JobID]int
 }
 
 func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
@@ -97,6 +172,11 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 		       arguments:
 				   extender.urlPrefix: http://127.0.0.1
 				   extender.httpTimeout: 100ms
+				   extender.verbTimeouts:
+				     prioritize: 300ms
+				   extender.maxConnections: 2
+				   extender.failureThreshold: 5
+				   extender.circuitOpenDuration: 30s
 				   extender.onSessionOpenVerb: onSessionOpen
 				   extender.onSessionCloseVerb: onSessionClose
 				   extender.predicateVerb: predicate
@@ -105,12 +185,22 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 				   extender.reclaimableVerb: reclaimable
 				   extender.queueOverusedVerb: queueOverused
 				   extender.jobEnqueueableVerb: jobEnqueueable
+				   extender.jobValidVerb: jobValid
+				   extender.jobOrderVerb: jobOrder
+				   extender.bindVerb: bind
+				   extender.bindResources: [example.com/fpga]
 				   extender.ignorable: true
 		     - name: proportion
 		     - name: nodeorder
+
+		   The extender is reached over gRPC with extender.grpcAddress, e.g. 127.0.0.1:9443, in place of
+		   extender.urlPrefix; the verbs then only enable the methods of the Extender service, the prioritize
+		   verb enabling BatchNodeOrder, and the preemptable, reclaimable, queueOverused, jobEnqueueable and
+		   jobReady verbs are not supported.
 	*/
 	ec := &extenderConfig{}
 	ec.urlPrefix, _ = arguments[ExtenderURLPrefix].(string)
+	ec.grpcAddress, _ = arguments[ExtenderGRPCAddress].(string)
 	ec.onSessionOpenVerb, _ = arguments[ExtenderOnSessionOpenVerb].(string)
 	ec.onSessionCloseVerb, _ = arguments[ExtenderOnSessionCloseVerb].(string)
 	ec.predicateVerb, _ = arguments[ExtenderPredicateVerb].(string)
@@ -120,23 +210,90 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 	ec.queueOverusedVerb, _ = arguments[ExtenderQueueOverusedVerb].(string)
 	ec.jobEnqueueableVerb, _ = arguments[ExtenderJobEnqueueableVerb].(string)
 	ec.jobReadyVerb, _ = arguments[ExtenderJobReadyVerb].(string)
+	ec.jobValidVerb, _ = arguments[ExtenderJobValidVerb].(string)
+	ec.jobOrderVerb, _ = arguments[ExtenderJobOrderVerb].(string)
+	ec.bindVerb, _ = arguments[ExtenderBindVerb].(string)
+	if resources, ok := arguments[ExtenderBindResources].([]interface{}); ok {
+		for _, resource := range resources {
+			if name, ok := resource.(string); ok {
+				ec.bindResources = append(ec.bindResources, v1.ResourceName(name))
+			}
+		}
+	}
 
 	arguments.GetBool(&ec.ignorable, ExtenderIgnorable)
 
+	ec.maxConnections = defaultMaxConnections
+	arguments.GetInt(&ec.maxConnections, ExtenderMaxConnections)
+	ec.failureThreshold = defaultFailureThreshold
+	arguments.GetInt(&ec.failureThreshold, ExtenderFailureThreshold)
+	ec.circuitOpenDuration = defaultCircuitOpenDuration
+	if openDuration, _ := arguments[ExtenderCircuitOpenDuration].(string); openDuration != "" {
+		if duration, err := time.ParseDuration(openDuration); err == nil {
+			ec.circuitOpenDuration = duration
+		}
+	}
+
 	ec.httpTimeout = time.Second
 	if httpTimeout, _ := arguments[ExtenderHTTPTimeout].(string); httpTimeout != "" {
 		if timeoutDuration, err := time.ParseDuration(httpTimeout); err == nil {
 			ec.httpTimeout = timeoutDuration
 		}
 	}
+	ec.verbTimeouts = map[string]time.Duration{}
+	for verb, timeout := range stringMap(arguments[ExtenderVerbTimeouts]) {
+		if timeoutDuration, err := time.ParseDuration(timeout); err == nil {
+			ec.verbTimeouts[verb] = timeoutDuration
+		} else {
+			klog.Warningf("Could not parse the timeout %s of verb %s: %v", timeout, verb, err)
+		}
+	}
 
 	return ec
 }
 
+// stringMap returns the map argument with the values in string, the yaml maps having interface keys.
+func stringMap(argument interface{}) map[string]string {
+	values := map[string]string{}
+	switch m := argument.(type) {
+	case map[string]interface{}:
+		for k, v := range m {
+			values[k] = fmt.Sprint(v)
+		}
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			values[fmt.Sprint(k)] = fmt.Sprint(v)
+		}
+	}
+	return values
+}
+
+// timeout returns the timeout of the verb
+func (ec *extenderConfig) timeout(verb string) time.Duration {
+	if timeout, found := ec.verbTimeouts[verb]; found {
+		return timeout
+	}
+	return ec.httpTimeout
+}
+
 func New(arguments framework.Arguments) framework.Plugin {
 	cfg := parseExtenderConfig(arguments)
-	klog.V(4).Infof("Initialize extender plugin with endpoint address %s", cfg.urlPrefix)
-	return &extenderPlugin{client: http.Client{Timeout: cfg.httpTimeout}, config: cfg}
+	address := cfg.urlPrefix
+	if cfg.grpcAddress != "" {
+		address = cfg.grpcAddress
+	}
+	klog.V(4).Infof("Initialize extender plugin with endpoint address %s", address)
+	if cfg.grpcAddress != "" && (cfg.preemptableVerb != "" || cfg.reclaimableVerb != "" || cfg.queueOverusedVerb != "" ||
+		cfg.jobEnqueueableVerb != "" || cfg.jobReadyVerb != "") {
+		klog.Warningf("The preemptable, reclaimable, queueOverused, jobEnqueueable and jobReady verbs are not supported by the gRPC extender %s", address)
+	}
+	ep := &extenderPlugin{config: cfg}
+	endpoint, err := getEndpoint(cfg)
+	if err != nil {
+		klog.Errorf("Failed to initialize extender plugin with endpoint address %s: %v", address, err)
+	}
+	ep.endpoint = endpoint
+	return ep
 }
 
 func (ep *extenderPlugin) Name() string {
@@ -145,7 +302,8 @@ func (ep *extenderPlugin) Name() string {
 
 func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 	if ep.config.onSessionOpenVerb != "" {
-		err := ep.send(ep.config.onSessionOpenVerb, &OnSessionOpenRequest{
+		err := ep.send(onSessionOpenVerb, ep.config.onSessionOpenVerb, &OnSessionOpenRequest{
+			Session:        ssn.UID,
 			Jobs:           ssn.Jobs,
 			Nodes:          ssn.Nodes,
 			Queues:         ssn.Queues,
@@ -160,10 +318,15 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		}
 	}
 
+	// The results of the extender may depend on more than the task and the node.
+	ssn.AddEquivalentFn(ep.Name(), func(*api.TaskInfo) bool {
+		return false
+	})
+
 	if ep.config.predicateVerb != "" {
 		ssn.AddPredicateFn(ep.Name(), func(task *api.TaskInfo, node *api.NodeInfo) error {
 			resp := &PredicateResponse{}
-			err := ep.send(ep.config.predicateVerb, &PredicateRequest{Task: task, Node: node}, resp)
+			err := ep.send(predicateVerb, ep.config.predicateVerb, &PredicateRequest{Task: task, Node: node}, resp)
 			if err != nil {
 				klog.Warningf("Predicate failed with error %v", err)
 
@@ -188,7 +351,7 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 	if ep.config.prioritizeVerb != "" {
 		ssn.AddBatchNodeOrderFn(ep.Name(), func(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]float64, error) {
 			resp := &PrioritizeResponse{}
-			err := ep.send(ep.config.prioritizeVerb, &PrioritizeRequest{Task: task, Nodes: nodes}, resp)
+			err := ep.send(prioritizeVerb, ep.config.prioritizeVerb, &PrioritizeRequest{Task: task, Nodes: nodes}, resp)
 			if err != nil {
 				klog.Warningf("Prioritize failed with error %v", err)
 
@@ -208,7 +371,7 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 	if ep.config.preemptableVerb != "" {
 		ssn.AddPreemptableFn(ep.Name(), func(evictor *api.TaskInfo, evictees []*api.TaskInfo) ([]*api.TaskInfo, int) {
 			resp := &PreemptableResponse{}
-			err := ep.send(ep.config.preemptableVerb, &PreemptableRequest{Evictor: evictor, Evictees: evictees}, resp)
+			err := ep.send(preemptableVerb, ep.config.preemptableVerb, &PreemptableRequest{Evictor: evictor, Evictees: evictees}, resp)
 			if err != nil {
 				klog.Warningf("Preemptable failed with error %v", err)
 
@@ -225,7 +388,7 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 	if ep.config.reclaimableVerb != "" {
 		ssn.AddReclaimableFn(ep.Name(), func(evictor *api.TaskInfo, evictees []*api.TaskInfo) ([]*api.TaskInfo, int) {
 			resp := &ReclaimableResponse{}
-			err := ep.send(ep.config.reclaimableVerb, &ReclaimableRequest{Evictor: evictor, Evictees: evictees}, resp)
+			err := ep.send(reclaimableVerb, ep.config.reclaimableVerb, &ReclaimableRequest{Evictor: evictor, Evictees: evictees}, resp)
 			if err != nil {
 				klog.Warningf("Reclaimable failed with error %v", err)
 
@@ -243,16 +406,20 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		ssn.AddJobEnqueueableFn(ep.Name(), func(obj interface{}) int {
 			job := obj.(*api.JobInfo)
 			resp := &JobEnqueueableResponse{}
-			err := ep.send(ep.config.jobEnqueueableVerb, &JobEnqueueableRequest{Job: job}, resp)
+			err := ep.send(jobEnqueueableVerb, ep.config.jobEnqueueableVerb, &JobEnqueueableRequest{Job: job}, resp)
 			if err != nil {
 				klog.Warningf("JobEnqueueable failed with error %v", err)
 
 				if ep.config.ignorable {
 					return util.Permit
 				}
+				ssn.RecordVetoReason(ep.Name(), fmt.Sprintf("the extender failed: %v", err))
 				return util.Reject
 			}
 
+			if resp.Status < 0 {
+				ssn.RecordVetoReason(ep.Name(), "the extender rejected the job")
+			}
 			return resp.Status
 		})
 	}
@@ -261,7 +428,7 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		ssn.AddOverusedFn(ep.Name(), func(obj interface{}) bool {
 			queue := obj.(*api.QueueInfo)
 			resp := &QueueOverusedResponse{}
-			err := ep.send(ep.config.queueOverusedVerb, &QueueOverusedRequest{Queue: queue}, resp)
+			err := ep.send(queueOverusedVerb, ep.config.queueOverusedVerb, &QueueOverusedRequest{Queue: queue}, resp)
 			if err != nil {
 				klog.Warningf("QueueOverused failed with error %v", err)
 
@@ -276,7 +443,7 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		ssn.AddJobReadyFn(ep.Name(), func(obj interface{}) bool {
 			job := obj.(*api.JobInfo)
 			resp := &JobReadyResponse{}
-			err := ep.send(ep.config.jobReadyVerb, &JobReadyRequest{Job: job}, resp)
+			err := ep.send(jobReadyVerb, ep.config.jobReadyVerb, &JobReadyRequest{Job: job}, resp)
 			if err != nil {
 				klog.Warningf("JobReady failed with error %v", err)
 
@@ -286,43 +453,120 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 			return resp.Status
 		})
 	}
-}
 
-func (ep *extenderPlugin) OnSessionClose(ssn *framework.Session) {
-	if ep.config.onSessionCloseVerb != "" {
-		if err := ep.send(ep.config.onSessionCloseVerb, &OnSessionCloseRequest{}, nil); err != nil {
-			klog.Warningf("OnSessionClose failed with error %v", err)
+	if ep.config.jobValidVerb != "" {
+		// All the jobs are validated at once, the jobs without result are valid.
+		jobs := make([]*api.JobInfo, 0, len(ssn.Jobs))
+		for _, job := range ssn.Jobs {
+			jobs = append(jobs, job)
+		}
+		resp := &JobValidResponse{}
+		err := ep.send(jobValidVerb, ep.config.jobValidVerb, &JobValidRequest{Jobs: jobs}, resp)
+		if err != nil {
+			klog.Warningf("JobValid failed with error %v", err)
 		}
+		ep.validResults = resp.Results
+		ssn.AddJobValidFn(ep.Name(), func(obj interface{}) *api.ValidateResult {
+			job := obj.(*api.JobInfo)
+			if err != nil {
+				if ep.config.ignorable {
+					return nil
+				}
+				return &api.ValidateResult{Pass: false, Reason: "ExtenderError", Message: err.Error()}
+			}
+			return ep.validResults[job.UID]
+		})
 	}
-}
 
-func (ep *extenderPlugin) send(action string, args interface{}, result interface{}) error {
-	out, err := json.Marshal(args)
-	if err != nil {
-		return err
+	if ep.config.jobOrderVerb != "" {
+		// All the jobs are ordered at once, the jobs not ordered by the extender are left to the other plugins.
+		jobs := make([]*api.JobInfo, 0, len(ssn.Jobs))
+		for _, job := range ssn.Jobs {
+			jobs = append(jobs, job)
+		}
+		resp := &JobOrderResponse{}
+		if err := ep.send(jobOrderVerb, ep.config.jobOrderVerb, &JobOrderRequest{Jobs: jobs}, resp); err != nil {
+			klog.Warningf("JobOrder failed with error %v", err)
+		}
+		ep.jobRanks = make(map[api.JobID]int, len(resp.Jobs))
+		for rank, uid := range resp.Jobs {
+			ep.jobRanks[uid] = rank
+		}
+		ssn.AddJobOrderFn(ep.Name(), func(l, r interface{}) int {
+			lRank, lFound := ep.jobRanks[l.(*api.JobInfo).UID]
+			rRank, rFound := ep.jobRanks[r.(*api.JobInfo).UID]
+			if !lFound || !rFound || lRank == rRank {
+				return 0
+			}
+			if lRank < rRank {
+				return -1
+			}
+			return 1
+		})
 	}
 
-	url := strings.TrimRight(ep.config.urlPrefix, "/") + "/" + action
+	if ep.config.bindVerb != "" {
+		ssn.AddBindFn(ep.Name(), ep.bind)
+	}
+}
 
-	req, err := http.NewRequest("POST", url, bytes.NewReader(out))
-	if err != nil {
-		return err
+// bind binds the task with the extender if it requests one of the bind resources, the scheduler binding
+// the task if the extender does not.
+func (ep *extenderPlugin) bind(task *api.TaskInfo) (bool, error) {
+	if !ep.bindsTask(task) {
+		return false, nil
 	}
+	resp := &BindResponse{}
+	if err := ep.send(bindVerb, ep.config.bindVerb, &BindRequest{Task: task, Node: task.NodeName}, resp); err != nil {
+		klog.Warningf("Bind failed with error %v", err)
 
-	req.Header.Set("Content-Type", "application/json")
+		if ep.config.ignorable {
+			return false, nil
+		}
+		return false, err
+	}
+	if resp.ErrorMessage != "" {
+		return false, errors.New(resp.ErrorMessage)
+	}
+	return resp.Bound, nil
+}
 
-	resp, err := ep.client.Do(req)
-	if err != nil {
-		return err
+func (ep *extenderPlugin) bindsTask(task *api.TaskInfo) bool {
+	if len(ep.config.bindResources) == 0 {
+		return true
+	}
+	for _, name := range ep.config.bindResources {
+		if task.Resreq.Get(name) > 0 {
+			return true
+		}
 	}
-	defer resp.Body.Close()
+	return false
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed %v with extender at URL %v, code %v", action, url, resp.StatusCode)
+func (ep *extenderPlugin) OnSessionClose(ssn *framework.Session) {
+	if ep.config.onSessionCloseVerb != "" {
+		if err := ep.send(onSessionCloseVerb, ep.config.onSessionCloseVerb, &OnSessionCloseRequest{Session: ssn.UID}, nil); err != nil {
+			klog.Warningf("OnSessionClose failed with error %v", err)
+		}
+	}
+}
+
+// send sends the request of the verb to the extender within the timeout of the verb, unless the circuit is open.
+func (ep *extenderPlugin) send(verb, path string, args interface{}, result interface{}) error {
+	if ep.endpoint == nil {
+		return fmt.Errorf("extender endpoint is not initialized")
+	}
+	if !ep.endpoint.breaker.allow() {
+		return errCircuitOpen
 	}
 
-	if result != nil {
-		return json.NewDecoder(resp.Body).Decode(result)
+	ctx, cancel := context.WithTimeout(context.Background(), ep.config.timeout(verb))
+	defer cancel()
+	err := ep.endpoint.transport.send(ctx, verb, path, args, result)
+	if errors.Is(err, errUnsupportedVerb) {
+		ep.endpoint.breaker.abort()
+	} else {
+		ep.endpoint.breaker.record(err)
 	}
-	return nil
+	return err
 }
@@ -0,0 +1,312 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/extender/extenderpb"
+	"volcano.sh/volcano/pkg/scheduler/plugins/extender/fake"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+func startServer(t *testing.T, server *fake.Server) string {
+	address, err := server.Start()
+	if err != nil {
+		t.Fatalf("failed to start the fake extender: %v", err)
+	}
+	t.Cleanup(server.Stop)
+	return address
+}
+
+func buildTest(arguments framework.Arguments) (uthelper.TestCommonStruct, []conf.Tier) {
+	test := uthelper.TestCommonStruct{
+		Plugins: map[string]framework.PluginBuilder{PluginName: New},
+		PodGroups: []*schedulingv1beta1.PodGroup{
+			util.BuildPodGroup("pg1", "c1", "c1", 0, nil, schedulingv1beta1.PodGroupInqueue),
+			util.BuildPodGroup("pg2", "c1", "c1", 0, nil, schedulingv1beta1.PodGroupInqueue),
+			util.BuildPodGroup("pg3", "c1", "c1", 0, nil, schedulingv1beta1.PodGroupInqueue),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+		},
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			util.BuildNode("n2", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			util.BuildNode("n3", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+		},
+		Queues: []*schedulingv1beta1.Queue{util.BuildQueue("c1", 1, nil)},
+	}
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:             PluginName,
+					EnabledPredicate: &trueValue,
+					EnabledNodeOrder: &trueValue,
+					EnabledJobOrder:  &trueValue,
+					Arguments:        arguments,
+				},
+			},
+		},
+	}
+	return test, tiers
+}
+
+func TestGRPCExtenderAllocate(t *testing.T) {
+	server := &fake.Server{
+		PredicateFn: func(in *extenderpb.PredicateRequest) (*extenderpb.PredicateResponse, error) {
+			if in.Node.Name == "n1" {
+				return &extenderpb.PredicateResponse{ErrorMessage: "n1 is reserved"}, nil
+			}
+			return &extenderpb.PredicateResponse{}, nil
+		},
+		BatchNodeOrderFn: func(in *extenderpb.BatchNodeOrderRequest) (*extenderpb.BatchNodeOrderResponse, error) {
+			var pod v1.Pod
+			if err := json.Unmarshal(in.Task.Pod, &pod); err != nil || pod.Name != "p1" {
+				return nil, errors.New("unexpected pod")
+			}
+			return &extenderpb.BatchNodeOrderResponse{NodeScores: map[string]float64{"n1": 100, "n2": 10, "n3": 50}}, nil
+		},
+	}
+	address := startServer(t, server)
+
+	test, tiers := buildTest(framework.Arguments{
+		ExtenderGRPCAddress:    address,
+		ExtenderPredicateVerb:  "predicate",
+		ExtenderPrioritizeVerb: "prioritize",
+	})
+	test.ExpectBindsNum = 1
+	test.ExpectBindMap = map[string]string{"c1/p1": "n3"}
+	test.RegisterSession(tiers, nil)
+	defer test.Close()
+	test.Run([]framework.Action{allocate.New()})
+	if err := test.CheckAll(0); err != nil {
+		t.Fatal(err)
+	}
+	if calls := server.Calls("BatchNodeOrder"); calls != 1 {
+		t.Errorf("expected the nodes to be scored in 1 call, got %d", calls)
+	}
+}
+
+func TestGRPCExtenderJobValidAndOrder(t *testing.T) {
+	server := &fake.Server{
+		JobValidFn: func(in *extenderpb.JobValidRequest) (*extenderpb.JobValidResponse, error) {
+			return &extenderpb.JobValidResponse{Results: map[string]*extenderpb.JobValidResult{
+				"c1/pg2": {Pass: false, Reason: "Quota", Message: "no quota left"},
+			}}, nil
+		},
+		JobOrderFn: func(in *extenderpb.JobOrderRequest) (*extenderpb.JobOrderResponse, error) {
+			return &extenderpb.JobOrderResponse{Jobs: []string{"c1/pg3", "c1/pg1"}}, nil
+		},
+	}
+	address := startServer(t, server)
+
+	test, tiers := buildTest(framework.Arguments{
+		ExtenderGRPCAddress:  address,
+		ExtenderJobValidVerb: "jobValid",
+		ExtenderJobOrderVerb: "jobOrder",
+	})
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+
+	if vr := ssn.JobValid(ssn.Jobs["c1/pg2"]); vr == nil || vr.Pass || vr.Reason != "Quota" {
+		t.Errorf("expected pg2 to be invalid, got %v", vr)
+	}
+	if vr := ssn.JobValid(ssn.Jobs["c1/pg1"]); vr != nil {
+		t.Errorf("expected pg1 to be valid, got %v", vr)
+	}
+	if !ssn.JobOrderFn(ssn.Jobs["c1/pg3"], ssn.Jobs["c1/pg1"]) || ssn.JobOrderFn(ssn.Jobs["c1/pg1"], ssn.Jobs["c1/pg3"]) {
+		t.Errorf("expected pg3 to be ordered before pg1")
+	}
+	if server.Calls("JobValid") != 1 || server.Calls("JobOrder") != 1 {
+		t.Errorf("expected the jobs to be validated and ordered in 1 call each, got %d and %d",
+			server.Calls("JobValid"), server.Calls("JobOrder"))
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	server := &fake.Server{Delay: 200 * time.Millisecond}
+	address := startServer(t, server)
+
+	arguments := framework.Arguments{
+		ExtenderGRPCAddress:         address,
+		ExtenderPredicateVerb:       "predicate",
+		ExtenderVerbTimeouts:        map[string]interface{}{predicateVerb: "20ms"},
+		ExtenderFailureThreshold:    2,
+		ExtenderCircuitOpenDuration: "1h",
+	}
+	ep := New(arguments).(*extenderPlugin)
+	task := api.NewTaskInfo(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	node := api.NewNodeInfo(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), nil))
+
+	for i := 0; i < 3; i++ {
+		err := ep.send(predicateVerb, ep.config.predicateVerb, &PredicateRequest{Task: task, Node: node}, &PredicateResponse{})
+		if err == nil {
+			t.Fatalf("expected call %d to fail", i)
+		}
+		if open := errors.Is(err, errCircuitOpen); open != (i == 2) {
+			t.Errorf("expected the circuit to be open at call %d: %v, got error %v", i, i == 2, err)
+		}
+	}
+	if calls := server.Calls("Predicate"); calls != 2 {
+		t.Errorf("expected the extender to be called 2 times before the circuit opens, got %d", calls)
+	}
+
+	// The predicates of the sessions sharing the endpoint fail unless the extender is ignorable.
+	for _, ignorable := range []bool{false, true} {
+		arguments[ExtenderIgnorable] = ignorable
+		test, tiers := buildTest(arguments)
+		ssn := test.RegisterSession(tiers, nil)
+		if err := ssn.PredicateFn(task, node); (err != nil) == ignorable {
+			t.Errorf("expected the predicate of an ignorable %v extender to fail %v, got %v", ignorable, !ignorable, err)
+		}
+		test.Close()
+	}
+}
+
+func TestCircuitBreakerProbe(t *testing.T) {
+	cb := &circuitBreaker{}
+	cb.configure(2, 20*time.Millisecond)
+	failure := errors.New("failure")
+
+	cb.record(failure)
+	if !cb.allow() {
+		t.Fatalf("expected the circuit to be closed after 1 failure")
+	}
+	cb.record(failure)
+	if cb.allow() {
+		t.Fatalf("expected the circuit to be open after 2 failures")
+	}
+
+	// A single probe is let through once the circuit was open for long enough.
+	time.Sleep(30 * time.Millisecond)
+	if !cb.allow() {
+		t.Fatalf("expected a probe to be let through")
+	}
+	if cb.allow() {
+		t.Fatalf("expected a single probe to be let through")
+	}
+	cb.record(failure)
+	if cb.allow() {
+		t.Fatalf("expected the circuit to open again after a failed probe")
+	}
+
+	// A probe of an unsupported verb lets another one through.
+	time.Sleep(30 * time.Millisecond)
+	if !cb.allow() {
+		t.Fatalf("expected a probe to be let through")
+	}
+	cb.abort()
+	if !cb.allow() {
+		t.Fatalf("expected another probe to be let through after an aborted one")
+	}
+	cb.record(nil)
+	for i := 0; i < 2; i++ {
+		if !cb.allow() {
+			t.Fatalf("expected the circuit to be closed after a successful probe")
+		}
+	}
+	cb.record(failure)
+	if !cb.allow() {
+		t.Fatalf("expected the failures to be counted from the successful probe on")
+	}
+}
+
+func TestBind(t *testing.T) {
+	server := &fake.Server{
+		BindFn: func(in *extenderpb.BindRequest) (*extenderpb.BindResponse, error) {
+			if in.Node != "n1" {
+				return &extenderpb.BindResponse{ErrorMessage: "unexpected node " + in.Node}, nil
+			}
+			return &extenderpb.BindResponse{Bound: true}, nil
+		},
+	}
+	address := startServer(t, server)
+
+	arguments := framework.Arguments{
+		ExtenderGRPCAddress:   address,
+		ExtenderBindVerb:      "bind",
+		ExtenderBindResources: []interface{}{"nvidia.com/gpu"},
+	}
+	test, tiers := buildTest(arguments)
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+	if len(ssn.BindFns()) != 1 {
+		t.Fatalf("expected the extender to bind the tasks")
+	}
+	bind := ssn.BindFns()[0]
+
+	cpuTask := api.NewTaskInfo(util.BuildPod("c1", "cpu", "n1", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	gpuTask := api.NewTaskInfo(util.BuildPod("c1", "gpu", "n1", v1.PodPending,
+		api.BuildResourceList("1", "1Gi", api.ScalarResource{Name: "nvidia.com/gpu", Value: "1"}), "pg1", nil, nil))
+	if bound, err := bind(cpuTask); bound || err != nil {
+		t.Errorf("expected the task without gpu to be left to the scheduler, got %v, %v", bound, err)
+	}
+	if bound, err := bind(gpuTask); !bound || err != nil {
+		t.Errorf("expected the task with gpu to be bound by the extender, got %v, %v", bound, err)
+	}
+	gpuTask.NodeName = "n2"
+	if _, err := bind(gpuTask); err == nil {
+		t.Errorf("expected the bind of the extender to fail")
+	}
+	if calls := server.Calls("Bind"); calls != 2 {
+		t.Errorf("expected the extender to bind 2 times, got %d", calls)
+	}
+}
+
+func TestHTTPExtenderJobOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jobOrder" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(&JobOrderResponse{Jobs: []api.JobID{"c1/pg2", "c1/pg1"}})
+	}))
+	defer server.Close()
+
+	test, tiers := buildTest(framework.Arguments{
+		ExtenderURLPrefix:    server.URL,
+		ExtenderJobOrderVerb: "jobOrder",
+	})
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+	if !ssn.JobOrderFn(ssn.Jobs["c1/pg2"], ssn.Jobs["c1/pg1"]) {
+		t.Errorf("expected pg2 to be ordered before pg1")
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"volcano.sh/volcano/pkg/scheduler/plugins/extender/extenderpb"
+)
+
+// Server is an in-process Extender gRPC server for tests, its methods answer with the functions set,
+// and with an empty response otherwise.
+type Server struct {
+	extenderpb.UnimplementedExtenderServer
+
+	PredicateFn      func(*extenderpb.PredicateRequest) (*extenderpb.PredicateResponse, error)
+	BatchNodeOrderFn func(*extenderpb.BatchNodeOrderRequest) (*extenderpb.BatchNodeOrderResponse, error)
+	JobValidFn       func(*extenderpb.JobValidRequest) (*extenderpb.JobValidResponse, error)
+	JobOrderFn       func(*extenderpb.JobOrderRequest) (*extenderpb.JobOrderResponse, error)
+	BindFn           func(*extenderpb.BindRequest) (*extenderpb.BindResponse, error)
+	// Delay delays the responses, e.g. to exceed the timeouts.
+	Delay time.Duration
+
+	mutex sync.Mutex
+	calls map[string]int
+
+	server *grpc.Server
+}
+
+// Start serves the Extender service on a local port and returns its address.
+func (s *Server) Start() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	s.server = grpc.NewServer()
+	extenderpb.RegisterExtenderServer(s.server, s)
+	go s.server.Serve(listener)
+	return listener.Addr().String(), nil
+}
+
+// Stop stops the server.
+func (s *Server) Stop() {
+	if s.server != nil {
+		s.server.Stop()
+	}
+}
+
+// Calls returns the number of calls of the method.
+func (s *Server) Calls(method string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls[method]
+}
+
+func (s *Server) called(ctx context.Context, method string) error {
+	s.mutex.Lock()
+	if s.calls == nil {
+		s.calls = map[string]int{}
+	}
+	s.calls[method]++
+	s.mutex.Unlock()
+
+	if s.Delay > 0 {
+		select {
+		case <-time.After(s.Delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (s *Server) OnSessionOpen(ctx context.Context, in *extenderpb.OnSessionOpenRequest) (*extenderpb.OnSessionOpenResponse, error) {
+	if err := s.called(ctx, "OnSessionOpen"); err != nil {
+		return nil, err
+	}
+	return &extenderpb.OnSessionOpenResponse{}, nil
+}
+
+func (s *Server) OnSessionClose(ctx context.Context, in *extenderpb.OnSessionCloseRequest) (*extenderpb.OnSessionCloseResponse, error) {
+	if err := s.called(ctx, "OnSessionClose"); err != nil {
+		return nil, err
+	}
+	return &extenderpb.OnSessionCloseResponse{}, nil
+}
+
+func (s *Server) Predicate(ctx context.Context, in *extenderpb.PredicateRequest) (*extenderpb.PredicateResponse, error) {
+	if err := s.called(ctx, "Predicate"); err != nil {
+		return nil, err
+	}
+	if s.PredicateFn != nil {
+		return s.PredicateFn(in)
+	}
+	return &extenderpb.PredicateResponse{}, nil
+}
+
+func (s *Server) BatchNodeOrder(ctx context.Context, in *extenderpb.BatchNodeOrderRequest) (*extenderpb.BatchNodeOrderResponse, error) {
+	if err := s.called(ctx, "BatchNodeOrder"); err != nil {
+		return nil, err
+	}
+	if s.BatchNodeOrderFn != nil {
+		return s.BatchNodeOrderFn(in)
+	}
+	return &extenderpb.BatchNodeOrderResponse{NodeScores: map[string]float64{}}, nil
+}
+
+func (s *Server) JobValid(ctx context.Context, in *extenderpb.JobValidRequest) (*extenderpb.JobValidResponse, error) {
+	if err := s.called(ctx, "JobValid"); err != nil {
+		return nil, err
+	}
+	if s.JobValidFn != nil {
+		return s.JobValidFn(in)
+	}
+	return &extenderpb.JobValidResponse{}, nil
+}
+
+func (s *Server) JobOrder(ctx context.Context, in *extenderpb.JobOrderRequest) (*extenderpb.JobOrderResponse, error) {
+	if err := s.called(ctx, "JobOrder"); err != nil {
+		return nil, err
+	}
+	if s.JobOrderFn != nil {
+		return s.JobOrderFn(in)
+	}
+	return &extenderpb.JobOrderResponse{}, nil
+}
+
+func (s *Server) Bind(ctx context.Context, in *extenderpb.BindRequest) (*extenderpb.BindResponse, error) {
+	if err := s.called(ctx, "Bind"); err != nil {
+		return nil, err
+	}
+	if s.BindFn != nil {
+		return s.BindFn(in)
+	}
+	return &extenderpb.BindResponse{}, nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/extender/extenderpb"
+)
+
+// errUnsupportedVerb is returned for the verbs the gRPC service does not have.
+var errUnsupportedVerb = errors.New("verb is not supported by the gRPC extender")
+
+// grpcTransport calls the methods of the Extender gRPC service, the connections being used in turn.
+type grpcTransport struct {
+	conns   []*grpc.ClientConn
+	clients []extenderpb.ExtenderClient
+	next    atomic.Uint32
+}
+
+func newGRPCTransport(address string, maxConnections int) (*grpcTransport, error) {
+	if maxConnections <= 0 {
+		maxConnections = 1
+	}
+	gt := &grpcTransport{}
+	for i := 0; i < maxConnections; i++ {
+		// The connection is established in the background, and again after a failure.
+		conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			gt.close()
+			return nil, fmt.Errorf("failed to dial extender at %s: %v", address, err)
+		}
+		gt.conns = append(gt.conns, conn)
+		gt.clients = append(gt.clients, extenderpb.NewExtenderClient(conn))
+	}
+	return gt, nil
+}
+
+func (gt *grpcTransport) close() {
+	for _, conn := range gt.conns {
+		conn.Close()
+	}
+}
+
+func (gt *grpcTransport) client() extenderpb.ExtenderClient {
+	return gt.clients[int(gt.next.Add(1))%len(gt.clients)]
+}
+
+func (gt *grpcTransport) send(ctx context.Context, verb, path string, args interface{}, result interface{}) error {
+	client := gt.client()
+	switch req := args.(type) {
+	case *OnSessionOpenRequest:
+		in := &extenderpb.OnSessionOpenRequest{Session: string(req.Session)}
+		for _, job := range req.Jobs {
+			in.Jobs = append(in.Jobs, toJob(job))
+		}
+		for _, node := range req.Nodes {
+			in.Nodes = append(in.Nodes, toNode(node))
+		}
+		_, err := client.OnSessionOpen(ctx, in)
+		return err
+	case *OnSessionCloseRequest:
+		_, err := client.OnSessionClose(ctx, &extenderpb.OnSessionCloseRequest{Session: string(req.Session)})
+		return err
+	case *PredicateRequest:
+		task, err := toTask(req.Task)
+		if err != nil {
+			return err
+		}
+		out, err := client.Predicate(ctx, &extenderpb.PredicateRequest{Task: task, Node: toNode(req.Node)})
+		if err != nil {
+			return err
+		}
+		resp := result.(*PredicateResponse)
+		resp.Code = int(out.Code)
+		resp.ErrorMessage = out.ErrorMessage
+		return nil
+	case *PrioritizeRequest:
+		task, err := toTask(req.Task)
+		if err != nil {
+			return err
+		}
+		in := &extenderpb.BatchNodeOrderRequest{Task: task}
+		for _, node := range req.Nodes {
+			in.Nodes = append(in.Nodes, toNode(node))
+		}
+		out, err := client.BatchNodeOrder(ctx, in)
+		if err != nil {
+			return err
+		}
+		resp := result.(*PrioritizeResponse)
+		resp.NodeScore = out.NodeScores
+		resp.ErrorMessage = out.ErrorMessage
+		return nil
+	case *JobValidRequest:
+		in := &extenderpb.JobValidRequest{}
+		for _, job := range req.Jobs {
+			in.Jobs = append(in.Jobs, toJob(job))
+		}
+		out, err := client.JobValid(ctx, in)
+		if err != nil {
+			return err
+		}
+		resp := result.(*JobValidResponse)
+		resp.Results = map[api.JobID]*api.ValidateResult{}
+		for uid, r := range out.Results {
+			resp.Results[api.JobID(uid)] = &api.ValidateResult{Pass: r.Pass, Reason: r.Reason, Message: r.Message}
+		}
+		return nil
+	case *JobOrderRequest:
+		in := &extenderpb.JobOrderRequest{}
+		for _, job := range req.Jobs {
+			in.Jobs = append(in.Jobs, toJob(job))
+		}
+		out, err := client.JobOrder(ctx, in)
+		if err != nil {
+			return err
+		}
+		resp := result.(*JobOrderResponse)
+		for _, uid := range out.Jobs {
+			resp.Jobs = append(resp.Jobs, api.JobID(uid))
+		}
+		return nil
+	case *BindRequest:
+		task, err := toTask(req.Task)
+		if err != nil {
+			return err
+		}
+		out, err := client.Bind(ctx, &extenderpb.BindRequest{Task: task, Node: req.Node})
+		if err != nil {
+			return err
+		}
+		resp := result.(*BindResponse)
+		resp.Bound = out.Bound
+		resp.ErrorMessage = out.ErrorMessage
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errUnsupportedVerb, verb)
+}
+
+// toResources returns the resources by name, the cpu in millicores.
+func toResources(r *api.Resource) map[string]float64 {
+	if r == nil {
+		return nil
+	}
+	resources := map[string]float64{
+		"cpu":    r.MilliCPU,
+		"memory": r.Memory,
+	}
+	for name, quantity := range r.ScalarResources {
+		resources[string(name)] = quantity
+	}
+	return resources
+}
+
+func toTask(task *api.TaskInfo) (*extenderpb.Task, error) {
+	t := &extenderpb.Task{
+		Uid:       string(task.UID),
+		Namespace: task.Namespace,
+		Name:      task.Name,
+		Job:       string(task.Job),
+		NodeName:  task.NodeName,
+		Resreq:    toResources(task.Resreq),
+	}
+	if task.Pod != nil {
+		pod, err := json.Marshal(task.Pod)
+		if err != nil {
+			return nil, err
+		}
+		t.Pod = pod
+	}
+	return t, nil
+}
+
+func toNode(node *api.NodeInfo) *extenderpb.Node {
+	n := &extenderpb.Node{
+		Name:        node.Name,
+		Idle:        toResources(node.Idle),
+		Allocatable: toResources(node.Allocatable),
+	}
+	if node.Node != nil {
+		n.Labels = node.Node.Labels
+	}
+	return n
+}
+
+func toJob(job *api.JobInfo) *extenderpb.Job {
+	return &extenderpb.Job{
+		Uid:          string(job.UID),
+		Namespace:    job.Namespace,
+		Name:         job.Name,
+		Queue:        string(job.Queue),
+		Priority:     job.Priority,
+		MinAvailable: job.MinAvailable,
+		Pending:      int32(len(job.TaskStatusIndex[api.Pending])),
+		Ready:        job.ReadyTaskNum(),
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errCircuitOpen is returned without calling the extender while too many calls failed in a row.
+var errCircuitOpen = errors.New("extender circuit is open after consecutive failures")
+
+// transport sends the request of a verb to the extender and decodes its response into result.
+type transport interface {
+	send(ctx context.Context, verb, path string, args interface{}, result interface{}) error
+}
+
+// endpoint is the transport to an extender and its circuit breaker, shared by the sessions.
+type endpoint struct {
+	transport transport
+	breaker   *circuitBreaker
+}
+
+var (
+	endpointsMutex sync.Mutex
+	// endpoints pools the connections to the extenders across the sessions.
+	endpoints = map[string]*endpoint{}
+)
+
+// getEndpoint returns the endpoint of the extender of the configuration, creating it on first use.
+func getEndpoint(ec *extenderConfig) (*endpoint, error) {
+	endpointsMutex.Lock()
+	defer endpointsMutex.Unlock()
+
+	key := fmt.Sprintf("http/%s/%d", ec.urlPrefix, ec.maxConnections)
+	if ec.grpcAddress != "" {
+		key = fmt.Sprintf("grpc/%s/%d", ec.grpcAddress, ec.maxConnections)
+	}
+	if ep, found := endpoints[key]; found {
+		ep.breaker.configure(ec.failureThreshold, ec.circuitOpenDuration)
+		return ep, nil
+	}
+
+	var t transport
+	if ec.grpcAddress != "" {
+		gt, err := newGRPCTransport(ec.grpcAddress, ec.maxConnections)
+		if err != nil {
+			return nil, err
+		}
+		t = gt
+	} else {
+		t = newHTTPTransport(ec.urlPrefix, ec.maxConnections)
+	}
+	ep := &endpoint{transport: t, breaker: &circuitBreaker{}}
+	ep.breaker.configure(ec.failureThreshold, ec.circuitOpenDuration)
+	endpoints[key] = ep
+	return ep, nil
+}
+
+// circuitBreaker stops calling the extender for a while once threshold calls failed in a row.
+type circuitBreaker struct {
+	mutex sync.Mutex
+	// threshold is the number of failures in a row opening the circuit, 0 to never open it.
+	threshold    int
+	openDuration time.Duration
+
+	failures int
+	// openUntil is the end of the open circuit, zero while the circuit is closed.
+	openUntil time.Time
+	// probing is true while the call probing the extender after the open circuit is pending.
+	probing bool
+}
+
+func (cb *circuitBreaker) configure(threshold int, openDuration time.Duration) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.threshold = threshold
+	cb.openDuration = openDuration
+}
+
+// allow checks whether the extender may be called. Once the circuit was open for long enough a single
+// call is let through to probe the extender, the circuit closes if it succeeds and opens again if it fails.
+func (cb *circuitBreaker) allow() bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	if cb.threshold <= 0 || cb.openUntil.IsZero() {
+		return true
+	}
+	if cb.probing || time.Now().Before(cb.openUntil) {
+		return false
+	}
+	cb.probing = true
+	return true
+}
+
+// record records the result of a call, opening the circuit after threshold failures in a row or a failed probe.
+func (cb *circuitBreaker) record(err error) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	probe := cb.probing
+	cb.probing = false
+	if err == nil {
+		cb.failures = 0
+		cb.openUntil = time.Time{}
+		return
+	}
+	cb.failures++
+	if cb.threshold > 0 && (probe || cb.failures >= cb.threshold) {
+		cb.openUntil = time.Now().Add(cb.openDuration)
+	}
+}
+
+// abort ends the probe without a result, e.g. of a verb the extender does not support.
+func (cb *circuitBreaker) abort() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.probing = false
+}
+
+// httpTransport sends the requests in JSON to the path of the verbs under the url prefix.
+type httpTransport struct {
+	client    *http.Client
+	urlPrefix string
+}
+
+func newHTTPTransport(urlPrefix string, maxConnections int) *httpTransport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = maxConnections
+	return &httpTransport{
+		client:    &http.Client{Transport: t},
+		urlPrefix: urlPrefix,
+	}
+}
+
+func (ht *httpTransport) send(ctx context.Context, verb, path string, args interface{}, result interface{}) error {
+	out, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimRight(ht.urlPrefix, "/") + "/" + path
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(out))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ht.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed %v with extender at URL %v, code %v", path, url, resp.StatusCode)
+	}
+
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
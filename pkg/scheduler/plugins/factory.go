@@ -22,16 +22,20 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/plugins/capacity"
 	"volcano.sh/volcano/pkg/scheduler/plugins/cdp"
 	"volcano.sh/volcano/pkg/scheduler/plugins/conformance"
+	"volcano.sh/volcano/pkg/scheduler/plugins/deadline"
 	"volcano.sh/volcano/pkg/scheduler/plugins/deviceshare"
 	"volcano.sh/volcano/pkg/scheduler/plugins/drf"
 	"volcano.sh/volcano/pkg/scheduler/plugins/extender"
+	"volcano.sh/volcano/pkg/scheduler/plugins/fairshare"
 	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	networktopology "volcano.sh/volcano/pkg/scheduler/plugins/network-topology"
 	"volcano.sh/volcano/pkg/scheduler/plugins/nodegroup"
 	"volcano.sh/volcano/pkg/scheduler/plugins/nodeorder"
 	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware"
 	"volcano.sh/volcano/pkg/scheduler/plugins/overcommit"
 	"volcano.sh/volcano/pkg/scheduler/plugins/pdb"
 	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
+	preemptionpolicy "volcano.sh/volcano/pkg/scheduler/plugins/preemption-policy"
 	"volcano.sh/volcano/pkg/scheduler/plugins/priority"
 	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
 	"volcano.sh/volcano/pkg/scheduler/plugins/rescheduling"
@@ -55,6 +59,7 @@ func init() {
 	framework.RegisterPluginBuilder(tdm.PluginName, tdm.New)
 	framework.RegisterPluginBuilder(overcommit.PluginName, overcommit.New)
 	framework.RegisterPluginBuilder(sla.PluginName, sla.New)
+	framework.RegisterPluginBuilder(deadline.PluginName, deadline.New)
 	framework.RegisterPluginBuilder(tasktopology.PluginName, tasktopology.New)
 	framework.RegisterPluginBuilder(numaaware.PluginName, numaaware.New)
 	framework.RegisterPluginBuilder(cdp.PluginName, cdp.New)
@@ -62,14 +67,40 @@ func init() {
 	framework.RegisterPluginBuilder(usage.PluginName, usage.New)
 	framework.RegisterPluginBuilder(pdb.PluginName, pdb.New)
 	framework.RegisterPluginBuilder(nodegroup.PluginName, nodegroup.New)
+	framework.RegisterPluginBuilder(networktopology.PluginName, networktopology.New)
+	framework.RegisterPluginBuilder(preemptionpolicy.PluginName, preemptionpolicy.New)
 
 	// Plugins for Queues
 	framework.RegisterPluginBuilder(proportion.PluginName, proportion.New)
 	framework.RegisterPluginBuilder(capacity.PluginName, capacity.New)
+	framework.RegisterPluginBuilder(fairshare.PluginName, fairshare.New)
 
 	// Plugins for Extender
 	framework.RegisterPluginBuilder(extender.PluginName, extender.New)
 
 	// Plugins for ResourceQuota
 	framework.RegisterPluginBuilder(resourcequota.PluginName, resourcequota.New)
+
+	// Argument schemas, the configuration is rejected if a plugin gets unknown arguments.
+	framework.RegisterPluginArgumentSchema(binpack.PluginName, binpack.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(deadline.PluginName, deadline.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(deviceshare.PluginName, deviceshare.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(extender.PluginName, extender.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(fairshare.PluginName, fairshare.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(networktopology.PluginName, networktopology.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(nodeorder.PluginName, nodeorder.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(numaaware.PluginName, numaaware.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(overcommit.PluginName, overcommit.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(preemptionpolicy.PluginName, preemptionpolicy.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(predicates.PluginName, predicates.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(rescheduling.PluginName, rescheduling.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(sla.PluginName, sla.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(tasktopology.PluginName, tasktopology.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(tdm.PluginName, tdm.ArgumentSchema)
+	framework.RegisterPluginArgumentSchema(usage.PluginName, usage.ArgumentSchema)
+	// The plugins below take no arguments.
+	for _, name := range []string{drf.PluginName, gang.PluginName, priority.PluginName, conformance.PluginName,
+		cdp.PluginName, pdb.PluginName, nodegroup.PluginName, proportion.PluginName, capacity.PluginName, resourcequota.PluginName} {
+		framework.RegisterPluginArgumentSchema(name, framework.ArgumentSchema{})
+	}
 }
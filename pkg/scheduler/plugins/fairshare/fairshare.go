@@ -0,0 +1,360 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/helpers"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "fairshare"
+
+	// HalfLifeKey is the argument of the time after which the past usage counts for half
+	HalfLifeKey = "fairshare.halfLife"
+	// HistoryWeightKey is the argument of the weight of the decayed usage in the effective share,
+	// between 0 and 1, the current share having the rest
+	HistoryWeightKey = "fairshare.historyWeight"
+	// ConfigMapKey is the argument of the namespace/name of the ConfigMap the usage is persisted in
+	ConfigMapKey = "fairshare.configMap"
+	// PersistPeriodKey is the argument of the minimum time between two writes of the ConfigMap
+	PersistPeriodKey = "fairshare.persistPeriod"
+
+	defaultHalfLife      = 24 * time.Hour
+	defaultHistoryWeight = 0.5
+	defaultConfigMap     = "volcano-system/volcano-scheduler-fairshare"
+	defaultPersistPeriod = time.Minute
+
+	shareDelta = 0.000001
+)
+
+/*
+   actions: "enqueue, allocate, backfill"
+   tiers:
+   - plugins:
+     - name: priority
+     - name: gang
+     - name: fairshare
+       arguments:
+         fairshare.halfLife: 24h
+         fairshare.historyWeight: 0.5
+         fairshare.configMap: volcano-system/volcano-scheduler-fairshare
+         fairshare.persistPeriod: 1m
+*/
+
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	HalfLifeKey:      framework.StringArgument,
+	HistoryWeightKey: framework.FloatArgument,
+	ConfigMapKey:     framework.StringArgument,
+	PersistPeriodKey: framework.StringArgument,
+}
+
+// shareAttr is the usage of a queue or a namespace.
+type shareAttr struct {
+	name string
+	// fairShare is the share of the cluster the queue or namespace is entitled to given its weight.
+	fairShare float64
+	allocated *api.Resource
+	// share is the current dominant share of the cluster.
+	share float64
+	// decayedShare is the average dominant share over time, the older usage being decayed.
+	decayedShare float64
+	// effective is the share accounting for the decayed usage relative to the fair share,
+	// above 1 if the queue or namespace used more than it is entitled to.
+	effective float64
+	// waiting is true if the queue has jobs with pending tasks.
+	waiting bool
+}
+
+type fairSharePlugin struct {
+	pluginArguments framework.Arguments
+
+	halfLife      time.Duration
+	historyWeight float64
+	configMap     string
+	persistPeriod time.Duration
+
+	totalResource  *api.Resource
+	queueAttrs     map[api.QueueID]*shareAttr
+	namespaceAttrs map[string]*shareAttr
+}
+
+// New return fairshare plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	fp := &fairSharePlugin{
+		pluginArguments: arguments,
+		halfLife:        defaultHalfLife,
+		historyWeight:   defaultHistoryWeight,
+		configMap:       defaultConfigMap,
+		persistPeriod:   defaultPersistPeriod,
+	}
+	parseDuration(arguments, HalfLifeKey, &fp.halfLife)
+	parseDuration(arguments, PersistPeriodKey, &fp.persistPeriod)
+	historyWeight := fp.historyWeight
+	arguments.GetFloat64(&historyWeight, HistoryWeightKey)
+	if historyWeight >= 0 && historyWeight <= 1 {
+		fp.historyWeight = historyWeight
+	} else {
+		klog.Warningf("Invalid argument %s %v, it must be between 0 and 1, use %v", HistoryWeightKey, historyWeight, fp.historyWeight)
+	}
+	if configMap, ok := arguments[ConfigMapKey].(string); ok {
+		if _, _, err := cache.SplitMetaNamespaceKey(configMap); err != nil || len(configMap) == 0 {
+			klog.Warningf("Invalid argument %s %s, use %s", ConfigMapKey, configMap, fp.configMap)
+		} else {
+			fp.configMap = configMap
+		}
+	}
+	return fp
+}
+
+func parseDuration(arguments framework.Arguments, key string, ptr *time.Duration) {
+	value, ok := arguments[key].(string)
+	if !ok {
+		return
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		klog.Warningf("Could not parse argument: %s for key %s, with err %v", value, key, err)
+		return
+	}
+	*ptr = duration
+}
+
+func (fp *fairSharePlugin) Name() string {
+	return PluginName
+}
+
+func (fp *fairSharePlugin) OnSessionOpen(ssn *framework.Session) {
+	klog.V(5).Infof("Enter fairshare plugin ...")
+	defer klog.V(5).Infof("Leaving fairshare plugin ...")
+
+	store.load(ssn.KubeClient(), fp.configMap)
+
+	fp.totalResource = ssn.TotalResource.Clone()
+	fp.queueAttrs = map[api.QueueID]*shareAttr{}
+	fp.namespaceAttrs = map[string]*shareAttr{}
+
+	var totalWeight float64
+	for _, queue := range ssn.Queues {
+		totalWeight += queueWeight(queue)
+	}
+	for _, queue := range ssn.Queues {
+		fp.queueAttrs[queue.UID] = &shareAttr{
+			name:      queue.Name,
+			fairShare: queueWeight(queue) / totalWeight,
+			allocated: api.EmptyResource(),
+		}
+	}
+
+	for _, job := range ssn.Jobs {
+		qAttr, found := fp.queueAttrs[job.Queue]
+		if !found {
+			continue
+		}
+		nsAttr, found := fp.namespaceAttrs[job.Namespace]
+		if !found {
+			nsAttr = &shareAttr{name: job.Namespace, allocated: api.EmptyResource()}
+			fp.namespaceAttrs[job.Namespace] = nsAttr
+		}
+		for status, tasks := range job.TaskStatusIndex {
+			if !api.AllocatedStatus(status) {
+				continue
+			}
+			for _, task := range tasks {
+				qAttr.allocated.Add(task.Resreq)
+				nsAttr.allocated.Add(task.Resreq)
+			}
+		}
+		if !job.IsPending() && job.HasPendingTasks() {
+			qAttr.waiting = true
+		}
+	}
+	// The namespaces having jobs are entitled to equal shares.
+	for _, attr := range fp.namespaceAttrs {
+		attr.fairShare = 1 / float64(len(fp.namespaceAttrs))
+	}
+
+	queueShares := make(map[string]float64, len(fp.queueAttrs))
+	for _, attr := range fp.queueAttrs {
+		attr.share = fp.dominantShare(attr.allocated)
+		queueShares[attr.name] = attr.share
+	}
+	namespaceShares := make(map[string]float64, len(fp.namespaceAttrs))
+	for _, attr := range fp.namespaceAttrs {
+		attr.share = fp.dominantShare(attr.allocated)
+		namespaceShares[attr.name] = attr.share
+	}
+
+	// The usage is accounted by the live sessions only, the dry runs see the same usage.
+	state := store.update(time.Now(), fp.halfLife, queueShares, namespaceShares, !ssn.DryRun())
+	for _, attr := range fp.queueAttrs {
+		attr.decayedShare = decayedShare(state.Queues[attr.name], fp.halfLife)
+		fp.updateEffectiveShare(attr)
+		metrics.UpdateQueueFairShare(attr.name, attr.decayedShare, attr.effective)
+		klog.V(4).Infof("Queue <%s>: fair share <%v>, share <%v>, decayed share <%v>, effective share <%v>",
+			attr.name, attr.fairShare, attr.share, attr.decayedShare, attr.effective)
+	}
+	for _, attr := range fp.namespaceAttrs {
+		attr.decayedShare = decayedShare(state.Namespaces[attr.name], fp.halfLife)
+		fp.updateEffectiveShare(attr)
+		metrics.UpdateNamespaceEffectiveShare(attr.name, attr.effective)
+	}
+
+	ssn.AddQueueOrderFn(fp.Name(), func(l, r interface{}) int {
+		lv := l.(*api.QueueInfo)
+		rv := r.(*api.QueueInfo)
+
+		if lv.Queue.Spec.Priority != rv.Queue.Spec.Priority {
+			// return negative means high priority
+			return int(rv.Queue.Spec.Priority) - int(lv.Queue.Spec.Priority)
+		}
+
+		lAttr, lFound := fp.queueAttrs[lv.UID]
+		rAttr, rFound := fp.queueAttrs[rv.UID]
+		if !lFound || !rFound {
+			return 0
+		}
+		return compareShares(lAttr.effective, rAttr.effective)
+	})
+
+	ssn.AddJobOrderFn(fp.Name(), func(l, r interface{}) int {
+		lv := l.(*api.JobInfo)
+		rv := r.(*api.JobInfo)
+
+		if lv.Namespace == rv.Namespace {
+			return 0
+		}
+		lAttr, lFound := fp.namespaceAttrs[lv.Namespace]
+		rAttr, rFound := fp.namespaceAttrs[rv.Namespace]
+		if !lFound || !rFound {
+			return 0
+		}
+		klog.V(5).Infof("Fairshare JobOrderFn: <%v/%v> namespace effective share %v, <%v/%v> namespace effective share %v",
+			lv.Namespace, lv.Name, lAttr.effective, rv.Namespace, rv.Name, rAttr.effective)
+		return compareShares(lAttr.effective, rAttr.effective)
+	})
+
+	ssn.AddOverusedFn(fp.Name(), func(obj interface{}) bool {
+		queue := obj.(*api.QueueInfo)
+		attr, found := fp.queueAttrs[queue.UID]
+		if !found {
+			return false
+		}
+
+		overused := fp.overused(attr)
+		if overused {
+			klog.V(3).Infof("Queue <%v>: fair share <%v>, share <%v>, decayed share <%v>, effective share <%v>",
+				queue.Name, attr.fairShare, attr.share, attr.decayedShare, attr.effective)
+		}
+		return overused
+	})
+
+	// Register event handlers.
+	ssn.AddEventHandler(&framework.EventHandler{
+		AllocateFunc: func(event *framework.Event) {
+			fp.updateAllocated(ssn, event.Task, true)
+		},
+		DeallocateFunc: func(event *framework.Event) {
+			fp.updateAllocated(ssn, event.Task, false)
+		},
+	})
+}
+
+func (fp *fairSharePlugin) OnSessionClose(ssn *framework.Session) {
+	if !ssn.DryRun() {
+		store.persistAsync(ssn.KubeClient(), time.Now(), fp.persistPeriod)
+	}
+	fp.totalResource = nil
+	fp.queueAttrs = nil
+	fp.namespaceAttrs = nil
+}
+
+// overused returns whether the queue holds more than its fair share while its effective share is above 1,
+// as long as another queue that did not use its fair share is waiting for resources.
+func (fp *fairSharePlugin) overused(attr *shareAttr) bool {
+	if attr.effective <= 1+shareDelta || attr.share <= attr.fairShare {
+		return false
+	}
+	for _, other := range fp.queueAttrs {
+		if other != attr && other.waiting && other.effective < 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (fp *fairSharePlugin) updateAllocated(ssn *framework.Session, task *api.TaskInfo, allocate bool) {
+	job, found := ssn.Jobs[task.Job]
+	if !found {
+		return
+	}
+	for _, attr := range []*shareAttr{fp.queueAttrs[job.Queue], fp.namespaceAttrs[job.Namespace]} {
+		if attr == nil {
+			continue
+		}
+		if allocate {
+			attr.allocated.Add(task.Resreq)
+		} else {
+			attr.allocated.Sub(task.Resreq)
+		}
+		attr.share = fp.dominantShare(attr.allocated)
+		fp.updateEffectiveShare(attr)
+	}
+	klog.V(4).Infof("Fairshare update: task <%v/%v>, resreq <%v>, allocate %v", task.Namespace, task.Name, task.Resreq, allocate)
+}
+
+func (fp *fairSharePlugin) dominantShare(allocated *api.Resource) float64 {
+	res := float64(0)
+	for _, rn := range fp.totalResource.ResourceNames() {
+		share := helpers.Share(allocated.Get(rn), fp.totalResource.Get(rn))
+		if share > res {
+			res = share
+		}
+	}
+	return res
+}
+
+func (fp *fairSharePlugin) updateEffectiveShare(attr *shareAttr) {
+	usage := (1-fp.historyWeight)*attr.share + fp.historyWeight*attr.decayedShare
+	attr.effective = helpers.Share(usage, attr.fairShare)
+}
+
+func queueWeight(queue *api.QueueInfo) float64 {
+	if queue.Weight < 1 {
+		return 1
+	}
+	return float64(queue.Weight)
+}
+
+func compareShares(l, r float64) int {
+	if l < r-shareDelta {
+		return -1
+	}
+	if l > r+shareDelta {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"math"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+// resetStore replaces the usage store by a loaded one holding the decayed shares of the queues.
+func resetStore(queueShares map[string]float64) {
+	store = &usageStore{state: newUsageState(), configMap: defaultConfigMap, loaded: true}
+	for name, share := range queueShares {
+		store.state.Queues[name] = share * defaultHalfLife.Seconds() / math.Ln2
+	}
+	store.state.LastUpdate = time.Now()
+}
+
+func TestUsageStateUpdate(t *testing.T) {
+	halfLife := time.Hour
+	start := time.Now()
+	s := newUsageState()
+	s.update(start, halfLife, map[string]float64{"q1": 0.5}, nil)
+	if len(s.Queues) != 1 || s.Queues["q1"] != 0 {
+		t.Fatalf("expected no usage accounted at the first update, got %v", s.Queues)
+	}
+
+	s.update(start.Add(time.Hour), halfLife, map[string]float64{"q1": 0.5}, nil)
+	if s.Queues["q1"] != 1800 {
+		t.Errorf("expected usage 1800 after one hour at share 0.5, got %v", s.Queues["q1"])
+	}
+	s.update(start.Add(2*time.Hour), halfLife, map[string]float64{}, nil)
+	if s.Queues["q1"] != 900 {
+		t.Errorf("expected usage 900 decayed by one half-life, got %v", s.Queues["q1"])
+	}
+
+	// The decayed share of a constant share converges to the share.
+	s = newUsageState()
+	now := start
+	for i := 0; i <= 24*60; i++ {
+		s.update(now, halfLife, map[string]float64{"q1": 0.5}, map[string]float64{"ns1": 0.25})
+		now = now.Add(time.Minute)
+	}
+	if share := decayedShare(s.Queues["q1"], halfLife); math.Abs(share-0.5) > 0.01 {
+		t.Errorf("expected decayed share 0.5 of q1, got %v", share)
+	}
+	if share := decayedShare(s.Namespaces["ns1"], halfLife); math.Abs(share-0.25) > 0.01 {
+		t.Errorf("expected decayed share 0.25 of ns1, got %v", share)
+	}
+
+	// The usage of the queues that use nothing anymore is forgotten.
+	s.update(now.Add(100*halfLife), halfLife, nil, nil)
+	if len(s.Queues) != 0 || len(s.Namespaces) != 0 {
+		t.Errorf("expected the usage forgotten, got %v %v", s.Queues, s.Namespaces)
+	}
+}
+
+func TestUsagePersistence(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	state := &usageState{
+		LastUpdate: time.Now().Round(time.Second),
+		Queues:     map[string]float64{"q1": 3600},
+		Namespaces: map[string]float64{"ns1": 1800},
+	}
+	if err := persist(client, defaultConfigMap, state); err != nil {
+		t.Fatalf("failed to create the ConfigMap: %v", err)
+	}
+	state.Queues["q1"] = 7200
+	if err := persist(client, defaultConfigMap, state); err != nil {
+		t.Fatalf("failed to update the ConfigMap: %v", err)
+	}
+
+	us := &usageStore{state: newUsageState()}
+	us.load(client, defaultConfigMap)
+	if !us.loaded {
+		t.Fatalf("expected the usage loaded")
+	}
+	if !reflect.DeepEqual(us.state.Queues, state.Queues) || !reflect.DeepEqual(us.state.Namespaces, state.Namespaces) ||
+		!us.state.LastUpdate.Equal(state.LastUpdate) {
+		t.Errorf("expected the usage %v, got %v", state, us.state)
+	}
+
+	// A dry run does not change the usage of the store.
+	before := us.state.clone()
+	us.update(time.Now().Add(time.Hour), time.Hour, map[string]float64{"q1": 1}, nil, false)
+	if !reflect.DeepEqual(us.state, before) {
+		t.Errorf("expected the usage unchanged by a dry run, got %v", us.state)
+	}
+
+	us = &usageStore{state: newUsageState()}
+	us.load(client, "volcano-system/missing")
+	if !us.loaded || len(us.state.Queues) != 0 {
+		t.Errorf("expected no usage loaded from a missing ConfigMap, got %v", us.state)
+	}
+}
+
+func TestFairShare(t *testing.T) {
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:            gang.PluginName,
+					EnabledJobOrder: &trueValue,
+					EnabledJobReady: &trueValue,
+				},
+				{
+					Name:              PluginName,
+					EnabledQueueOrder: &trueValue,
+					EnabledJobOrder:   &trueValue,
+					EnabledOverused:   &trueValue,
+				},
+			},
+		},
+	}
+	plugins := map[string]framework.PluginBuilder{
+		gang.PluginName: gang.New,
+		PluginName:      New,
+	}
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		decayedShares map[string]float64
+		overused      map[string]bool
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "the queue that used less in the past is allocated first",
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1.PodGroupInqueue),
+					util.BuildPodGroup("pg2", "ns2", "q2", 1, nil, schedulingv1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("ns1", "p1-1", "", v1.PodPending, api.BuildResourceList("2", "1Gi"), "pg1", nil, nil),
+					util.BuildPod("ns1", "p1-2", "", v1.PodPending, api.BuildResourceList("2", "1Gi"), "pg1", nil, nil),
+					util.BuildPod("ns2", "p2-1", "", v1.PodPending, api.BuildResourceList("2", "1Gi"), "pg2", nil, nil),
+					util.BuildPod("ns2", "p2-2", "", v1.PodPending, api.BuildResourceList("2", "1Gi"), "pg2", nil, nil),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueue("q1", 1, nil),
+					util.BuildQueue("q2", 1, nil),
+				},
+				ExpectBindMap:  map[string]string{"ns2/p2-1": "n1", "ns2/p2-2": "n1"},
+				ExpectBindsNum: 2,
+			},
+			decayedShares: map[string]float64{"q1": 1},
+			overused:      map[string]bool{"q1": false, "q2": false},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "the queue holding more than its fair share after a heavy past usage is overused",
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1.PodGroupRunning),
+					util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1.PodGroupInqueue),
+					util.BuildPodGroup("pg3", "ns2", "q2", 1, nil, schedulingv1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("ns1", "p1-1", "n1", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg1", nil, nil),
+					util.BuildPod("ns1", "p2-1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+					util.BuildPod("ns2", "p3-1", "", v1.PodPending, api.BuildResourceList("2", "1Gi"), "pg3", nil, nil),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueue("q1", 1, nil),
+					util.BuildQueue("q2", 1, nil),
+				},
+			},
+			decayedShares: map[string]float64{"q1": 0.9},
+			overused:      map[string]bool{"q1": true, "q2": false},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "the queue with a higher weight is entitled to a larger share",
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1.PodGroupRunning),
+					util.BuildPodGroup("pg2", "ns2", "q2", 1, nil, schedulingv1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("ns1", "p1-1", "n1", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg1", nil, nil),
+					util.BuildPod("ns2", "p2-1", "", v1.PodPending, api.BuildResourceList("2", "1Gi"), "pg2", nil, nil),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueue("q1", 3, nil),
+					util.BuildQueue("q2", 1, nil),
+				},
+			},
+			decayedShares: map[string]float64{"q1": 0.75},
+			overused:      map[string]bool{"q1": false, "q2": false},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			resetStore(test.decayedShares)
+			test.Plugins = plugins
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+
+			for _, queue := range ssn.Queues {
+				if overused := ssn.Overused(queue); overused != test.overused[queue.Name] {
+					t.Errorf("expected queue %s overused %v, got %v", queue.Name, test.overused[queue.Name], overused)
+				}
+			}
+			if test.ExpectBindsNum > 0 {
+				test.Run([]framework.Action{allocate.New()})
+				if err := test.CheckAll(i); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestJobOrder(t *testing.T) {
+	resetStore(nil)
+	store.state.Namespaces["ns1"] = defaultHalfLife.Seconds() / math.Ln2
+
+	trueValue := true
+	test := uthelper.TestCommonStruct{
+		PodGroups: []*schedulingv1.PodGroup{
+			util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1.PodGroupInqueue),
+			util.BuildPodGroup("pg2", "ns2", "q1", 1, nil, schedulingv1.PodGroupInqueue),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("ns1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+			util.BuildPod("ns2", "p2", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+		},
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+		},
+		Queues:  []*schedulingv1.Queue{util.BuildQueue("q1", 1, nil)},
+		Plugins: map[string]framework.PluginBuilder{PluginName: New},
+	}
+	ssn := test.RegisterSession([]conf.Tier{{Plugins: []conf.PluginOption{{Name: PluginName, EnabledJobOrder: &trueValue}}}}, nil)
+	defer test.Close()
+
+	ns1Job, ns2Job := ssn.Jobs["ns1/pg1"], ssn.Jobs["ns2/pg2"]
+	if !ssn.JobOrderFn(ns2Job, ns1Job) || ssn.JobOrderFn(ns1Job, ns2Job) {
+		t.Errorf("expected the job of the namespace that used less in the past first")
+	}
+}
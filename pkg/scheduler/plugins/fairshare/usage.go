@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// usageKey is the key of the usage in the data of the ConfigMap.
+	usageKey = "usage"
+	// minUsage is the usage below which the queues and namespaces that use nothing anymore are forgotten.
+	minUsage = 1e-3
+)
+
+// usageState is the decayed usage of the queues and namespaces, as persisted in the ConfigMap.
+type usageState struct {
+	// LastUpdate is when the usage was last decayed and accumulated.
+	LastUpdate time.Time `json:"lastUpdate"`
+	// Queues is the usage of the queues by name, in dominant share seconds.
+	Queues map[string]float64 `json:"queues"`
+	// Namespaces is the usage of the namespaces by name, in dominant share seconds.
+	Namespaces map[string]float64 `json:"namespaces"`
+}
+
+func newUsageState() *usageState {
+	return &usageState{
+		Queues:     map[string]float64{},
+		Namespaces: map[string]float64{},
+	}
+}
+
+func (s *usageState) clone() *usageState {
+	c := &usageState{
+		LastUpdate: s.LastUpdate,
+		Queues:     make(map[string]float64, len(s.Queues)),
+		Namespaces: make(map[string]float64, len(s.Namespaces)),
+	}
+	for name, usage := range s.Queues {
+		c.Queues[name] = usage
+	}
+	for name, usage := range s.Namespaces {
+		c.Namespaces[name] = usage
+	}
+	return c
+}
+
+// update decays the usage since the last update by the half-life, and accumulates the current
+// dominant shares of the queues and namespaces over the same period.
+func (s *usageState) update(now time.Time, halfLife time.Duration, queueShares, namespaceShares map[string]float64) {
+	var elapsed time.Duration
+	if !s.LastUpdate.IsZero() && now.After(s.LastUpdate) {
+		elapsed = now.Sub(s.LastUpdate)
+	}
+	factor := math.Exp2(-elapsed.Seconds() / halfLife.Seconds())
+	accumulate(s.Queues, queueShares, factor, elapsed)
+	accumulate(s.Namespaces, namespaceShares, factor, elapsed)
+	s.LastUpdate = now
+}
+
+func accumulate(usage, shares map[string]float64, factor float64, elapsed time.Duration) {
+	for name := range usage {
+		usage[name] *= factor
+	}
+	for name, share := range shares {
+		usage[name] += share * elapsed.Seconds()
+	}
+	for name, value := range usage {
+		if _, found := shares[name]; !found && value < minUsage {
+			delete(usage, name)
+		}
+	}
+}
+
+// decayedShare returns the average dominant share of the usage over time, weighted by the decay:
+// a queue holding the share s for many half-lives has a decayed share of s.
+func decayedShare(usage float64, halfLife time.Duration) float64 {
+	return usage * math.Ln2 / halfLife.Seconds()
+}
+
+// usageStore keeps the usage across the sessions, the plugin being built again for every session,
+// and persists it in a ConfigMap so that it survives the restarts of the scheduler.
+type usageStore struct {
+	mutex sync.Mutex
+	// configMap is the namespace/name of the ConfigMap the usage is persisted in.
+	configMap string
+	// loaded is true once the usage was read from the ConfigMap, it is not persisted before
+	// so that a transient error does not overwrite the persisted usage.
+	loaded      bool
+	persisting  bool
+	lastPersist time.Time
+	state       *usageState
+}
+
+// store is shared by the sessions of the scheduler.
+var store = &usageStore{state: newUsageState()}
+
+// load reads the usage from the ConfigMap unless it was already, or if the ConfigMap changed.
+func (us *usageStore) load(client kubernetes.Interface, configMap string) {
+	us.mutex.Lock()
+	defer us.mutex.Unlock()
+
+	if us.configMap != configMap {
+		us.configMap = configMap
+		us.loaded = false
+	}
+	if us.loaded || client == nil {
+		return
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(configMap)
+	if err != nil {
+		klog.Errorf("Invalid fair-share ConfigMap %s: %v", configMap, err)
+		return
+	}
+	cm, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		klog.V(3).Infof("Fair-share ConfigMap %s not found, start with no usage", configMap)
+		us.loaded = true
+		return
+	}
+	if err != nil {
+		klog.Errorf("Failed to get fair-share ConfigMap %s: %v", configMap, err)
+		return
+	}
+	state := newUsageState()
+	if data, found := cm.Data[usageKey]; found {
+		if err := json.Unmarshal([]byte(data), state); err != nil {
+			klog.Errorf("Failed to decode the usage of fair-share ConfigMap %s, start with no usage: %v", configMap, err)
+			state = newUsageState()
+		}
+	}
+	if state.Queues == nil {
+		state.Queues = map[string]float64{}
+	}
+	if state.Namespaces == nil {
+		state.Namespaces = map[string]float64{}
+	}
+	us.state = state
+	us.loaded = true
+}
+
+// update decays and accumulates the usage until now, and returns a copy of it. The usage of
+// the store is left unchanged if commit is false, so that dry runs do not account their shares.
+func (us *usageStore) update(now time.Time, halfLife time.Duration, queueShares, namespaceShares map[string]float64, commit bool) *usageState {
+	us.mutex.Lock()
+	defer us.mutex.Unlock()
+
+	state := us.state.clone()
+	state.update(now, halfLife, queueShares, namespaceShares)
+	if commit {
+		us.state = state.clone()
+	}
+	return state
+}
+
+// persistAsync writes the usage to the ConfigMap in the background if the last write
+// is older than period, the scheduling cycle does not wait for the API server.
+func (us *usageStore) persistAsync(client kubernetes.Interface, now time.Time, period time.Duration) {
+	us.mutex.Lock()
+	defer us.mutex.Unlock()
+
+	if !us.loaded || us.persisting || client == nil || now.Sub(us.lastPersist) < period {
+		return
+	}
+	us.persisting = true
+	us.lastPersist = now
+	state := us.state.clone()
+	configMap := us.configMap
+	go func() {
+		if err := persist(client, configMap, state); err != nil {
+			klog.Errorf("Failed to persist the usage in fair-share ConfigMap %s: %v", configMap, err)
+		}
+		us.mutex.Lock()
+		us.persisting = false
+		us.mutex.Unlock()
+	}()
+}
+
+// persist writes the usage to the ConfigMap, which is created if missing.
+func persist(client kubernetes.Interface, configMap string, state *usageState) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(configMap)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	cm, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string]string{usageKey: string(data)},
+		}
+		_, err = client.CoreV1().ConfigMaps(namespace).Create(context.TODO(), cm, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the ConfigMap: %v", err)
+	}
+	cm = cm.DeepCopy()
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[usageKey] = string(data)
+	_, err = client.CoreV1().ConfigMaps(namespace).Update(context.TODO(), cm, metav1.UpdateOptions{})
+	return err
+}
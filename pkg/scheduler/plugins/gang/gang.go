@@ -18,6 +18,7 @@ package gang
 
 import (
 	"fmt"
+	"sort"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -84,12 +85,25 @@ func (gp *gangPlugin) OnSessionOpen(ssn *framework.Session) {
 		var victims []*api.TaskInfo
 		jobOccupiedMap := map[api.JobID]int32{}
 
+		// The elastic tasks are considered first, so that the tasks above minAvailable are the victims.
+		elastic := map[api.TaskID]bool{}
 		for _, preemptee := range preemptees {
 			job := ssn.Jobs[preemptee.Job]
-			if _, found := jobOccupiedMap[job.UID]; !found {
-				jobOccupiedMap[job.UID] = job.ReadyTaskNum()
+			if _, found := jobOccupiedMap[job.UID]; found {
+				continue
 			}
+			jobOccupiedMap[job.UID] = job.ReadyTaskNum()
+			for _, task := range job.ElasticTasks() {
+				elastic[task.UID] = true
+			}
+		}
+		preemptees = append([]*api.TaskInfo{}, preemptees...)
+		sort.SliceStable(preemptees, func(i, j int) bool {
+			return elastic[preemptees[i].UID] && !elastic[preemptees[j].UID]
+		})
 
+		for _, preemptee := range preemptees {
+			job := ssn.Jobs[preemptee.Job]
 			if jobOccupiedMap[job.UID] > job.MinAvailable {
 				jobOccupiedMap[job.UID]--
 				victims = append(victims, preemptee)
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopology
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// domain is a network domain, the nodes sharing the values of the hierarchy labels down to its tier.
+type domain struct {
+	// tier is 1 for the lowest level of the hierarchy, the tier above the highest level is the whole cluster.
+	tier int
+	// name is the path of the label values, e.g. zone-a/spine-1/leaf-3.
+	name  string
+	nodes sets.Set[string]
+}
+
+// hierarchy is the network hierarchy built from the node labels, e.g. zone > spine > leaf > node.
+type hierarchy struct {
+	// labels are the label keys of the levels, from the highest to the lowest.
+	labels []string
+	// domains are the domains of each tier, the tier 0 being unused.
+	domains [][]*domain
+	// nodeDomains are the domain names of a node at each tier, empty where the node misses a label.
+	nodeDomains map[string][]string
+}
+
+func newHierarchy(labels []string, nodes map[string]*api.NodeInfo) *hierarchy {
+	h := &hierarchy{
+		labels:      labels,
+		domains:     make([][]*domain, len(labels)+2),
+		nodeDomains: make(map[string][]string, len(nodes)),
+	}
+	cluster := &domain{tier: h.clusterTier(), nodes: sets.New[string]()}
+	h.domains[cluster.tier] = []*domain{cluster}
+
+	byName := map[string]*domain{}
+	for name, node := range nodes {
+		if node.Node == nil {
+			continue
+		}
+		cluster.nodes.Insert(name)
+		names := make([]string, len(labels)+2)
+		var path []string
+		for level, key := range labels {
+			value, found := node.Node.Labels[key]
+			if !found || len(value) == 0 {
+				break
+			}
+			path = append(path, value)
+			tier := len(labels) - level
+			names[tier] = strings.Join(path, "/")
+			d, found := byName[names[tier]]
+			if !found {
+				d = &domain{tier: tier, name: names[tier], nodes: sets.New[string]()}
+				byName[d.name] = d
+				h.domains[tier] = append(h.domains[tier], d)
+			}
+			d.nodes.Insert(name)
+		}
+		h.nodeDomains[name] = names
+	}
+
+	for tier := range h.domains {
+		sort.Slice(h.domains[tier], func(i, j int) bool {
+			return h.domains[tier][i].name < h.domains[tier][j].name
+		})
+	}
+	return h
+}
+
+// clusterTier returns the tier of the whole cluster, above the highest level of the hierarchy.
+func (h *hierarchy) clusterTier() int {
+	return len(h.labels) + 1
+}
+
+// distance returns the number of tiers to go up from the domain to reach a domain including the node,
+// 0 if the node is in the domain.
+func (h *hierarchy) distance(d *domain, node string) int {
+	if d.nodes.Has(node) {
+		return 0
+	}
+	names := h.nodeDomains[node]
+	for tier := d.tier + 1; tier < h.clusterTier(); tier++ {
+		if len(names[tier]) > 0 && strings.HasPrefix(d.name, names[tier]+"/") {
+			return tier - d.tier
+		}
+	}
+	return h.clusterTier() - d.tier
+}
+
+// place returns the smallest domain up to the tier including the nodes and with enough idle resources
+// for the tasks, or nil if there is none.
+func (h *hierarchy) place(maxTier int, placed sets.Set[string], tasks []*api.TaskInfo, nodes map[string]*api.NodeInfo) *domain {
+	for tier := 1; tier <= maxTier && tier <= h.clusterTier(); tier++ {
+		candidates := make([]*domain, 0, len(h.domains[tier]))
+		for _, d := range h.domains[tier] {
+			if d.nodes.IsSuperset(placed) {
+				candidates = append(candidates, d)
+			}
+		}
+		// The domains with the fewest nodes are tried first to keep the larger ones for the larger jobs.
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].nodes.Len() < candidates[j].nodes.Len()
+		})
+		for _, d := range candidates {
+			if fit(d, tasks, nodes) {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// fit returns whether the tasks fit in the future idle resources of the nodes of the domain, placing the tasks
+// from the largest to the smallest on the first node they fit on.
+func fit(d *domain, tasks []*api.TaskInfo, nodes map[string]*api.NodeInfo) bool {
+	if len(tasks) == 0 {
+		return true
+	}
+	names := sets.List(d.nodes)
+	idle := make([]*api.Resource, 0, len(names))
+	for _, name := range names {
+		if node, found := nodes[name]; found && node.Ready() {
+			idle = append(idle, node.FutureIdle())
+		}
+	}
+
+	tasks = append([]*api.TaskInfo{}, tasks...)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		l, r := tasks[i].Resreq, tasks[j].Resreq
+		if l.MilliCPU != r.MilliCPU {
+			return l.MilliCPU > r.MilliCPU
+		}
+		return l.Memory > r.Memory
+	})
+	for _, task := range tasks {
+		placed := false
+		for _, resource := range idle {
+			if task.Resreq.LessEqual(resource, api.Zero) {
+				resource.Sub(task.Resreq)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopology
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "network-topology"
+
+	// LabelsKey is the argument of the node label keys of the network hierarchy, from the highest level to the lowest
+	LabelsKey = "network-topology.labels"
+	// WeightKey is the argument of the weight of the plugin in the node order
+	WeightKey = "network-topology.weight"
+
+	// MaxTierAnnotation is the podgroup annotation of the highest tier the job may span, 1 being the lowest level
+	// of the hierarchy. The job is only placed in a domain up to this tier when set, and preferably in the
+	// smallest domain with enough resources otherwise.
+	MaxTierAnnotation = "volcano.sh/network-topology-max-tier"
+)
+
+var defaultLabels = []string{"topology.kubernetes.io/zone", "volcano.sh/network-spine", "volcano.sh/network-leaf"}
+
+/*
+   actions: "enqueue, allocate, backfill"
+   tiers:
+   - plugins:
+     - name: priority
+     - name: gang
+     - name: predicates
+     - name: network-topology
+       arguments:
+         network-topology.labels: [topology.kubernetes.io/zone, volcano.sh/network-spine, volcano.sh/network-leaf]
+         network-topology.weight: 10
+*/
+
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	LabelsKey: framework.ListArgument,
+	WeightKey: framework.IntArgument,
+}
+
+// placement is the domain chosen for a job in the session.
+type placement struct {
+	// domain is nil if no domain has enough resources for the job.
+	domain *domain
+	// maxTier is the highest tier the job may span, 0 if it is not constrained.
+	maxTier int
+}
+
+type networkTopologyPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+
+	labels []string
+	weight int
+
+	hierarchy  *hierarchy
+	placements map[api.JobID]*placement
+}
+
+// New return network-topology plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	np := &networkTopologyPlugin{
+		pluginArguments: arguments,
+		labels:          defaultLabels,
+		weight:          1,
+	}
+	arguments.GetInt(&np.weight, WeightKey)
+	if values, ok := arguments[LabelsKey].([]interface{}); ok {
+		labels := make([]string, 0, len(values))
+		for _, value := range values {
+			if label, ok := value.(string); ok && len(label) > 0 {
+				labels = append(labels, label)
+			}
+		}
+		if len(labels) == len(values) && len(labels) > 0 {
+			np.labels = labels
+		} else {
+			klog.Warningf("Invalid argument %s %v, use %v", LabelsKey, values, np.labels)
+		}
+	}
+	return np
+}
+
+func (np *networkTopologyPlugin) Name() string {
+	return PluginName
+}
+
+// maxTier returns the highest tier the job may span set by its annotation, 0 if it is not constrained.
+func (np *networkTopologyPlugin) maxTier(job *api.JobInfo) int {
+	if job.PodGroup == nil {
+		return 0
+	}
+	value, found := job.PodGroup.Annotations[MaxTierAnnotation]
+	if !found {
+		return 0
+	}
+	tier, err := strconv.Atoi(value)
+	if err != nil || tier < 1 || tier > np.hierarchy.clusterTier() {
+		klog.Warningf("Invalid annotation %s %q of job <%s/%s>, it must be between 1 and %d",
+			MaxTierAnnotation, value, job.Namespace, job.Name, np.hierarchy.clusterTier())
+		return 0
+	}
+	return tier
+}
+
+// placement returns the domain the job is placed in. It is chosen once per session for the whole job, so that
+// the tasks allocated first do not take the resources the others need in the domain.
+func (np *networkTopologyPlugin) placement(ssn *framework.Session, job *api.JobInfo) *placement {
+	if p, found := np.placements[job.UID]; found {
+		return p
+	}
+
+	placed := sets.New[string]()
+	var pending []*api.TaskInfo
+	for _, task := range job.Tasks {
+		switch {
+		case task.Status == api.Pending:
+			if !task.SchGated {
+				pending = append(pending, task)
+			}
+		case api.AllocatedStatus(task.Status) || task.Status == api.Pipelined:
+			if len(task.NodeName) > 0 {
+				placed.Insert(task.NodeName)
+			}
+		}
+	}
+
+	p := &placement{maxTier: np.maxTier(job)}
+	maxTier := p.maxTier
+	if maxTier == 0 {
+		maxTier = np.hierarchy.clusterTier()
+	}
+	p.domain = np.hierarchy.place(maxTier, placed, pending, ssn.Nodes)
+	// A gang only needs its minAvailable tasks to run, the domain fitting them is used if none fits them all.
+	if missing := int(job.MinAvailable) - placed.Len(); p.domain == nil && missing > 0 && missing < len(pending) {
+		queue := util.NewPriorityQueue(ssn.TaskOrderFn)
+		for _, task := range pending {
+			queue.Push(task)
+		}
+		required := make([]*api.TaskInfo, 0, missing)
+		for len(required) < missing {
+			required = append(required, queue.Pop().(*api.TaskInfo))
+		}
+		p.domain = np.hierarchy.place(maxTier, placed, required, ssn.Nodes)
+	}
+
+	if p.domain != nil {
+		klog.V(4).Infof("Job <%s/%s> is placed in network domain %q of tier %d",
+			job.Namespace, job.Name, p.domain.name, p.domain.tier)
+	} else {
+		klog.V(4).Infof("No network domain up to tier %d has enough resources for job <%s/%s>",
+			maxTier, job.Namespace, job.Name)
+	}
+	np.placements[job.UID] = p
+	return p
+}
+
+func (np *networkTopologyPlugin) OnSessionOpen(ssn *framework.Session) {
+	klog.V(5).Infof("Enter network-topology plugin ...")
+	defer klog.V(5).Infof("Leaving network-topology plugin ...")
+
+	np.hierarchy = newHierarchy(np.labels, ssn.Nodes)
+	np.placements = map[api.JobID]*placement{}
+
+	predicateFn := func(task *api.TaskInfo, node *api.NodeInfo) error {
+		job, found := ssn.Jobs[task.Job]
+		if !found || np.maxTier(job) == 0 {
+			return nil
+		}
+		p := np.placement(ssn, job)
+		if p.domain == nil {
+			return api.NewFitErrWithStatus(task, node, &api.Status{
+				Code:   api.Unschedulable,
+				Reason: fmt.Sprintf("no network domain up to tier %d has enough resources for the job", p.maxTier),
+			})
+		}
+		if !p.domain.nodes.Has(node.Name) {
+			return api.NewFitErrWithStatus(task, node, &api.Status{
+				Code:   api.Unschedulable,
+				Reason: fmt.Sprintf("node is out of the network domain %q of the job", p.domain.name),
+			})
+		}
+		return nil
+	}
+	ssn.AddPredicateFn(np.Name(), predicateFn)
+
+	batchNodeOrderFn := func(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]float64, error) {
+		nodeScores := make(map[string]float64, len(nodes))
+		job, found := ssn.Jobs[task.Job]
+		if !found {
+			return nodeScores, nil
+		}
+		p := np.placement(ssn, job)
+		if p.domain == nil || p.domain.tier == np.hierarchy.clusterTier() {
+			return nodeScores, nil
+		}
+
+		// The nodes of the domain get the max score, the score decreasing with the number of tiers to go up
+		// to reach a domain including the node.
+		span := float64(np.hierarchy.clusterTier() - p.domain.tier)
+		for _, node := range nodes {
+			distance := float64(np.hierarchy.distance(p.domain, node.Name))
+			nodeScores[node.Name] = float64(api.DefaultMaxNodeScore) * (span - distance) / span * float64(np.weight)
+		}
+		klog.V(4).Infof("network-topology plugin Score for task %s/%s is: %v", task.Namespace, task.Name, nodeScores)
+		return nodeScores, nil
+	}
+	ssn.AddBatchNodeOrderFn(np.Name(), batchNodeOrderFn)
+}
+
+func (np *networkTopologyPlugin) OnSessionClose(ssn *framework.Session) {
+	np.hierarchy = nil
+	np.placements = nil
+}
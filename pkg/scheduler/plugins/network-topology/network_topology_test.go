@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopology
+
+import (
+	"os"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+const (
+	spineLabel = "volcano.sh/network-spine"
+	leafLabel  = "volcano.sh/network-leaf"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+// buildNodes returns n1 and n2 under leaf l1, n3 under leaf l2, both under spine s1, and n4 under spine s2.
+func buildNodes() []*v1.Node {
+	build := func(name, spine, leaf string) *v1.Node {
+		return util.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...),
+			map[string]string{spineLabel: spine, leafLabel: leaf})
+	}
+	return []*v1.Node{build("n1", "s1", "l1"), build("n2", "s1", "l1"), build("n3", "s1", "l2"), build("n4", "s2", "l3")}
+}
+
+func TestHierarchy(t *testing.T) {
+	nodes := map[string]*api.NodeInfo{}
+	for _, node := range buildNodes() {
+		nodes[node.Name] = api.NewNodeInfo(node)
+	}
+	h := newHierarchy([]string{spineLabel, leafLabel}, nodes)
+
+	if h.clusterTier() != 3 {
+		t.Fatalf("expected the cluster at tier 3, got %d", h.clusterTier())
+	}
+	var leaves []string
+	for _, d := range h.domains[1] {
+		leaves = append(leaves, d.name)
+	}
+	if len(leaves) != 3 || leaves[0] != "s1/l1" || leaves[1] != "s1/l2" || leaves[2] != "s2/l3" {
+		t.Errorf("expected the leaves s1/l1, s1/l2 and s2/l3, got %v", leaves)
+	}
+
+	leaf := h.domains[1][0]
+	for node, expected := range map[string]int{"n1": 0, "n3": 1, "n4": 2} {
+		if got := h.distance(leaf, node); got != expected {
+			t.Errorf("expected the distance of %s to %s to be %d, got %d", node, leaf.name, expected, got)
+		}
+	}
+
+	task := func(cpu string) *api.TaskInfo {
+		return api.NewTaskInfo(util.BuildPod("c1", "p-"+cpu, "", v1.PodPending, api.BuildResourceList(cpu, "1Gi"), "pg", nil, nil))
+	}
+	tests := []struct {
+		name     string
+		maxTier  int
+		placed   sets.Set[string]
+		tasks    []*api.TaskInfo
+		expected string
+	}{
+		{name: "a leaf fits the job", maxTier: 3, placed: sets.New[string](), tasks: []*api.TaskInfo{task("4"), task("3")}, expected: "s1/l1"},
+		{name: "the smallest leaf is tried first", maxTier: 3, placed: sets.New[string](), tasks: []*api.TaskInfo{task("4")}, expected: "s1/l2"},
+		{name: "the placed tasks are included", maxTier: 3, placed: sets.New("n1"), tasks: []*api.TaskInfo{task("4")}, expected: "s1/l1"},
+		{name: "a spine fits the job", maxTier: 3, placed: sets.New[string](), tasks: []*api.TaskInfo{task("4"), task("4"), task("4")}, expected: "s1"},
+		{name: "no domain up to the tier fits the job", maxTier: 1, placed: sets.New[string](), tasks: []*api.TaskInfo{task("4"), task("4"), task("4")}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got string
+			if d := h.place(test.maxTier, test.placed, test.tasks, nodes); d != nil {
+				got = d.name
+			}
+			if got != test.expected {
+				t.Errorf("expected domain %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestNetworkTopology(t *testing.T) {
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:            gang.PluginName,
+					EnabledJobOrder: &trueValue,
+					EnabledJobReady: &trueValue,
+				},
+				{
+					Name:             PluginName,
+					EnabledPredicate: &trueValue,
+					EnabledNodeOrder: &trueValue,
+					Arguments: framework.Arguments{
+						LabelsKey: []interface{}{spineLabel, leafLabel},
+					},
+				},
+			},
+		},
+	}
+	plugins := map[string]framework.PluginBuilder{
+		gang.PluginName: gang.New,
+		PluginName:      New,
+	}
+
+	buildPodGroup := func(maxTier string) *schedulingv1.PodGroup {
+		pg := util.BuildPodGroup("pg", "c1", "c1", 2, nil, schedulingv1.PodGroupInqueue)
+		if maxTier != "" {
+			pg.Annotations = map[string]string{MaxTierAnnotation: maxTier}
+		}
+		return pg
+	}
+	// The running pods take n1CPU of n1 and 2 CPUs of n3.
+	buildPods := func(n1CPU string) []*v1.Pod {
+		return []*v1.Pod{
+			util.BuildPod("c1", "r1", "n1", v1.PodRunning, api.BuildResourceList(n1CPU, "1Gi"), "pg-running", nil, nil),
+			util.BuildPod("c1", "r3", "n3", v1.PodRunning, api.BuildResourceList("2", "1Gi"), "pg-running", nil, nil),
+			util.BuildPod("c1", "p0", "", v1.PodPending, api.BuildResourceList("4", "1Gi"), "pg", nil, nil),
+			util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("2", "1Gi"), "pg", nil, nil),
+		}
+	}
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:           "the job is placed in a leaf",
+			PodGroups:      []*schedulingv1.PodGroup{util.BuildPodGroup("pg-running", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning), buildPodGroup("1")},
+			Pods:           buildPods("1"),
+			Nodes:          buildNodes(),
+			Queues:         []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap:  map[string]string{"c1/p0": "n2", "c1/p1": "n1"},
+			ExpectBindsNum: 2,
+		},
+		{
+			Name:      "the job is not placed when no leaf has enough resources",
+			PodGroups: []*schedulingv1.PodGroup{util.BuildPodGroup("pg-running", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning), buildPodGroup("1")},
+			Pods:      buildPods("3"),
+			Nodes:     buildNodes(),
+			Queues:    []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+		},
+		{
+			Name:           "the job is placed in a spine",
+			PodGroups:      []*schedulingv1.PodGroup{util.BuildPodGroup("pg-running", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning), buildPodGroup("2")},
+			Pods:           buildPods("3"),
+			Nodes:          buildNodes(),
+			Queues:         []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap:  map[string]string{"c1/p0": "n2", "c1/p1": "n3"},
+			ExpectBindsNum: 2,
+		},
+		{
+			Name:           "the job prefers the smallest domain without max tier",
+			PodGroups:      []*schedulingv1.PodGroup{util.BuildPodGroup("pg-running", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning), buildPodGroup("")},
+			Pods:           buildPods("3"),
+			Nodes:          buildNodes(),
+			Queues:         []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap:  map[string]string{"c1/p0": "n2", "c1/p1": "n3"},
+			ExpectBindsNum: 2,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = plugins
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{allocate.New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -62,6 +62,18 @@ const (
 	PodTopologySpreadWeight = "podtopologyspread.weight"
 )
 
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	NodeAffinityWeight:      framework.IntArgument,
+	PodAffinityWeight:       framework.IntArgument,
+	LeastRequestedWeight:    framework.IntArgument,
+	BalancedResourceWeight:  framework.IntArgument,
+	MostRequestedWeight:     framework.IntArgument,
+	TaintTolerationWeight:   framework.IntArgument,
+	ImageLocalityWeight:     framework.IntArgument,
+	PodTopologySpreadWeight: framework.IntArgument,
+}
+
 type nodeOrderPlugin struct {
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
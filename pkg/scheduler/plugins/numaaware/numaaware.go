@@ -35,6 +35,8 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
 	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/provider/cpumanager"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/provider/devicemanager"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/provider/memorymanager"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
 )
 
@@ -45,13 +47,20 @@ const (
 	NumaTopoWeight = "weight"
 )
 
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	NumaTopoWeight: framework.IntArgument,
+}
+
 type numaPlugin struct {
 	sync.Mutex
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
 	hintProviders   []policy.HintProvider
-	assignRes       map[api.TaskID]map[string]api.ResNumaSets // map[taskUID]map[nodename][resourceName]cpuset.CPUSet
-	nodeResSets     map[string]api.ResNumaSets                // map[nodename][resourceName]cpuset.CPUSet
+	assignRes       map[api.TaskID]map[string]api.ResNumaSets    // map[taskUID]map[nodename][resourceName]cpuset.CPUSet
+	nodeResSets     map[string]api.ResNumaSets                   // map[nodename][resourceName]cpuset.CPUSet
+	assignAmounts   map[api.TaskID]map[string]api.ResNumaAmounts // map[taskUID]map[nodename][resourceName][numaID]amount
+	nodeResAmounts  map[string]api.ResNumaAmounts                // map[nodename][resourceName][numaID]amount
 	taskBindNodeMap map[api.TaskID]string
 }
 
@@ -60,10 +69,15 @@ func New(arguments framework.Arguments) framework.Plugin {
 	plugin := &numaPlugin{
 		pluginArguments: arguments,
 		assignRes:       make(map[api.TaskID]map[string]api.ResNumaSets),
+		assignAmounts:   make(map[api.TaskID]map[string]api.ResNumaAmounts),
 		taskBindNodeMap: make(map[api.TaskID]string),
 	}
 
-	plugin.hintProviders = append(plugin.hintProviders, cpumanager.NewProvider())
+	plugin.hintProviders = append(plugin.hintProviders,
+		cpumanager.NewProvider(),
+		memorymanager.NewProvider(),
+		devicemanager.NewProvider(),
+	)
 	return plugin
 }
 
@@ -81,6 +95,7 @@ func (pp *numaPlugin) OnSessionOpen(ssn *framework.Session) {
 	weight := calculateWeight(pp.pluginArguments)
 	numaNodes := api.GenerateNumaNodes(ssn.Nodes)
 	pp.nodeResSets = api.GenerateNodeResNumaSets(ssn.Nodes)
+	pp.nodeResAmounts = api.GenerateNodeResNumaAmounts(ssn.Nodes)
 
 	ssn.AddEventHandler(&framework.EventHandler{
 		AllocateFunc: func(event *framework.Event) {
@@ -95,6 +110,15 @@ func (pp *numaPlugin) OnSessionOpen(ssn *framework.Session) {
 			}
 
 			node.Allocate(resNumaSets)
+			if resNumaAmounts, found := pp.assignAmounts[event.Task.UID][event.Task.NodeName]; found {
+				pp.nodeResAmounts[event.Task.NodeName].Allocate(resNumaAmounts)
+				// the amounts are recorded in the resource decision of the task, so that the node accounts
+				// for them when the task is added to or removed from it
+				if event.Task.NumaInfo == nil {
+					event.Task.NumaInfo = &api.TopologyInfo{}
+				}
+				event.Task.NumaInfo.ResMap = resNumaAmounts.ResMap()
+			}
 			pp.taskBindNodeMap[event.Task.UID] = event.Task.NodeName
 		},
 		DeallocateFunc: func(event *framework.Event) {
@@ -110,6 +134,12 @@ func (pp *numaPlugin) OnSessionOpen(ssn *framework.Session) {
 
 			delete(pp.taskBindNodeMap, event.Task.UID)
 			node.Release(resNumaSets)
+			if resNumaAmounts, found := pp.assignAmounts[event.Task.UID][event.Task.NodeName]; found {
+				pp.nodeResAmounts[event.Task.NodeName].Release(resNumaAmounts)
+				if event.Task.NumaInfo != nil {
+					event.Task.NumaInfo.ResMap = make(map[int]v1.ResourceList)
+				}
+			}
 		},
 	})
 
@@ -129,11 +159,13 @@ func (pp *numaPlugin) OnSessionOpen(ssn *framework.Session) {
 		}
 
 		resNumaSets := pp.nodeResSets[node.Name].Clone()
+		resNumaAmounts := pp.nodeResAmounts[node.Name].Clone()
 
 		taskPolicy := policy.GetPolicy(node, numaNodes[node.Name])
 		allResAssignMap := make(map[string]cpuset.CPUSet)
+		allResAssignAmounts := make(api.ResNumaAmounts)
 		for _, container := range task.Pod.Spec.Containers {
-			providersHints := policy.AccumulateProvidersHints(&container, node.NumaSchedulerInfo, resNumaSets, pp.hintProviders)
+			providersHints := policy.AccumulateProvidersHints(&container, node.NumaSchedulerInfo, resNumaSets, resNumaAmounts, pp.hintProviders)
 			hit, admit := taskPolicy.Predicate(providersHints)
 			if !admit {
 				numaStatus.Code = api.UnschedulableAndUnresolvable
@@ -145,11 +177,13 @@ func (pp *numaPlugin) OnSessionOpen(ssn *framework.Session) {
 
 			klog.V(4).Infof("[numaaware] hits for task %s container '%v': %v on node %s, besthit: %v",
 				task.Name, container.Name, providersHints, node.Name, hit)
-			resAssignMap := policy.Allocate(&container, &hit, node.NumaSchedulerInfo, resNumaSets, pp.hintProviders)
+			resAssignMap, resAssignAmounts := policy.Allocate(&container, &hit, node.NumaSchedulerInfo, resNumaSets, resNumaAmounts, pp.hintProviders)
 			for resName, assign := range resAssignMap {
 				allResAssignMap[resName] = allResAssignMap[resName].Union(assign)
 				resNumaSets[resName] = resNumaSets[resName].Difference(assign)
 			}
+			allResAssignAmounts.Add(resAssignAmounts)
+			resNumaAmounts.Allocate(resAssignAmounts)
 		}
 
 		pp.Lock()
@@ -160,13 +194,23 @@ func (pp *numaPlugin) OnSessionOpen(ssn *framework.Session) {
 
 		pp.assignRes[task.UID][node.Name] = allResAssignMap
 
-		klog.V(4).Infof(" task %s's on node<%s> resAssignMap: %v",
-			task.Name, node.Name, pp.assignRes[task.UID][node.Name])
+		if _, ok := pp.assignAmounts[task.UID]; !ok {
+			pp.assignAmounts[task.UID] = make(map[string]api.ResNumaAmounts)
+		}
+
+		pp.assignAmounts[task.UID][node.Name] = allResAssignAmounts
+
+		klog.V(4).Infof(" task %s's on node<%s> resAssignMap: %v, resAssignAmounts: %v",
+			task.Name, node.Name, pp.assignRes[task.UID][node.Name], pp.assignAmounts[task.UID][node.Name])
 
 		return nil
 	}
 
 	ssn.AddPredicateFn(pp.Name(), predicateFn)
+	// The predicate assigns the numa resources of the node to the Guaranteed task itself.
+	ssn.AddEquivalentFn(pp.Name(), func(task *api.TaskInfo) bool {
+		return v1qos.GetPodQOS(task.Pod) != v1.PodQOSGuaranteed
+	})
 
 	batchNodeOrderFn := func(task *api.TaskInfo, nodeInfo []*api.NodeInfo) (map[string]float64, error) {
 		nodeScores := make(map[string]float64, len(nodeInfo))
@@ -178,7 +222,7 @@ func (pp *numaPlugin) OnSessionOpen(ssn *framework.Session) {
 			return nodeScores, nil
 		}
 
-		scoreList := getNodeNumaNumForTask(nodeInfo, pp.assignRes[task.UID])
+		scoreList := getNodeNumaNumForTask(nodeInfo, pp.assignRes[task.UID], pp.assignAmounts[task.UID])
 		util.NormalizeScore(api.DefaultMaxNodeScore, true, scoreList)
 
 		for idx, scoreNode := range scoreList {
@@ -235,21 +279,23 @@ func filterNodeByPolicy(task *api.TaskInfo, node *api.NodeInfo, nodeResSets map[
 	return true, nil
 }
 
-func getNodeNumaNumForTask(nodeInfo []*api.NodeInfo, resAssignMap map[string]api.ResNumaSets) []api.ScoredNode {
+func getNodeNumaNumForTask(nodeInfo []*api.NodeInfo, resAssignMap map[string]api.ResNumaSets,
+	resAssignAmounts map[string]api.ResNumaAmounts) []api.ScoredNode {
 	nodeNumaCnts := make([]api.ScoredNode, len(nodeInfo))
 	workqueue.ParallelizeUntil(context.TODO(), 16, len(nodeInfo), func(index int) {
 		node := nodeInfo[index]
 		assignCpus := resAssignMap[node.Name][string(v1.ResourceCPU)]
 		nodeNumaCnts[index] = api.ScoredNode{
 			NodeName: node.Name,
-			Score:    int64(getNumaNodeCntForCPUID(assignCpus, node.NumaSchedulerInfo.CPUDetail)),
+			Score:    int64(getNumaNodeCnt(assignCpus, node.NumaSchedulerInfo.CPUDetail, resAssignAmounts[node.Name])),
 		}
 	})
 
 	return nodeNumaCnts
 }
 
-func getNumaNodeCntForCPUID(cpus cpuset.CPUSet, cpuDetails topology.CPUDetails) int {
+// getNumaNodeCnt return the number of the numa nodes of the assigned cpus and resource amounts
+func getNumaNodeCnt(cpus cpuset.CPUSet, cpuDetails topology.CPUDetails, resAmounts api.ResNumaAmounts) int {
 	mask, _ := bitmask.NewBitMask()
 	s := cpus.List()
 
@@ -257,6 +303,14 @@ func getNumaNodeCntForCPUID(cpus cpuset.CPUSet, cpuDetails topology.CPUDetails)
 		mask.Add(cpuDetails[cpuID].NUMANodeID)
 	}
 
+	for _, amounts := range resAmounts {
+		for numaID, amount := range amounts {
+			if amount > 0 {
+				mask.Add(numaID)
+			}
+		}
+	}
+
 	return mask.Count()
 }
 
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package numaaware
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodeinfov1alpha1 "volcano.sh/apis/pkg/apis/nodeinfo/v1alpha1"
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func buildNumatopo(name string) *nodeinfov1alpha1.Numatopology {
+	cpuDetail := map[string]nodeinfov1alpha1.CPUInfo{}
+	for cpu := 0; cpu < 8; cpu++ {
+		cpuDetail[fmt.Sprint(cpu)] = nodeinfov1alpha1.CPUInfo{NUMANodeID: cpu / 4, SocketID: cpu / 4, CoreID: cpu}
+	}
+	return &nodeinfov1alpha1.Numatopology{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: nodeinfov1alpha1.NumatopoSpec{
+			Policies: map[nodeinfov1alpha1.PolicyName]string{
+				nodeinfov1alpha1.CPUManagerPolicy:      "static",
+				nodeinfov1alpha1.TopologyManagerPolicy: "single-numa-node",
+			},
+			NumaResMap: map[string]nodeinfov1alpha1.ResourceInfo{
+				string(v1.ResourceCPU): {Allocatable: "0-7", Capacity: 8},
+			},
+			CPUDetail: cpuDetail,
+		},
+	}
+}
+
+func TestNumaAwareReplicas(t *testing.T) {
+	plugin := New(framework.Arguments{}).(*numaPlugin)
+	framework.RegisterPluginBuilder(PluginName, func(framework.Arguments) framework.Plugin {
+		return plugin
+	})
+	defer framework.CleanupPluginBuilders()
+
+	scherCache := cache.NewDefaultMockSchedulerCache("test-scheduler")
+	for _, name := range []string{"n1", "n2"} {
+		scherCache.AddOrUpdateNode(util.BuildNode(name, api.BuildResourceList("8", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+		scherCache.AddNumaInfoV1alpha1(buildNumatopo(name))
+	}
+	scherCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1.PodGroupInqueue))
+	// two replicas of the same Guaranteed template, each fitting in a numa node
+	for _, name := range []string{"p1", "p2"} {
+		pod := util.BuildPod("c1", name, "", v1.PodPending, api.BuildResourceList("4", "1Gi"), "pg1", nil, nil)
+		pod.Spec.Containers[0].Resources.Limits = pod.Spec.Containers[0].Resources.Requests
+		pod.Annotations[schedulingv1.NumaPolicyKey] = "single-numa-node"
+		scherCache.AddPod(pod)
+	}
+
+	enabled := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{Name: PluginName, EnabledPredicate: &enabled, EnabledNodeOrder: &enabled}}}}
+	ssn := framework.OpenSession(scherCache, tiers, nil)
+	defer framework.CloseSession(ssn)
+
+	tasks := map[string]*api.TaskInfo{}
+	for _, task := range ssn.Jobs["c1/pg1"].Tasks {
+		tasks[task.Name] = task
+		for _, node := range []string{"n1", "n2"} {
+			assert.NoError(t, ssn.PredicateFn(task, ssn.Nodes[node]))
+		}
+	}
+
+	// the predicate assigns the cpus of every node to every replica, so each replica gets its own cpus
+	stmt := framework.NewStatement(ssn)
+	assert.NoError(t, stmt.Allocate(tasks["p1"], ssn.Nodes["n1"]))
+	assert.NoError(t, stmt.Allocate(tasks["p2"], ssn.Nodes["n2"]))
+	for name, node := range map[string]string{"p1": "n1", "p2": "n2"} {
+		task := tasks[name]
+		assert.Equal(t, node, plugin.taskBindNodeMap[task.UID], "task %s", name)
+		assert.Equal(t, 4, plugin.assignRes[task.UID][node][string(v1.ResourceCPU)].Size(), "task %s", name)
+		assert.Equal(t, 4, plugin.nodeResSets[node][string(v1.ResourceCPU)].Size(), "node %s", node)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// AmountFilter returns whether the resource is managed by the hint provider
+type AmountFilter func(resName v1.ResourceName) bool
+
+// requestedAmounts return the request of the container of the resources managed by the hint provider and
+// reported by numa node on the node
+func requestedAmounts(container *v1.Container, topoInfo *api.NumatopoInfo, managed AmountFilter) map[string]float64 {
+	requests := make(map[string]float64)
+	for resName, quantity := range container.Resources.Requests {
+		if !managed(resName) {
+			continue
+		}
+
+		resInfo, ok := topoInfo.NumaResMap[string(resName)]
+		if !ok || len(resInfo.AllocatablePerNuma) == 0 {
+			continue
+		}
+
+		request := api.ResQuantity2Float64(resName, quantity)
+		if request <= 0 {
+			continue
+		}
+		requests[string(resName)] = request
+	}
+
+	return requests
+}
+
+// sortedNumaNodes return the numa IDs of the allocatable amounts in order
+func sortedNumaNodes(allocatable map[int]float64) []int {
+	numaNodes := make([]int, 0, len(allocatable))
+	for numaID := range allocatable {
+		numaNodes = append(numaNodes, numaID)
+	}
+	sort.Ints(numaNodes)
+	return numaNodes
+}
+
+// GetAmountTopologyHints return the numa topology hints of the resources allocated by quantity, which are
+// managed by the hint provider and requested by the container, like the memory manager of kubelet does.
+func GetAmountTopologyHints(container *v1.Container, topoInfo *api.NumatopoInfo,
+	resNumaAmounts api.ResNumaAmounts, managed AmountFilter) map[string][]TopologyHint {
+	requests := requestedAmounts(container, topoInfo, managed)
+	if len(requests) == 0 {
+		return nil
+	}
+
+	hints := make(map[string][]TopologyHint, len(requests))
+	for resName, request := range requests {
+		allocatable := topoInfo.NumaResMap[resName].AllocatablePerNuma
+		hints[resName] = generateAmountTopologyHints(allocatable, resNumaAmounts[resName], request)
+	}
+
+	return hints
+}
+
+// generateAmountTopologyHints return the numa topology hints based on
+// - the allocatable amounts, the hint is preferred if its numa nodes are the fewest able to fit the request
+// - the idle amounts, the hint is given if its numa nodes have enough idle amount to fit the request
+func generateAmountTopologyHints(allocatable, idle map[int]float64, request float64) []TopologyHint {
+	numaNodes := sortedNumaNodes(allocatable)
+	minAffinitySize := len(numaNodes)
+	hints := []TopologyHint{}
+	bitmask.IterateBitMasks(numaNodes, func(mask bitmask.BitMask) {
+		totalAllocatable := float64(0)
+		totalIdle := float64(0)
+		for _, numaID := range mask.GetBits() {
+			totalAllocatable += allocatable[numaID]
+			totalIdle += idle[numaID]
+		}
+
+		if totalAllocatable >= request && mask.Count() < minAffinitySize {
+			minAffinitySize = mask.Count()
+		}
+
+		if totalIdle < request {
+			return
+		}
+
+		hints = append(hints, TopologyHint{
+			NUMANodeAffinity: mask,
+			Preferred:        false,
+		})
+	})
+
+	for i := range hints {
+		if hints[i].NUMANodeAffinity.Count() == minAffinitySize {
+			hints[i].Preferred = true
+		}
+	}
+
+	return hints
+}
+
+// AllocateAmounts return the amounts of the resources allocated by quantity, which are managed by the hint provider
+// and requested by the container, assigned on the numa nodes of the best hit first and then on the others.
+func AllocateAmounts(container *v1.Container, bestHit *TopologyHint, topoInfo *api.NumatopoInfo,
+	resNumaAmounts api.ResNumaAmounts, managed AmountFilter) api.ResNumaAmounts {
+	requests := requestedAmounts(container, topoInfo, managed)
+	if len(requests) == 0 {
+		return nil
+	}
+
+	assigned := make(api.ResNumaAmounts, len(requests))
+	for resName, request := range requests {
+		numaNodes := sortedNumaNodes(topoInfo.NumaResMap[resName].AllocatablePerNuma)
+		idle := resNumaAmounts[resName]
+		amounts := make(map[int]float64)
+		remaining := request
+
+		take := func(numaID int) {
+			if remaining <= 0 || idle[numaID] <= amounts[numaID] {
+				return
+			}
+			amount := idle[numaID] - amounts[numaID]
+			if amount > remaining {
+				amount = remaining
+			}
+			amounts[numaID] += amount
+			remaining -= amount
+		}
+
+		if bestHit != nil && bestHit.NUMANodeAffinity != nil {
+			for _, numaID := range bestHit.NUMANodeAffinity.GetBits() {
+				take(numaID)
+			}
+		}
+		// Get any remaining amount from what's leftover after attempting to take the aligned ones.
+		for _, numaID := range numaNodes {
+			take(numaID)
+		}
+
+		assigned[resName] = amounts
+	}
+
+	return assigned
+}
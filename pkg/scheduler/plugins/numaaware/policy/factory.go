@@ -48,8 +48,10 @@ type HintProvider interface {
 	// Name returns provider name used for register and logging.
 	Name() string
 	// GetTopologyHints returns hints if this hint provider has a preference,
-	GetTopologyHints(container *v1.Container, topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string][]TopologyHint
-	Allocate(container *v1.Container, bestHit *TopologyHint, topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string]cpuset.CPUSet
+	// the idle resources allocated by id are given by resNumaSets and those allocated by quantity by resNumaAmounts.
+	GetTopologyHints(container *v1.Container, topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts) map[string][]TopologyHint
+	// Allocate returns the resources allocated by id and the resource amounts allocated by quantity to the container.
+	Allocate(container *v1.Container, bestHit *TopologyHint, topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts) (map[string]cpuset.CPUSet, api.ResNumaAmounts)
 }
 
 // GetPolicy return the interface matched the input task topology config
@@ -70,10 +72,10 @@ func GetPolicy(node *api.NodeInfo, numaNodes []int) Policy {
 
 // AccumulateProvidersHints return all TopologyHint collection from different providers
 func AccumulateProvidersHints(container *v1.Container,
-	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts,
 	hintProviders []HintProvider) (providersHints []map[string][]TopologyHint) {
 	for _, provider := range hintProviders {
-		hints := provider.GetTopologyHints(container, topoInfo, resNumaSets)
+		hints := provider.GetTopologyHints(container, topoInfo, resNumaSets, resNumaAmounts)
 		providersHints = append(providersHints, hints)
 	}
 
@@ -82,14 +84,17 @@ func AccumulateProvidersHints(container *v1.Container,
 
 // Allocate return all resource assignment collection from different providers
 func Allocate(container *v1.Container, bestHit *TopologyHint,
-	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, hintProviders []HintProvider) map[string]cpuset.CPUSet {
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts,
+	hintProviders []HintProvider) (map[string]cpuset.CPUSet, api.ResNumaAmounts) {
 	allResAlloc := make(map[string]cpuset.CPUSet)
+	allResAmounts := make(api.ResNumaAmounts)
 	for _, provider := range hintProviders {
-		resAlloc := provider.Allocate(container, bestHit, topoInfo, resNumaSets)
+		resAlloc, resAmounts := provider.Allocate(container, bestHit, topoInfo, resNumaSets, resNumaAmounts)
 		for resName, assign := range resAlloc {
 			allResAlloc[resName] = assign
 		}
+		allResAmounts.Add(resAmounts)
 	}
 
-	return allResAlloc
+	return allResAlloc, allResAmounts
 }
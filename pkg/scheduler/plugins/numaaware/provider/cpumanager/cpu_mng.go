@@ -116,7 +116,7 @@ func getPhysicalCoresNum(CPUDetails topology.CPUDetails) int {
 }
 
 func (mng *cpuMng) GetTopologyHints(container *v1.Container,
-	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string][]policy.TopologyHint {
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts) map[string][]policy.TopologyHint {
 	if _, ok := container.Resources.Requests[v1.ResourceCPU]; !ok {
 		klog.Warningf("container %s has no cpu request", container.Name)
 		return nil
@@ -160,7 +160,7 @@ func (mng *cpuMng) GetTopologyHints(container *v1.Container,
 }
 
 func (mng *cpuMng) Allocate(container *v1.Container, bestHit *policy.TopologyHint,
-	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string]cpuset.CPUSet {
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts) (map[string]cpuset.CPUSet, api.ResNumaAmounts) {
 	cputopo := &topology.CPUTopology{
 		NumCPUs:    topoInfo.CPUDetail.CPUs().Size(),
 		NumCores:   getPhysicalCoresNum(topoInfo.CPUDetail),
@@ -201,7 +201,7 @@ func (mng *cpuMng) Allocate(container *v1.Container, bestHit *policy.TopologyHin
 		if err != nil {
 			return map[string]cpuset.CPUSet{
 				string(v1.ResourceCPU): cpuset.New(),
-			}
+			}, nil
 		}
 
 		result = result.Union(alignedCPUs)
@@ -212,12 +212,12 @@ func (mng *cpuMng) Allocate(container *v1.Container, bestHit *policy.TopologyHin
 	if err != nil {
 		return map[string]cpuset.CPUSet{
 			string(v1.ResourceCPU): cpuset.New(),
-		}
+		}, nil
 	}
 
 	result = result.Union(remainingCPUs)
 
 	return map[string]cpuset.CPUSet{
 		string(v1.ResourceCPU): result,
-	}
+	}, nil
 }
@@ -199,7 +199,7 @@ func Test_GetTopologyHints(t *testing.T) {
 
 	for _, testcase := range teseCases {
 		provider := NewProvider()
-		topologyHintmap := provider.GetTopologyHints(&testcase.container, &numaInfo, testcase.resNumaSets)
+		topologyHintmap := provider.GetTopologyHints(&testcase.container, &numaInfo, testcase.resNumaSets, nil)
 		if !(equality.Semantic.DeepEqual(topologyHintmap["cpu"], testcase.expect) ||
 			(len(topologyHintmap["cpu"]) == 0 && len(testcase.expect) == 0)) {
 			t.Errorf("%s failed. topologyHintmap = %v\n", testcase.name, topologyHintmap)
@@ -324,7 +324,7 @@ func Test_Allocate(t *testing.T) {
 
 	for _, testcase := range teseCases {
 		provider := NewProvider()
-		assignMap := provider.Allocate(&testcase.container, testcase.bestHit, &numaInfo, testcase.resNumaSets)
+		assignMap, _ := provider.Allocate(&testcase.container, testcase.bestHit, &numaInfo, testcase.resNumaSets, nil)
 		if !(equality.Semantic.DeepEqual(assignMap["cpu"], testcase.expect)) {
 			t.Errorf("%s failed.\n", testcase.name)
 		}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devicemanager
+
+import (
+	v1 "k8s.io/api/core/v1"
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
+	"k8s.io/utils/cpuset"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+type deviceMng struct {
+}
+
+// NewProvider return a new provider
+func NewProvider() policy.HintProvider {
+	return &deviceMng{}
+}
+
+// Name return the device manager name
+func (mng *deviceMng) Name() string {
+	return "deviceMng"
+}
+
+// isDeviceResource return whether the resource is managed by the device manager, the extended resources as the
+// gpus, npus, fpgas and rdma nics reported by the device plugins.
+func isDeviceResource(resName v1.ResourceName) bool {
+	return v1helper.IsExtendedResourceName(resName)
+}
+
+// GetTopologyHints return the numa topology hints of the devices requested by the container
+// based on their amounts reported by numa node in the numatopology.
+func (mng *deviceMng) GetTopologyHints(container *v1.Container,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts) map[string][]policy.TopologyHint {
+	return policy.GetAmountTopologyHints(container, topoInfo, resNumaAmounts, isDeviceResource)
+}
+
+// Allocate return the number of the devices assigned to the container by numa node.
+func (mng *deviceMng) Allocate(container *v1.Container, bestHit *policy.TopologyHint,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts) (map[string]cpuset.CPUSet, api.ResNumaAmounts) {
+	return nil, policy.AllocateAmounts(container, bestHit, topoInfo, resNumaAmounts, isDeviceResource)
+}
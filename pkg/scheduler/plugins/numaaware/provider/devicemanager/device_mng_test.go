@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devicemanager
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+var numaInfo = api.NumatopoInfo{
+	NumaResMap: map[string]*api.ResourceInfo{
+		"nvidia.com/gpu": {
+			AllocatablePerNuma: map[int]float64{0: 4, 1: 4},
+		},
+		"memory": {
+			AllocatablePerNuma: map[int]float64{0: 1024, 1: 1024},
+		},
+	},
+}
+
+func newMask(bits ...int) bitmask.BitMask {
+	mask, _ := bitmask.NewBitMask(bits...)
+	return mask
+}
+
+func Test_GetTopologyHints(t *testing.T) {
+	testCases := []struct {
+		name           string
+		container      v1.Container
+		resNumaAmounts api.ResNumaAmounts
+		expect         map[string][]policy.TopologyHint
+	}{
+		{
+			name: "gpus fit in the numa node with idle gpus",
+			container: v1.Container{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						"nvidia.com/gpu": resource.MustParse("2"),
+						"memory":         resource.MustParse("512"),
+					},
+				},
+			},
+			resNumaAmounts: api.ResNumaAmounts{
+				"nvidia.com/gpu": {0: 1, 1: 3},
+				"memory":         {0: 1024, 1: 1024},
+			},
+			expect: map[string][]policy.TopologyHint{
+				"nvidia.com/gpu": {
+					{NUMANodeAffinity: newMask(1), Preferred: true},
+					{NUMANodeAffinity: newMask(0, 1), Preferred: false},
+				},
+			},
+		},
+		{
+			name: "the device is not reported by numa node",
+			container: v1.Container{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						"example.com/fpga": resource.MustParse("1"),
+					},
+				},
+			},
+			expect: nil,
+		},
+	}
+
+	for _, testcase := range testCases {
+		provider := NewProvider()
+		hints := provider.GetTopologyHints(&testcase.container, &numaInfo, nil, testcase.resNumaAmounts)
+		if !equality.Semantic.DeepEqual(hints, testcase.expect) {
+			t.Errorf("%s failed, expect %v but got %v\n", testcase.name, testcase.expect, hints)
+		}
+	}
+}
+
+func Test_Allocate(t *testing.T) {
+	container := v1.Container{
+		Resources: v1.ResourceRequirements{
+			Requests: v1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("2"),
+			},
+		},
+	}
+	bestHit := &policy.TopologyHint{NUMANodeAffinity: newMask(1), Preferred: true}
+	resNumaAmounts := api.ResNumaAmounts{
+		"nvidia.com/gpu": {0: 4, 1: 3},
+	}
+	expect := api.ResNumaAmounts{
+		"nvidia.com/gpu": {1: 2},
+	}
+
+	_, amounts := NewProvider().Allocate(&container, bestHit, &numaInfo, nil, resNumaAmounts)
+	if !equality.Semantic.DeepEqual(amounts, expect) {
+		t.Errorf("expect %v but got %v\n", expect, amounts)
+	}
+}
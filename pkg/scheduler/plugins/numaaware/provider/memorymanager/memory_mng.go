@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	v1 "k8s.io/api/core/v1"
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
+	"k8s.io/utils/cpuset"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+type memoryMng struct {
+}
+
+// NewProvider return a new provider
+func NewProvider() policy.HintProvider {
+	return &memoryMng{}
+}
+
+// Name return the memory manager name
+func (mng *memoryMng) Name() string {
+	return "memoryMng"
+}
+
+// isMemoryResource return whether the resource is managed by the memory manager, the memory and the hugepages.
+func isMemoryResource(resName v1.ResourceName) bool {
+	return resName == v1.ResourceMemory || v1helper.IsHugePageResourceName(resName)
+}
+
+// GetTopologyHints return the numa topology hints of the memory and the hugepages requested by the container
+// based on their amounts reported by numa node in the numatopology.
+func (mng *memoryMng) GetTopologyHints(container *v1.Container,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts) map[string][]policy.TopologyHint {
+	return policy.GetAmountTopologyHints(container, topoInfo, resNumaAmounts, isMemoryResource)
+}
+
+// Allocate return the amounts of the memory and the hugepages assigned to the container by numa node.
+func (mng *memoryMng) Allocate(container *v1.Container, bestHit *policy.TopologyHint,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets, resNumaAmounts api.ResNumaAmounts) (map[string]cpuset.CPUSet, api.ResNumaAmounts) {
+	return nil, policy.AllocateAmounts(container, bestHit, topoInfo, resNumaAmounts, isMemoryResource)
+}
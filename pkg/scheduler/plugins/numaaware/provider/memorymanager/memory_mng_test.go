@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+const gi = 1024 * 1024 * 1024
+
+var numaInfo = api.NumatopoInfo{
+	NumaResMap: map[string]*api.ResourceInfo{
+		"memory": {
+			AllocatablePerNuma: map[int]float64{0: 8 * gi, 1: 8 * gi},
+		},
+		"hugepages-1Gi": {
+			AllocatablePerNuma: map[int]float64{0: 2 * gi, 1: 2 * gi},
+		},
+	},
+}
+
+func newMask(bits ...int) bitmask.BitMask {
+	mask, _ := bitmask.NewBitMask(bits...)
+	return mask
+}
+
+func Test_GetTopologyHints(t *testing.T) {
+	testCases := []struct {
+		name           string
+		container      v1.Container
+		resNumaAmounts api.ResNumaAmounts
+		expect         map[string][]policy.TopologyHint
+	}{
+		{
+			name: "memory fits in a single numa node",
+			container: v1.Container{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						"memory": resource.MustParse("4Gi"),
+					},
+				},
+			},
+			resNumaAmounts: api.ResNumaAmounts{
+				"memory": {0: 8 * gi, 1: 2 * gi},
+			},
+			expect: map[string][]policy.TopologyHint{
+				"memory": {
+					{NUMANodeAffinity: newMask(0), Preferred: true},
+					{NUMANodeAffinity: newMask(0, 1), Preferred: false},
+				},
+			},
+		},
+		{
+			name: "memory spans the numa nodes",
+			container: v1.Container{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						"memory": resource.MustParse("12Gi"),
+					},
+				},
+			},
+			resNumaAmounts: api.ResNumaAmounts{
+				"memory": {0: 8 * gi, 1: 8 * gi},
+			},
+			expect: map[string][]policy.TopologyHint{
+				"memory": {
+					{NUMANodeAffinity: newMask(0, 1), Preferred: true},
+				},
+			},
+		},
+		{
+			name: "hugepages have no idle numa node",
+			container: v1.Container{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						"hugepages-1Gi": resource.MustParse("2Gi"),
+					},
+				},
+			},
+			resNumaAmounts: api.ResNumaAmounts{
+				"hugepages-1Gi": {0: 1 * gi, 1: 0},
+			},
+			expect: map[string][]policy.TopologyHint{
+				"hugepages-1Gi": {},
+			},
+		},
+		{
+			name: "no memory request",
+			container: v1.Container{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						"cpu": resource.MustParse("2"),
+					},
+				},
+			},
+			resNumaAmounts: api.ResNumaAmounts{
+				"memory": {0: 8 * gi, 1: 8 * gi},
+			},
+			expect: nil,
+		},
+	}
+
+	for _, testcase := range testCases {
+		provider := NewProvider()
+		hints := provider.GetTopologyHints(&testcase.container, &numaInfo, nil, testcase.resNumaAmounts)
+		if !equality.Semantic.DeepEqual(hints, testcase.expect) {
+			t.Errorf("%s failed, expect %v but got %v\n", testcase.name, testcase.expect, hints)
+		}
+	}
+}
+
+func Test_Allocate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		container      v1.Container
+		bestHit        *policy.TopologyHint
+		resNumaAmounts api.ResNumaAmounts
+		expect         api.ResNumaAmounts
+	}{
+		{
+			name: "allocate on the numa node of the best hit",
+			container: v1.Container{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						"memory":        resource.MustParse("4Gi"),
+						"hugepages-1Gi": resource.MustParse("1Gi"),
+					},
+				},
+			},
+			bestHit: &policy.TopologyHint{NUMANodeAffinity: newMask(1), Preferred: true},
+			resNumaAmounts: api.ResNumaAmounts{
+				"memory":        {0: 8 * gi, 1: 8 * gi},
+				"hugepages-1Gi": {0: 2 * gi, 1: 2 * gi},
+			},
+			expect: api.ResNumaAmounts{
+				"memory":        {1: 4 * gi},
+				"hugepages-1Gi": {1: 1 * gi},
+			},
+		},
+		{
+			name: "allocate the remaining on the other numa nodes",
+			container: v1.Container{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						"memory": resource.MustParse("6Gi"),
+					},
+				},
+			},
+			bestHit: &policy.TopologyHint{NUMANodeAffinity: newMask(1), Preferred: false},
+			resNumaAmounts: api.ResNumaAmounts{
+				"memory": {0: 8 * gi, 1: 4 * gi},
+			},
+			expect: api.ResNumaAmounts{
+				"memory": {0: 2 * gi, 1: 4 * gi},
+			},
+		},
+	}
+
+	for _, testcase := range testCases {
+		provider := NewProvider()
+		_, amounts := provider.Allocate(&testcase.container, testcase.bestHit, &numaInfo, nil, testcase.resNumaAmounts)
+		if !equality.Semantic.DeepEqual(amounts, testcase.expect) {
+			t.Errorf("%s failed, expect %v but got %v\n", testcase.name, testcase.expect, amounts)
+		}
+	}
+}
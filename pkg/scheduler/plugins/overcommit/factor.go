@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overcommit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// OverCommitFactorAnnotation is the queue annotation overriding the overcommit factors for the jobs of the queue,
+	// either a factor for all the resources, e.g. "1.5", or the factors of the named resources,
+	// e.g. "cpu=1.0,nvidia.com/gpu=2.0", the other resources keeping the factors of the plugin
+	OverCommitFactorAnnotation = "volcano.sh/overcommit-factor"
+)
+
+// factors are the overcommit factors of the resources.
+type factors struct {
+	// defaultFactor is the factor of the resources without their own factor
+	defaultFactor float64
+	resources     map[v1.ResourceName]float64
+}
+
+func newFactors(defaultFactor float64) *factors {
+	return &factors{
+		defaultFactor: defaultFactor,
+		resources:     map[v1.ResourceName]float64{},
+	}
+}
+
+// get returns the factor of the resource.
+func (f *factors) get(name v1.ResourceName) float64 {
+	if factor, found := f.resources[name]; found {
+		return factor
+	}
+	return f.defaultFactor
+}
+
+// clone returns a copy of the factors.
+func (f *factors) clone() *factors {
+	c := newFactors(f.defaultFactor)
+	for name, factor := range f.resources {
+		c.resources[name] = factor
+	}
+	return c
+}
+
+// capacity returns the total resource overcommitted by the factors.
+func (f *factors) capacity(total *api.Resource) *api.Resource {
+	capacity := total.Clone()
+	capacity.MilliCPU *= f.get(v1.ResourceCPU)
+	capacity.Memory *= f.get(v1.ResourceMemory)
+	for name, quantity := range capacity.ScalarResources {
+		capacity.ScalarResources[name] = quantity * f.get(name)
+	}
+	return capacity
+}
+
+// parseFactor parses an overcommit factor, which cannot be less than 1.
+func parseFactor(value string) (float64, error) {
+	factor, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, err
+	}
+	if factor < 1.0 {
+		return 0, fmt.Errorf("overcommit factor %v cannot be less than 1", factor)
+	}
+	return factor, nil
+}
+
+// queueFactors returns the overcommit factors of the queue, the factors of the plugin overridden by the annotation
+// of the queue.
+func queueFactors(queue *api.QueueInfo, pluginFactors *factors) *factors {
+	if queue == nil || queue.Queue == nil {
+		return pluginFactors
+	}
+	value, found := queue.Queue.Annotations[OverCommitFactorAnnotation]
+	if !found {
+		return pluginFactors
+	}
+
+	if !strings.Contains(value, "=") {
+		factor, err := parseFactor(value)
+		if err != nil {
+			klog.Warningf("Invalid annotation %s %q of queue <%s>: %v", OverCommitFactorAnnotation, value, queue.Name, err)
+			return pluginFactors
+		}
+		return newFactors(factor)
+	}
+
+	f := pluginFactors.clone()
+	for _, item := range strings.Split(value, ",") {
+		name, quantity, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found || len(name) == 0 {
+			klog.Warningf("Invalid annotation %s %q of queue <%s>: %q is not a resource factor",
+				OverCommitFactorAnnotation, value, queue.Name, item)
+			return pluginFactors
+		}
+		factor, err := parseFactor(quantity)
+		if err != nil {
+			klog.Warningf("Invalid annotation %s %q of queue <%s>: %v", OverCommitFactorAnnotation, value, queue.Name, err)
+			return pluginFactors
+		}
+		f.resources[v1.ResourceName(name)] = factor
+	}
+	return f
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package overcommit
 
 import (
+	"fmt"
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
 )
 
@@ -33,17 +37,33 @@ const (
 	// It determines the number of `pending` pods that the scheduler will tolerate
 	// when the resources of the cluster is insufficient
 	overCommitFactor = "overcommit-factor"
+	// overCommitFactorPrefix is the prefix of the overCommit factors of the resources, e.g. "overcommit-factor.cpu",
+	// "overcommit-factor.memory" or "overcommit-factor.nvidia.com/gpu", the resources without their own factor take
+	// the overcommit-factor
+	overCommitFactorPrefix = overCommitFactor + "."
 	// defaultOverCommitFactor defines the default overCommit resource factor for enqueue action
 	defaultOverCommitFactor = 1.2
 )
 
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	overCommitFactor:             framework.FloatArgument,
+	overCommitFactorPrefix + "*": framework.FloatArgument,
+}
+
 type overcommitPlugin struct {
 	// Arguments given for the plugin
 	pluginArguments  framework.Arguments
 	totalResource    *api.Resource
-	idleResource     *api.Resource
+	usedResource     *api.Resource
 	inqueueResource  *api.Resource
 	overCommitFactor float64
+	// factors are the overcommit factors of the resources given by the arguments
+	factors *factors
+	// queueIdle are the idle resources of the queues, overcommitted by the factors of the queues
+	queueIdle map[api.QueueID]*api.Resource
+	// queueInqueue are the inqueue resources of the jobs of the queues
+	queueInqueue map[api.QueueID]*api.Resource
 }
 
 // New function returns overcommit plugin object
@@ -51,9 +71,11 @@ func New(arguments framework.Arguments) framework.Plugin {
 	return &overcommitPlugin{
 		pluginArguments:  arguments,
 		totalResource:    api.EmptyResource(),
-		idleResource:     api.EmptyResource(),
+		usedResource:     api.EmptyResource(),
 		inqueueResource:  api.EmptyResource(),
 		overCommitFactor: defaultOverCommitFactor,
+		queueIdle:        map[api.QueueID]*api.Resource{},
+		queueInqueue:     map[api.QueueID]*api.Resource{},
 	}
 }
 
@@ -61,6 +83,69 @@ func (op *overcommitPlugin) Name() string {
 	return PluginName
 }
 
+// parseFactors parses the overcommit factor and the overcommit factors of the resources from the arguments.
+func (op *overcommitPlugin) parseFactors() {
+	op.pluginArguments.GetFloat64(&op.overCommitFactor, overCommitFactor)
+	if op.overCommitFactor < 1.0 {
+		klog.Warningf("Invalid input %f for overcommit-factor, reason: overcommit-factor cannot be less than 1,"+
+			" using default value: %f.", op.overCommitFactor, defaultOverCommitFactor)
+		op.overCommitFactor = defaultOverCommitFactor
+	}
+
+	op.factors = newFactors(op.overCommitFactor)
+	for key := range op.pluginArguments {
+		if !strings.HasPrefix(key, overCommitFactorPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, overCommitFactorPrefix)
+		factor := op.overCommitFactor
+		op.pluginArguments.GetFloat64(&factor, key)
+		if factor < 1.0 {
+			klog.Warningf("Invalid input %f for %s, reason: overcommit factor cannot be less than 1,"+
+				" using overcommit-factor: %f.", factor, key, op.overCommitFactor)
+			continue
+		}
+		op.factors.resources[v1.ResourceName(name)] = factor
+	}
+}
+
+// idle returns the idle resources of the cluster overcommitted by the factors of the queue.
+func (op *overcommitPlugin) idle(ssn *framework.Session, queueID api.QueueID) *api.Resource {
+	if idle, found := op.queueIdle[queueID]; found {
+		return idle
+	}
+	idle := queueFactors(ssn.Queues[queueID], op.factors).capacity(op.totalResource).SubWithoutAssert(op.usedResource)
+	op.queueIdle[queueID] = idle
+	return idle
+}
+
+// addInqueue adds the inqueue resources of the job of the queue.
+func (op *overcommitPlugin) addInqueue(queueID api.QueueID, inqueue *api.Resource) {
+	op.inqueueResource.Add(inqueue)
+	if _, found := op.queueInqueue[queueID]; !found {
+		op.queueInqueue[queueID] = api.EmptyResource()
+	}
+	op.queueInqueue[queueID].Add(inqueue)
+}
+
+// updateQueueMetrics records the inqueue resources of the queue and the idle resources the jobs of the queue
+// may be inqueue with, which are compared with the inqueue resources of all the queues.
+func (op *overcommitPlugin) updateQueueMetrics(ssn *framework.Session, queueID api.QueueID) {
+	queue, found := ssn.Queues[queueID]
+	if !found || ssn.DryRun() {
+		return
+	}
+	idle := op.idle(ssn, queueID)
+	inqueue := op.queueInqueue[queueID]
+	if inqueue == nil {
+		inqueue = api.EmptyResource()
+	}
+	for _, name := range op.totalResource.ResourceNames() {
+		metrics.UpdateQueueOvercommit(queue.Name, string(name), inqueue.Get(name), idle.Get(name))
+		metrics.UpdateOvercommitInqueue(string(name), op.inqueueResource.Get(name))
+	}
+}
+
 /*
 User should give overcommit-factor through overcommit plugin arguments as format below:
 
@@ -70,32 +155,29 @@ tiers:
   - name: overcommit
     arguments:
     overcommit-factor: 1.0
+    overcommit-factor.nvidia.com/gpu: 2.0
+
+The overcommit-factor applies to the resources without their own factor. The factors can be overridden for the jobs
+of a queue by the queue annotation volcano.sh/overcommit-factor, e.g. "1.5" or "cpu=1.0,nvidia.com/gpu=3.0".
 */
 func (op *overcommitPlugin) OnSessionOpen(ssn *framework.Session) {
 	klog.V(5).Infof("Enter overcommit plugin ...")
 	defer klog.V(5).Infof("Leaving overcommit plugin.")
 
-	op.pluginArguments.GetFloat64(&op.overCommitFactor, overCommitFactor)
-	if op.overCommitFactor < 1.0 {
-		klog.Warningf("Invalid input %f for overcommit-factor, reason: overcommit-factor cannot be less than 1,"+
-			" using default value: %f.", op.overCommitFactor, defaultOverCommitFactor)
-		op.overCommitFactor = defaultOverCommitFactor
-	}
+	op.parseFactors()
 
 	op.totalResource.Add(ssn.TotalResource)
-	// calculate idle resources of total cluster, overcommit resources included
-	used := api.EmptyResource()
+	// calculate used resources of total cluster, the idle resources of the queues are overcommitted by their factors
 	for _, node := range ssn.Nodes {
-		used.Add(node.Used)
+		op.usedResource.Add(node.Used)
 	}
-	op.idleResource = op.totalResource.Clone().Multi(op.overCommitFactor).SubWithoutAssert(used)
 
 	for _, job := range ssn.Jobs {
 		// calculate inqueue job resources
 		if job.PodGroup.Status.Phase == scheduling.PodGroupInqueue && job.PodGroup.Spec.MinResources != nil {
 			// deduct the resources of scheduling gated tasks in a job when calculating inqueued resources
 			// so that it will not block other jobs from being inqueued.
-			op.inqueueResource.Add(job.DeductSchGatedResources(job.GetMinResources()))
+			op.addInqueue(job.Queue, job.DeductSchGatedResources(job.GetMinResources()))
 			continue
 		}
 		// calculate inqueue resource for running jobs
@@ -105,13 +187,17 @@ func (op *overcommitPlugin) OnSessionOpen(ssn *framework.Session) {
 			job.PodGroup.Spec.MinResources != nil &&
 			int32(util.CalculateAllocatedTaskNum(job)) >= job.PodGroup.Spec.MinMember {
 			inqueued := util.GetInqueueResource(job, job.Allocated)
-			op.inqueueResource.Add(job.DeductSchGatedResources(inqueued))
+			op.addInqueue(job.Queue, job.DeductSchGatedResources(inqueued))
 		}
 	}
 
+	for queueID := range ssn.Queues {
+		op.updateQueueMetrics(ssn, queueID)
+	}
+
 	ssn.AddJobEnqueueableFn(op.Name(), func(obj interface{}) int {
 		job := obj.(*api.JobInfo)
-		idle := op.idleResource
+		idle := op.idle(ssn, job.Queue)
 		inqueue := api.EmptyResource()
 		inqueue.Add(op.inqueueResource)
 		if job.PodGroup.Spec.MinResources == nil {
@@ -128,6 +214,8 @@ func (op *overcommitPlugin) OnSessionOpen(ssn *framework.Session) {
 		klog.V(4).Infof("Resource in cluster is overused, reject job <%s/%s> to be inqueue",
 			job.Namespace, job.Name)
 		ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeNormal, string(scheduling.PodGroupUnschedulableType), "resource in cluster is overused")
+		ssn.RecordVetoReason(op.Name(), fmt.Sprintf("resource in cluster is overused: overcommitted idle <%s>, inqueue <%s>, job min resources <%s>",
+			idle, op.inqueueResource, jobMinReq))
 		return util.Reject
 	})
 
@@ -137,12 +225,15 @@ func (op *overcommitPlugin) OnSessionOpen(ssn *framework.Session) {
 			return
 		}
 		jobMinReq := job.GetMinResources()
-		op.inqueueResource.Add(job.DeductSchGatedResources(jobMinReq))
+		op.addInqueue(job.Queue, job.DeductSchGatedResources(jobMinReq))
+		op.updateQueueMetrics(ssn, job.Queue)
 	})
 }
 
 func (op *overcommitPlugin) OnSessionClose(ssn *framework.Session) {
 	op.totalResource = nil
-	op.idleResource = nil
+	op.usedResource = nil
 	op.inqueueResource = nil
+	op.queueIdle = nil
+	op.queueInqueue = nil
 }
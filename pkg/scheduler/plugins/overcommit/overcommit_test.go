@@ -120,3 +120,100 @@ func TestOvercommitPlugin(t *testing.T) {
 	}
 
 }
+
+func TestOvercommitFactors(t *testing.T) {
+	gpu := []api.ScalarResource{{Name: "nvidia.com/gpu", Value: "2"}, {Name: "pods", Value: "10"}}
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", gpu...), make(map[string]string))
+	gpuResource := api.BuildResourceList("1", "1Gi", api.ScalarResource{Name: "nvidia.com/gpu", Value: "4"})
+
+	onlinePG := util.BuildPodGroup("pg1", "test-namespace", "online", 1, nil, schedulingv1.PodGroupPhase(scheduling.PodGroupPending))
+	onlinePG.Spec.MinResources = &gpuResource
+	batchPG := util.BuildPodGroup("pg1", "test-namespace", "batch", 1, nil, schedulingv1.PodGroupPhase(scheduling.PodGroupPending))
+	batchPG.Spec.MinResources = &gpuResource
+
+	online := util.BuildQueueWithAnnos("online", 1, nil, map[string]string{OverCommitFactorAnnotation: "1.0"})
+	batch := util.BuildQueueWithAnnos("batch", 1, nil, map[string]string{OverCommitFactorAnnotation: "nvidia.com/gpu=2.5"})
+	defaultQueue := util.BuildQueue("online", 1, nil)
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		arguments           framework.Arguments
+		expectedEnqueueAble bool
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "gpus beyond the overcommit-factor",
+				Plugins:   map[string]framework.PluginBuilder{PluginName: New},
+				PodGroups: []*schedulingv1.PodGroup{onlinePG},
+				Queues:    []*schedulingv1.Queue{defaultQueue},
+				Nodes:     []*v1.Node{n1},
+			},
+			arguments:           framework.Arguments{overCommitFactor: 1.2},
+			expectedEnqueueAble: false,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "gpus within the overcommit factor of the gpus",
+				Plugins:   map[string]framework.PluginBuilder{PluginName: New},
+				PodGroups: []*schedulingv1.PodGroup{onlinePG},
+				Queues:    []*schedulingv1.Queue{defaultQueue},
+				Nodes:     []*v1.Node{n1},
+			},
+			arguments: framework.Arguments{
+				overCommitFactor: 1.2,
+				overCommitFactorPrefix + "nvidia.com/gpu": 2.0,
+			},
+			expectedEnqueueAble: true,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the queue overrides the overcommit factors of the plugin",
+				Plugins:   map[string]framework.PluginBuilder{PluginName: New},
+				PodGroups: []*schedulingv1.PodGroup{onlinePG},
+				Queues:    []*schedulingv1.Queue{online},
+				Nodes:     []*v1.Node{n1},
+			},
+			arguments: framework.Arguments{
+				overCommitFactor: 1.2,
+				overCommitFactorPrefix + "nvidia.com/gpu": 2.0,
+			},
+			expectedEnqueueAble: false,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the queue overrides the overcommit factor of the gpus",
+				Plugins:   map[string]framework.PluginBuilder{PluginName: New},
+				PodGroups: []*schedulingv1.PodGroup{batchPG},
+				Queues:    []*schedulingv1.Queue{batch},
+				Nodes:     []*v1.Node{n1},
+			},
+			arguments:           framework.Arguments{overCommitFactor: 1.2},
+			expectedEnqueueAble: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			trueValue := true
+			tiers := []conf.Tier{
+				{
+					Plugins: []conf.PluginOption{
+						{
+							Name:               PluginName,
+							EnabledJobEnqueued: &trueValue,
+							Arguments:          test.arguments,
+						},
+					},
+				},
+			}
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+			for _, job := range ssn.Jobs {
+				isEnqueue := ssn.JobEnqueueable(job)
+				if !equality.Semantic.DeepEqual(test.expectedEnqueueAble, isEnqueue) {
+					t.Errorf("case: %s error,  expect %v, but get %v", test.Name, test.expectedEnqueueAble, isEnqueue)
+				}
+			}
+		})
+	}
+}
@@ -68,6 +68,10 @@ const (
 	// PodTopologySpreadEnable is the key for enabling Pod Topology Spread Predicates in scheduler configmap
 	PodTopologySpreadEnable = "predicate.PodTopologySpreadEnable"
 
+	// DynamicResourcesEnable is the key for enabling Dynamic Resources Predicates in scheduler configmap,
+	// the resource claims are only allocated when the DynamicResourceAllocation feature gate is enabled
+	DynamicResourcesEnable = "predicate.DynamicResourcesEnable"
+
 	// CachePredicate control cache predicate feature
 	CachePredicate = "predicate.CacheEnable"
 
@@ -77,8 +81,32 @@ const (
 	ProportionalResource = "predicate.resources"
 	// ProportionalResourcesPrefix is the key prefix for additional resource key name
 	ProportionalResourcesPrefix = ProportionalResource + "."
+
+	// dynamicResourcesName is the name of the Dynamic Resources Predicates in the predicate status
+	dynamicResourcesName = "DynamicResources"
 )
 
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	NodeAffinityEnable:      framework.BoolArgument,
+	NodePortsEnable:         framework.BoolArgument,
+	TaintTolerationEnable:   framework.BoolArgument,
+	PodAffinityEnable:       framework.BoolArgument,
+	NodeVolumeLimitsEnable:  framework.BoolArgument,
+	VolumeZoneEnable:        framework.BoolArgument,
+	PodTopologySpreadEnable: framework.BoolArgument,
+	DynamicResourcesEnable:  framework.BoolArgument,
+	CachePredicate:          framework.BoolArgument,
+	ProportionalPredicate:   framework.BoolArgument,
+	ProportionalResource:    framework.StringArgument,
+	// The GPU predicates moved to the deviceshare plugin, the arguments are still accepted but ignored,
+	// the configurations enabling them without the deviceshare argument they moved to are rejected.
+	"predicate.GPUSharingEnable": framework.BoolArgument,
+	"predicate.GPUNumberEnable":  framework.BoolArgument,
+	// predicate.resources.<resource>.cpu and predicate.resources.<resource>.memory
+	ProportionalResourcesPrefix + "*": framework.FloatArgument,
+}
+
 type predicatesPlugin struct {
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
@@ -106,6 +134,7 @@ type predicateEnable struct {
 	nodeVolumeLimitsEnable  bool
 	volumeZoneEnable        bool
 	podTopologySpreadEnable bool
+	dynamicResourcesEnable  bool
 	cacheEnable             bool
 	proportionalEnable      bool
 	proportional            map[v1.ResourceName]baseResource
@@ -113,8 +142,7 @@ type predicateEnable struct {
 
 func enablePredicate(args framework.Arguments) predicateEnable {
 	/*
-	   User Should give predicatesEnable in this format(predicate.GPUSharingEnable).
-	   Currently supported only GPUSharing predicate checks.
+	   User Should give predicatesEnable in this format(predicate.NodeAffinityEnable).
 
 	   actions: "reclaim, allocate, backfill, preempt"
 	   tiers:
@@ -133,8 +161,7 @@ func enablePredicate(args framework.Arguments) predicateEnable {
 	         predicate.NodeVolumeLimitsEnable: true
 	         predicate.VolumeZoneEnable: true
 	         predicate.PodTopologySpreadEnable: true
-	         predicate.GPUSharingEnable: true
-	         predicate.GPUNumberEnable: true
+	         predicate.DynamicResourcesEnable: true
 	         predicate.CacheEnable: true
 	         predicate.ProportionalEnable: true
 	         predicate.resources: nvidia.com/gpu
@@ -152,6 +179,7 @@ func enablePredicate(args framework.Arguments) predicateEnable {
 		nodeVolumeLimitsEnable:  true,
 		volumeZoneEnable:        true,
 		podTopologySpreadEnable: true,
+		dynamicResourcesEnable:  true,
 		cacheEnable:             false,
 		proportionalEnable:      false,
 	}
@@ -165,6 +193,7 @@ func enablePredicate(args framework.Arguments) predicateEnable {
 	args.GetBool(&predicate.nodeVolumeLimitsEnable, NodeVolumeLimitsEnable)
 	args.GetBool(&predicate.volumeZoneEnable, VolumeZoneEnable)
 	args.GetBool(&predicate.podTopologySpreadEnable, PodTopologySpreadEnable)
+	args.GetBool(&predicate.dynamicResourcesEnable, DynamicResourcesEnable)
 
 	args.GetBool(&predicate.cacheEnable, CachePredicate)
 	// Checks whether predicate.ProportionalEnable is provided or not, if given, modifies the value in predicateEnable struct.
@@ -211,6 +240,16 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 	pCache := predicateCacheNew()
 	predicate := enablePredicate(pp.pluginArguments)
 
+	// The node selector and the taints are checked with the node index of the session before the predicates run.
+	var indexFilters api.NodeIndexFilter
+	if predicate.nodeAffinityEnable {
+		indexFilters |= api.NodeSelectorFilter
+	}
+	if predicate.taintTolerationEnable {
+		indexFilters |= api.TaintFilter
+	}
+	ssn.AddNodeIndexFilter(pp.Name(), indexFilters)
+
 	// Register event handlers to update task info in PodLister & nodeMap
 	ssn.AddEventHandler(&framework.EventHandler{
 		AllocateFunc: func(event *framework.Event) {
@@ -222,27 +261,6 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 				klog.Errorf("predicates, update pod %s/%s allocate to NOT EXIST node [%s]", pod.Namespace, pod.Name, nodeName)
 				return
 			}
-			nodeInfo, ok := ssn.Nodes[nodeName]
-			if !ok {
-				klog.Errorf("Failed to get node %s info from cache", nodeName)
-				return
-			}
-			//predicate gpu sharing
-			for _, val := range api.RegisteredDevices {
-				if devices, ok := nodeInfo.Others[val].(api.Devices); ok {
-					if !devices.HasDeviceRequest(pod) {
-						continue
-					}
-
-					err := devices.Allocate(ssn.KubeClient(), pod)
-					if err != nil {
-						klog.Errorf("AllocateToPod failed %s", err.Error())
-						return
-					}
-				} else {
-					klog.Warningf("Devices %s assertion conversion failed, skip", val)
-				}
-			}
 			node.AddPod(pod)
 			klog.V(4).Infof("predicates, update pod %s/%s allocate to node [%s]", pod.Namespace, pod.Name, nodeName)
 		},
@@ -256,29 +274,6 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 				return
 			}
 
-			nodeInfo, ok := ssn.Nodes[nodeName]
-			if !ok {
-				klog.Errorf("Failed to get node %s info from cache", nodeName)
-				return
-			}
-
-			for _, val := range api.RegisteredDevices {
-				if devices, ok := nodeInfo.Others[val].(api.Devices); ok {
-					if !devices.HasDeviceRequest(pod) {
-						continue
-					}
-
-					// deallocate pod gpu id
-					err := devices.Release(ssn.KubeClient(), pod)
-					if err != nil {
-						klog.Errorf("Device %s release failed for pod %s/%s, err:%s", val, pod.Namespace, pod.Name, err.Error())
-						return
-					}
-				} else {
-					klog.Warningf("Devices %s assertion conversion failed, skip", val)
-				}
-			}
-
 			err := node.RemovePod(klog.FromContext(context.TODO()), pod)
 			if err != nil {
 				klog.Errorf("predicates, remove pod %s/%s from node [%s] error: %v", pod.Namespace, pod.Name, nodeName, err)
@@ -547,6 +542,18 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 			}
 		}
 
+		// Check DynamicResources, the resource claims of the task must be allocatable on the node
+		if predicate.dynamicResourcesEnable && len(task.Pod.Spec.ResourceClaims) > 0 {
+			if _, err := ssn.GetPodClaims(task, node); err != nil {
+				dynamicResourcesStatus := &api.Status{
+					Code:   api.Unschedulable,
+					Reason: err.Error(),
+					Plugin: dynamicResourcesName,
+				}
+				predicateStatus = append(predicateStatus, dynamicResourcesStatus)
+			}
+		}
+
 		if predicate.proportionalEnable {
 			// Check ProportionalPredicate
 			proportionalStatus, _ := checkNodeResourceIsProportional(task, node, predicate.proportional)
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemptionpolicy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// ReclaimRateLimitAnnotation is the queue annotation of the resources the queue may reclaim from the other
+	// queues per rate window, e.g. "cpu=10,nvidia.com/gpu=2"
+	ReclaimRateLimitAnnotation = "volcano.sh/reclaim-rate-limit"
+	// VictimQueuesAnnotation is the queue annotation of the comma separated queues the queue may reclaim from,
+	// all the reclaimable queues when unset
+	VictimQueuesAnnotation = "volcano.sh/reclaim-victim-queues"
+	// MinRuntimeAnnotation is the queue annotation of the time the pods of the queue must have run before
+	// being preempted or reclaimed, e.g. "30m"
+	MinRuntimeAnnotation = "volcano.sh/preemption-min-runtime"
+	// DisruptionBudgetAnnotation is the queue annotation of the maximum number of pods of the queue being
+	// evicted at the same time
+	DisruptionBudgetAnnotation = "volcano.sh/disruption-budget"
+)
+
+// queuePolicy is the preemption policy of a queue read from its annotations.
+type queuePolicy struct {
+	// rateLimit is nil if the queue may reclaim without limit.
+	rateLimit *api.Resource
+	// victimQueues is nil if the queue may reclaim from all the queues.
+	victimQueues sets.Set[string]
+	minRuntime   time.Duration
+	// budget is negative if the pods of the queue may be evicted without limit.
+	budget int
+}
+
+func newQueuePolicy(queue *api.QueueInfo) *queuePolicy {
+	policy := &queuePolicy{budget: -1}
+	if queue.Queue == nil {
+		return policy
+	}
+	annotations := queue.Queue.Annotations
+
+	if value, found := annotations[ReclaimRateLimitAnnotation]; found {
+		if rateLimit, err := parseResource(value); err != nil {
+			klog.Warningf("Invalid annotation %s %q of queue <%s>: %v", ReclaimRateLimitAnnotation, value, queue.Name, err)
+		} else {
+			policy.rateLimit = rateLimit
+		}
+	}
+	if value, found := annotations[VictimQueuesAnnotation]; found {
+		policy.victimQueues = sets.New[string]()
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); len(name) > 0 {
+				policy.victimQueues.Insert(name)
+			}
+		}
+	}
+	if value, found := annotations[MinRuntimeAnnotation]; found {
+		if minRuntime, err := time.ParseDuration(value); err != nil || minRuntime < 0 {
+			klog.Warningf("Invalid annotation %s %q of queue <%s>: %v", MinRuntimeAnnotation, value, queue.Name, err)
+		} else {
+			policy.minRuntime = minRuntime
+		}
+	}
+	if value, found := annotations[DisruptionBudgetAnnotation]; found {
+		if budget, err := strconv.Atoi(value); err != nil || budget < 0 {
+			klog.Warningf("Invalid annotation %s %q of queue <%s>: %v", DisruptionBudgetAnnotation, value, queue.Name, err)
+		} else {
+			policy.budget = budget
+		}
+	}
+	return policy
+}
+
+// parseResource parses a comma separated list of resource quantities, e.g. "cpu=10,memory=64Gi".
+func parseResource(value string) (*api.Resource, error) {
+	rl := v1.ResourceList{}
+	for _, item := range strings.Split(value, ",") {
+		name, quantity, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found || len(name) == 0 {
+			return nil, fmt.Errorf("%q is not a resource quantity", item)
+		}
+		q, err := resource.ParseQuantity(quantity)
+		if err != nil {
+			return nil, err
+		}
+		if q.Sign() <= 0 {
+			return nil, fmt.Errorf("resource quantity for %q must be positive: %v", name, quantity)
+		}
+		rl[v1.ResourceName(name)] = q
+	}
+	return api.NewResource(rl), nil
+}
+
+// reclaimRecord is the resources a queue reclaimed from the other queues at a time.
+type reclaimRecord struct {
+	time     time.Time
+	resource *api.Resource
+}
+
+// reclaimHistory is the resources the queues reclaimed in the last rate window, kept across the sessions.
+type reclaimHistory struct {
+	mutex   sync.Mutex
+	records map[api.QueueID][]reclaimRecord
+}
+
+var history = &reclaimHistory{records: map[api.QueueID][]reclaimRecord{}}
+
+// reclaimed returns the resources the queue reclaimed since the time.
+func (h *reclaimHistory) reclaimed(queue api.QueueID, since time.Time) *api.Resource {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	reclaimed := api.EmptyResource()
+	for _, record := range h.records[queue] {
+		if record.time.After(since) {
+			reclaimed.Add(record.resource)
+		}
+	}
+	return reclaimed
+}
+
+// add records the resources the queue reclaimed at the time, and forgets the records older than the window.
+func (h *reclaimHistory) add(queue api.QueueID, now time.Time, reclaimed *api.Resource, window time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	records := make([]reclaimRecord, 0, len(h.records[queue])+1)
+	for _, record := range h.records[queue] {
+		if record.time.After(now.Add(-window)) {
+			records = append(records, record)
+		}
+	}
+	h.records[queue] = append(records, reclaimRecord{time: now, resource: reclaimed})
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemptionpolicy
+
+import (
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "preemption-policy"
+
+	// RateWindowKey is the argument of the window of the reclaim rate limits of the queues
+	RateWindowKey = "preemption-policy.rateWindow"
+
+	defaultRateWindow = time.Hour
+)
+
+/*
+   The policies are read from the queue annotations, and restrict the victims whatever the tier
+   of the plugin:
+
+   actions: "enqueue, reclaim, allocate, backfill, preempt"
+   tiers:
+   - plugins:
+     - name: priority
+     - name: gang
+     - name: preemption-policy
+       arguments:
+         preemption-policy.rateWindow: 1h
+*/
+
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	RateWindowKey: framework.StringArgument,
+}
+
+// eviction is a pod evicted in the session.
+type eviction struct {
+	// preemptor is the queue of the preemptor, empty if the pod was not evicted for a preemptor.
+	preemptor api.QueueID
+	victim    api.QueueID
+	resreq    *api.Resource
+}
+
+type preemptionPolicyPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+
+	rateWindow time.Duration
+
+	policies map[api.QueueID]*queuePolicy
+	// reclaimed is the resources the queues reclaimed in the session, not recorded in the history yet.
+	reclaimed map[api.QueueID]*api.Resource
+	// disrupting is the number of pods of the queues being evicted.
+	disrupting map[api.QueueID]int
+	// preemptors is the queue of the preemptor the victims were selected for.
+	preemptors map[api.TaskID]api.QueueID
+	evictions  map[api.TaskID]*eviction
+}
+
+// New return preemption-policy plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	pp := &preemptionPolicyPlugin{
+		pluginArguments: arguments,
+		rateWindow:      defaultRateWindow,
+	}
+	if value, ok := arguments[RateWindowKey].(string); ok {
+		if window, err := time.ParseDuration(value); err != nil || window <= 0 {
+			klog.Warningf("Could not parse argument: %s for key %s, with err %v", value, RateWindowKey, err)
+		} else {
+			pp.rateWindow = window
+		}
+	}
+	return pp
+}
+
+func (pp *preemptionPolicyPlugin) Name() string {
+	return PluginName
+}
+
+func (pp *preemptionPolicyPlugin) OnSessionOpen(ssn *framework.Session) {
+	klog.V(5).Infof("Enter preemption-policy plugin ...")
+	defer klog.V(5).Infof("Leaving preemption-policy plugin ...")
+
+	pp.policies = map[api.QueueID]*queuePolicy{}
+	for _, queue := range ssn.Queues {
+		pp.policies[queue.UID] = newQueuePolicy(queue)
+	}
+	pp.reclaimed = map[api.QueueID]*api.Resource{}
+	pp.disrupting = map[api.QueueID]int{}
+	pp.preemptors = map[api.TaskID]api.QueueID{}
+	pp.evictions = map[api.TaskID]*eviction{}
+	for _, job := range ssn.Jobs {
+		pp.disrupting[job.Queue] += len(job.TaskStatusIndex[api.Releasing])
+	}
+
+	victimFilterFn := func(preemptor *api.TaskInfo, preemptees []*api.TaskInfo) ([]*api.TaskInfo, int) {
+		preemptorJob, found := ssn.Jobs[preemptor.Job]
+		if !found {
+			return preemptees, util.Abstain
+		}
+		now := time.Now()
+		queue := preemptorJob.Queue
+		policy := pp.policy(queue)
+		reclaimed := pp.reclaimedSince(queue, now.Add(-pp.rateWindow))
+		selected := map[api.QueueID]int{}
+
+		var victims []*api.TaskInfo
+		for _, preemptee := range preemptees {
+			job, found := ssn.Jobs[preemptee.Job]
+			if !found {
+				continue
+			}
+			victimPolicy := pp.policy(job.Queue)
+			if job.Queue != queue && policy.victimQueues != nil && !policy.victimQueues.Has(string(job.Queue)) {
+				klog.V(4).Infof("Queue <%s> may not reclaim task <%s/%s> of queue <%s>",
+					queue, preemptee.Namespace, preemptee.Name, job.Queue)
+				continue
+			}
+			if victimPolicy.minRuntime > 0 && runtime(preemptee, now) < victimPolicy.minRuntime {
+				klog.V(4).Infof("Task <%s/%s> has run less than %v, it may not be evicted",
+					preemptee.Namespace, preemptee.Name, victimPolicy.minRuntime)
+				continue
+			}
+			if victimPolicy.budget >= 0 && pp.disrupting[job.Queue]+selected[job.Queue] >= victimPolicy.budget {
+				klog.V(4).Infof("The disruption budget %d of queue <%s> is exhausted, task <%s/%s> may not be evicted",
+					victimPolicy.budget, job.Queue, preemptee.Namespace, preemptee.Name)
+				continue
+			}
+			if job.Queue != queue && policy.rateLimit != nil {
+				next := reclaimed.Clone().Add(preemptee.Resreq)
+				if !next.LessEqualWithDimension(policy.rateLimit, policy.rateLimit) {
+					klog.V(4).Infof("Queue <%s> reclaimed %v in the last %v, it may not reclaim task <%s/%s> anymore",
+						queue, reclaimed, pp.rateWindow, preemptee.Namespace, preemptee.Name)
+					continue
+				}
+				reclaimed = next
+			}
+			selected[job.Queue]++
+			pp.preemptors[preemptee.UID] = queue
+			victims = append(victims, preemptee)
+		}
+		return victims, util.Permit
+	}
+	ssn.AddVictimFilterFn(pp.Name(), victimFilterFn)
+
+	ssn.AddEventHandler(&framework.EventHandler{
+		AllocateFunc: func(event *framework.Event) {
+			// The evictions of the discarded statements are reverted.
+			e, found := pp.evictions[event.Task.UID]
+			if !found || event.Task.Status == api.Releasing {
+				return
+			}
+			delete(pp.evictions, event.Task.UID)
+			pp.disrupting[e.victim]--
+			if len(e.preemptor) > 0 && e.preemptor != e.victim {
+				pp.reclaimed[e.preemptor].Sub(e.resreq)
+			}
+		},
+		DeallocateFunc: func(event *framework.Event) {
+			if event.Task.Status != api.Releasing {
+				return
+			}
+			job, found := ssn.Jobs[event.Task.Job]
+			if !found {
+				return
+			}
+			e := &eviction{
+				preemptor: pp.preemptors[event.Task.UID],
+				victim:    job.Queue,
+				resreq:    event.Task.Resreq.Clone(),
+			}
+			pp.evictions[event.Task.UID] = e
+			pp.disrupting[e.victim]++
+			if len(e.preemptor) > 0 && e.preemptor != e.victim {
+				if _, found := pp.reclaimed[e.preemptor]; !found {
+					pp.reclaimed[e.preemptor] = api.EmptyResource()
+				}
+				pp.reclaimed[e.preemptor].Add(e.resreq)
+			}
+		},
+	})
+}
+
+func (pp *preemptionPolicyPlugin) OnSessionClose(ssn *framework.Session) {
+	if !ssn.DryRun() {
+		now := time.Now()
+		for queue, reclaimed := range pp.reclaimed {
+			if !reclaimed.IsEmpty() {
+				history.add(queue, now, reclaimed, pp.rateWindow)
+			}
+		}
+		for _, e := range pp.evictions {
+			if len(e.preemptor) > 0 {
+				metrics.RegisterQueueEviction(string(e.preemptor), string(e.victim))
+			}
+		}
+	}
+
+	pp.policies = nil
+	pp.reclaimed = nil
+	pp.disrupting = nil
+	pp.preemptors = nil
+	pp.evictions = nil
+}
+
+// policy returns the policy of the queue, the default policy if the queue is unknown.
+func (pp *preemptionPolicyPlugin) policy(queue api.QueueID) *queuePolicy {
+	if policy, found := pp.policies[queue]; found {
+		return policy
+	}
+	return &queuePolicy{budget: -1}
+}
+
+// reclaimedSince returns the resources the queue reclaimed since the time, including in the session.
+func (pp *preemptionPolicyPlugin) reclaimedSince(queue api.QueueID, since time.Time) *api.Resource {
+	reclaimed := history.reclaimed(queue, since)
+	if r, found := pp.reclaimed[queue]; found {
+		reclaimed.Add(r)
+	}
+	return reclaimed
+}
+
+// runtime returns the time the task has run, since its creation if it has not started.
+func runtime(task *api.TaskInfo, now time.Time) time.Duration {
+	if task.Pod == nil {
+		return 0
+	}
+	start := task.Pod.CreationTimestamp.Time
+	if task.Pod.Status.StartTime != nil {
+		start = task.Pod.Status.StartTime.Time
+	}
+	return now.Sub(start)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemptionpolicy
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/actions/reclaim"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/conformance"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+var trueValue = true
+
+var tiers = []conf.Tier{
+	{
+		Plugins: []conf.PluginOption{
+			{
+				Name:               conformance.PluginName,
+				EnabledReclaimable: &trueValue,
+			},
+			{
+				Name:               gang.PluginName,
+				EnabledReclaimable: &trueValue,
+			},
+			{
+				Name:               proportion.PluginName,
+				EnabledReclaimable: &trueValue,
+				EnabledQueueOrder:  &trueValue,
+			},
+			{
+				Name: PluginName,
+			},
+		},
+	},
+}
+
+var plugins = map[string]framework.PluginBuilder{
+	conformance.PluginName: conformance.New,
+	gang.PluginName:        gang.New,
+	proportion.PluginName:  proportion.New,
+	PluginName:             New,
+}
+
+// buildReclaimTest returns a test where q2 reclaims 1 CPU from q1 filling n1, preemptee1 being its only preemptable pod.
+func buildReclaimTest(name string, q1, q2 map[string]string, startTime *metav1.Time) uthelper.TestCommonStruct {
+	pods := []*v1.Pod{
+		util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "true"}, nil),
+		util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "false"}, nil),
+		util.BuildPod("c1", "preemptee3", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "false"}, nil),
+		util.BuildPod("c1", "preemptor", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", nil, nil),
+	}
+	for _, pod := range pods[:3] {
+		pod.Status.StartTime = startTime
+	}
+	return uthelper.TestCommonStruct{
+		Name:    name,
+		Plugins: plugins,
+		PodGroups: []*schedulingv1beta1.PodGroup{
+			util.BuildPodGroup("pg1", "c1", "q1", 0, nil, schedulingv1beta1.PodGroupRunning),
+			util.BuildPodGroup("pg2", "c1", "q2", 0, nil, schedulingv1beta1.PodGroupInqueue),
+		},
+		Pods: pods,
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("3", "3Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+		},
+		Queues: []*schedulingv1beta1.Queue{
+			util.BuildQueueWithAnnos("q1", 1, nil, q1),
+			util.BuildQueueWithAnnos("q2", 1, nil, q2),
+		},
+	}
+}
+
+func TestPreemptionPolicy(t *testing.T) {
+	started := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		evicted bool
+	}{
+		{
+			TestCommonStruct: buildReclaimTest("without policy the queue reclaims", nil, nil, &started),
+			evicted:          true,
+		},
+		{
+			TestCommonStruct: buildReclaimTest("the queue reclaims from the allowed victim queues",
+				nil, map[string]string{VictimQueuesAnnotation: "q1,q3"}, &started),
+			evicted: true,
+		},
+		{
+			TestCommonStruct: buildReclaimTest("the queue does not reclaim from the other queues",
+				nil, map[string]string{VictimQueuesAnnotation: "q3"}, &started),
+		},
+		{
+			TestCommonStruct: buildReclaimTest("the pods having run long enough are reclaimed",
+				map[string]string{MinRuntimeAnnotation: "30s"}, nil, &started),
+			evicted: true,
+		},
+		{
+			TestCommonStruct: buildReclaimTest("the pods not having run long enough are not reclaimed",
+				map[string]string{MinRuntimeAnnotation: "1h"}, nil, &started),
+		},
+		{
+			TestCommonStruct: buildReclaimTest("the pods are not reclaimed beyond the disruption budget",
+				map[string]string{DisruptionBudgetAnnotation: "0"}, nil, &started),
+		},
+		{
+			TestCommonStruct: buildReclaimTest("the queue does not reclaim beyond its rate limit",
+				nil, map[string]string{ReclaimRateLimitAnnotation: "cpu=500m"}, &started),
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			history = &reclaimHistory{records: map[api.QueueID][]reclaimRecord{}}
+			if test.evicted {
+				test.ExpectEvictNum = 1
+				test.ExpectEvicted = []string{"c1/preemptee1"}
+			}
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{reclaim.New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestReclaimRateLimit(t *testing.T) {
+	history = &reclaimHistory{records: map[api.QueueID][]reclaimRecord{}}
+	started := metav1.NewTime(time.Now().Add(-time.Minute))
+	q2 := map[string]string{ReclaimRateLimitAnnotation: "cpu=1"}
+
+	test := buildReclaimTest("the queue reclaims up to its rate limit", nil, q2, &started)
+	test.ExpectEvictNum = 1
+	test.ExpectEvicted = []string{"c1/preemptee1"}
+	test.RegisterSession(tiers, nil)
+	test.Run([]framework.Action{reclaim.New()})
+	if err := test.CheckAll(0); err != nil {
+		t.Fatal(err)
+	}
+	test.Close()
+
+	if reclaimed := history.reclaimed("q2", time.Now().Add(-time.Hour)); reclaimed.MilliCPU != 1000 {
+		t.Fatalf("expected q2 to have reclaimed 1 CPU, got %v", reclaimed)
+	}
+
+	test = buildReclaimTest("the queue has reached its rate limit", nil, q2, &started)
+	test.RegisterSession(tiers, nil)
+	defer test.Close()
+	test.Run([]framework.Action{reclaim.New()})
+	if err := test.CheckAll(1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReclaimHistory(t *testing.T) {
+	h := &reclaimHistory{records: map[api.QueueID][]reclaimRecord{}}
+	now := time.Now()
+	h.add("q1", now.Add(-2*time.Hour), api.NewResource(api.BuildResourceList("2", "1Gi")), time.Hour)
+	h.add("q1", now.Add(-time.Minute), api.NewResource(api.BuildResourceList("1", "1Gi")), time.Hour)
+
+	if reclaimed := h.reclaimed("q1", now.Add(-time.Hour)); reclaimed.MilliCPU != 1000 {
+		t.Errorf("expected 1 CPU reclaimed in the last hour, got %v", reclaimed)
+	}
+	if len(h.records["q1"]) != 1 {
+		t.Errorf("expected the records older than the window to be forgotten, got %d records", len(h.records["q1"]))
+	}
+	if reclaimed := h.reclaimed("q2", now.Add(-time.Hour)); !reclaimed.IsEmpty() {
+		t.Errorf("expected nothing reclaimed by q2, got %v", reclaimed)
+	}
+}
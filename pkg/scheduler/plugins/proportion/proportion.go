@@ -17,6 +17,7 @@ limitations under the License.
 package proportion
 
 import (
+	"fmt"
 	"math"
 
 	v1 "k8s.io/api/core/v1"
@@ -348,6 +349,8 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 			return util.Permit
 		}
 		ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeNormal, string(scheduling.PodGroupUnschedulableType), "queue resource quota insufficient")
+		ssn.RecordVetoReason(pp.Name(), fmt.Sprintf("queue <%s> resource quota insufficient: capability <%s>, allocated <%s>, inqueue <%s>, job min resources <%s>",
+			queue.Name, attr.realCapability, attr.allocated, attr.inqueue, minReq))
 		return util.Reject
 	})
 
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	policyinformers "k8s.io/client-go/informers/policy/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/features"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// limiter keeps the victims of the strategies within the eviction limits of the session
+var limiter *evictionLimiter
+
+// evictionLimiter selects the victims of all the strategies of a session within the PodDisruptionBudgets,
+// the minAvailable of their jobs and the eviction cap of the session.
+type evictionLimiter struct {
+	jobs map[api.JobID]*api.JobInfo
+	// maxEvictions is the maximum number of victims in the session, 0 for no limit
+	maxEvictions int
+
+	victims      map[api.TaskID]bool
+	jobEvictions map[api.JobID]int32
+
+	// pdbInformer is nil if the session has no informer factory
+	pdbInformer policyinformers.PodDisruptionBudgetInformer
+	// pdbsLoaded is true once the pdbs are listed, pdbsSynced is false if they are unknown
+	pdbsLoaded  bool
+	pdbsSynced  bool
+	pdbs        []*policyv1.PodDisruptionBudget
+	pdbsAllowed []int32
+}
+
+func newEvictionLimiter(ssn *framework.Session, maxEvictions int) *evictionLimiter {
+	el := &evictionLimiter{
+		jobs:         ssn.Jobs,
+		maxEvictions: maxEvictions,
+		victims:      map[api.TaskID]bool{},
+		jobEvictions: map[api.JobID]int32{},
+	}
+	if ssn.InformerFactory() != nil {
+		el.pdbInformer = ssn.InformerFactory().Policy().V1().PodDisruptionBudgets()
+	}
+	return el
+}
+
+// loadPDBs lists the pdbs the first time the victims are filtered. The pdb informer is only started by the
+// scheduler cache if the PodDisruptionBudgetsSupport feature is enabled, otherwise it never syncs and the
+// pdbs protecting the victims are unknown.
+func (el *evictionLimiter) loadPDBs() {
+	if el.pdbsLoaded {
+		return
+	}
+	el.pdbsLoaded = true
+	el.pdbsSynced = true
+	if el.pdbInformer == nil {
+		return
+	}
+	if !el.pdbInformer.Informer().HasSynced() {
+		klog.Warningf("The pdbs are not synced, enable the %s feature to evict the victims of rescheduling",
+			features.PodDisruptionBudgetsSupport)
+		el.pdbsSynced = false
+		return
+	}
+	pdbs, err := el.pdbInformer.Lister().List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list pdbs: %v", err)
+		el.pdbsSynced = false
+		return
+	}
+	el.pdbs = pdbs
+	el.pdbsAllowed = make([]int32, len(el.pdbs))
+	for i, pdb := range el.pdbs {
+		el.pdbsAllowed[i] = pdb.Status.DisruptionsAllowed
+	}
+}
+
+// limitVictims wraps the victim function of the strategy to select its victims within the eviction limits.
+func limitVictims(strategy string, victimFn api.VictimTasksFn) api.VictimTasksFn {
+	return func(tasks []*api.TaskInfo) []*api.TaskInfo {
+		victims := victimFn(tasks)
+		if limiter == nil {
+			return victims
+		}
+		return limiter.filter(strategy, victims, intParam(strategyParams(strategy), MaxEvictionsParam, 0))
+	}
+}
+
+// filter returns the candidates which may be evicted, at most maxEvictions of them if it is positive.
+func (el *evictionLimiter) filter(strategy string, candidates []*api.TaskInfo, maxEvictions int) []*api.TaskInfo {
+	el.loadPDBs()
+	victims := make([]*api.TaskInfo, 0)
+	for _, task := range candidates {
+		if el.victims[task.UID] {
+			victims = append(victims, task)
+			continue
+		}
+		if el.maxEvictions > 0 && len(el.victims) >= el.maxEvictions {
+			klog.V(3).Infof("The eviction cap %d of the session is reached, strategy %s stops evicting", el.maxEvictions, strategy)
+			break
+		}
+		if maxEvictions > 0 && len(victims) >= maxEvictions {
+			klog.V(3).Infof("The eviction cap %d of strategy %s is reached", maxEvictions, strategy)
+			break
+		}
+		if job, found := el.jobs[task.Job]; found && job.ReadyTaskNum()-el.jobEvictions[job.UID]-1 < job.MinAvailable {
+			klog.V(4).Infof("Evicting task <%s/%s> would break the minAvailable %d of its job, skip it",
+				task.Namespace, task.Name, job.MinAvailable)
+			continue
+		}
+		if !el.pdbsSynced {
+			klog.V(4).Infof("The pdbs of task <%s/%s> are unknown, skip it", task.Namespace, task.Name)
+			continue
+		}
+		matched, allowed := el.matchPDBs(task)
+		if !allowed {
+			klog.V(4).Infof("Evicting task <%s/%s> would violate a pdb, skip it", task.Namespace, task.Name)
+			continue
+		}
+		for _, i := range matched {
+			el.pdbsAllowed[i]--
+		}
+		el.jobEvictions[task.Job]++
+		el.victims[task.UID] = true
+		victims = append(victims, task)
+	}
+	return victims
+}
+
+// matchPDBs returns the index of the pdbs the pod of the task is disrupted by, and whether they all allow it.
+func (el *evictionLimiter) matchPDBs(task *api.TaskInfo) ([]int, bool) {
+	pod := task.Pod
+	if pod == nil || len(pod.Labels) == 0 {
+		return nil, true
+	}
+	var matched []int
+	for i, pdb := range el.pdbs {
+		if pdb.Namespace != pod.Namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		// A PDB with a nil or empty selector matches nothing.
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		// The pods in DisruptedPods are already counted by the API server.
+		if _, exist := pdb.Status.DisruptedPods[pod.Name]; exist {
+			continue
+		}
+		if el.pdbsAllowed[i] <= 0 {
+			return nil, false
+		}
+		matched = append(matched, i)
+	}
+	return matched, true
+}
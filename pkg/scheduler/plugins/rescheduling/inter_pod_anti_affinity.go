@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// victimsFnForInterPodAntiAffinity evicts the pods running in the topology domain of pods matching their required
+// pod anti-affinity terms. Of two pods violating the anti-affinity of each other, only the one of lowest priority
+// is evicted. The namespace selectors of the terms are not supported, the terms without namespaces apply to the
+// namespace of the pod.
+var victimsFnForInterPodAntiAffinity = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+	candidates := make([]*api.TaskInfo, len(tasks))
+	copy(candidates, tasks)
+	sortTasks(candidates)
+
+	evicted := sets.New[api.TaskID]()
+	for _, task := range candidates {
+		if task.Pod == nil || task.Pod.Spec.Affinity == nil || task.Pod.Spec.Affinity.PodAntiAffinity == nil {
+			continue
+		}
+		node, found := Session.Nodes[task.NodeName]
+		if !found || node.Node == nil {
+			continue
+		}
+		for _, term := range task.Pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if violatesAntiAffinityTerm(task, node.Node, term, evicted) {
+				evicted.Insert(task.UID)
+				victims = append(victims, task)
+				break
+			}
+		}
+	}
+	klog.V(3).Infof("victims of strategy %s: %v", RemovePodsViolatingInterPodAntiAffinity, victims)
+	return victims
+}
+
+// violatesAntiAffinityTerm checks whether a pod not evicted yet runs in the topology domain of the node
+// and matches the anti-affinity term of the task.
+func violatesAntiAffinityTerm(task *api.TaskInfo, node *v1.Node, term v1.PodAffinityTerm, evicted sets.Set[api.TaskID]) bool {
+	domain, found := node.Labels[term.TopologyKey]
+	if !found {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+	if err != nil {
+		klog.V(4).Infof("Invalid anti-affinity label selector of task <%s/%s>: %v", task.Namespace, task.Name, err)
+		return false
+	}
+	namespaces := sets.New(term.Namespaces...)
+	if namespaces.Len() == 0 {
+		namespaces.Insert(task.Namespace)
+	}
+
+	for _, other := range Session.Nodes {
+		if other.Node == nil || other.Node.Labels[term.TopologyKey] != domain {
+			continue
+		}
+		for _, pod := range other.Tasks {
+			if pod.UID == task.UID || evicted.Has(pod.UID) || !api.AllocatedStatus(pod.Status) || pod.Pod == nil {
+				continue
+			}
+			if namespaces.Has(pod.Namespace) && selector.Matches(labels.Set(pod.Pod.Labels)) {
+				return true
+			}
+		}
+	}
+	return false
+}
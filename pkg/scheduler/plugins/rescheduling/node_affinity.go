@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// victimsFnForNodeAffinity evicts the pods whose node does not match their required node affinity anymore,
+// e.g. after its labels changed. With the nodeFit param, true by default, only the pods fitting another node
+// matching their affinity are evicted.
+var victimsFnForNodeAffinity = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+	nodeFit := boolParam(strategyParams(RemovePodsViolatingNodeAffinity), "nodeFit", true)
+	nodes := schedulableNodes()
+
+	for _, task := range tasks {
+		if task.Pod == nil || task.Pod.Spec.Affinity == nil || task.Pod.Spec.Affinity.NodeAffinity == nil ||
+			task.Pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+			continue
+		}
+		node, found := Session.Nodes[task.NodeName]
+		if !found || node.Node == nil {
+			continue
+		}
+		affinity := nodeaffinity.GetRequiredNodeAffinity(task.Pod)
+		if match, _ := affinity.Match(node.Node); match {
+			continue
+		}
+		if nodeFit && !fitsAnotherNode(task, nodes, affinity) {
+			klog.V(4).Infof("No other node matches the node affinity of task <%s/%s>, keep it", task.Namespace, task.Name)
+			continue
+		}
+		victims = append(victims, task)
+	}
+	sortTasks(victims)
+	klog.V(3).Infof("victims of strategy %s: %v", RemovePodsViolatingNodeAffinity, victims)
+	return victims
+}
+
+// fitsAnotherNode checks whether the task fits the idle resources of another node matching its node affinity
+func fitsAnotherNode(task *api.TaskInfo, nodes []*api.NodeInfo, affinity nodeaffinity.RequiredNodeAffinity) bool {
+	for _, node := range nodes {
+		if node.Name == task.NodeName {
+			continue
+		}
+		if match, _ := affinity.Match(node.Node); match && task.Resreq.LessEqual(node.FutureIdle(), api.Zero) {
+			return true
+		}
+	}
+	return false
+}
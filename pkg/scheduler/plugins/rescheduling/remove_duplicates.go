@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// victimsFnForRemoveDuplicates evicts the pods of a same owner running the same images on a node but the oldest one,
+// so that they spread on the other nodes. The owner kinds in the excludeOwnerKinds param are not considered.
+var victimsFnForRemoveDuplicates = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+	if len(schedulableNodes()) < 2 {
+		klog.V(4).Infof("No other node the duplicate pods may be moved to, skip strategy %s", RemoveDuplicates)
+		return victims
+	}
+	excludeOwnerKinds := sets.New(stringListParam(strategyParams(RemoveDuplicates), "excludeOwnerKinds")...)
+
+	duplicates := map[string][]*api.TaskInfo{}
+	for _, task := range tasks {
+		if task.Pod == nil || len(task.NodeName) == 0 {
+			continue
+		}
+		owner := metav1.GetControllerOf(task.Pod)
+		if owner == nil || excludeOwnerKinds.Has(owner.Kind) {
+			continue
+		}
+		images := make([]string, 0, len(task.Pod.Spec.Containers))
+		for _, container := range task.Pod.Spec.Containers {
+			images = append(images, container.Image)
+		}
+		sort.Strings(images)
+		key := strings.Join(append([]string{task.NodeName, task.Namespace, string(owner.UID)}, images...), "/")
+		duplicates[key] = append(duplicates[key], task)
+	}
+
+	for _, group := range duplicates {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool {
+			li, ri := group[i].Pod.CreationTimestamp, group[j].Pod.CreationTimestamp
+			if !li.Equal(&ri) {
+				return li.Before(&ri)
+			}
+			return group[i].Name < group[j].Name
+		})
+		victims = append(victims, group[1:]...)
+	}
+	sortTasks(victims)
+	klog.V(3).Infof("victims of strategy %s: %v", RemoveDuplicates, victims)
+	return victims
+}
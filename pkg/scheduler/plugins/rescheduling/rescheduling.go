@@ -36,8 +36,62 @@ const (
 	DefaultMetricsPeriod = "5m"
 	// DefaultStrategy indicates the default strategy rescheduling plugin making use of
 	DefaultStrategy = "lowNodeUtilization"
+
+	// RemoveDuplicates evicts the pods of a same owner duplicated on a node
+	RemoveDuplicates = "removeDuplicates"
+	// RemovePodsViolatingNodeAffinity evicts the pods whose node does not match their required node affinity anymore
+	RemovePodsViolatingNodeAffinity = "removePodsViolatingNodeAffinity"
+	// RemovePodsViolatingInterPodAntiAffinity evicts the pods running in the topology domain of pods they are anti-affine to
+	RemovePodsViolatingInterPodAntiAffinity = "removePodsViolatingInterPodAntiAffinity"
+	// RemovePodsViolatingTopologySpreadConstraint evicts the pods of the topology domains exceeding the max skew
+	RemovePodsViolatingTopologySpreadConstraint = "removePodsViolatingTopologySpreadConstraint"
+	// RemovePodsHavingTooManyRestarts evicts the pods whose containers restarted too many times
+	RemovePodsHavingTooManyRestarts = "removePodsHavingTooManyRestarts"
+
+	// MaxEvictionsPerSessionKey is the argument of the maximum number of pods evicted by all the strategies in a session
+	MaxEvictionsPerSessionKey = "maxEvictionsPerSession"
+	// MaxEvictionsParam is the param of the maximum number of pods evicted by a strategy in a session
+	MaxEvictionsParam = "maxEvictions"
 )
 
+/*
+   Every strategy takes its own params, and all the victims respect the PodDisruptionBudgets and the
+   minAvailable of their job:
+
+   actions: "shuffle"
+   tiers:
+   - plugins:
+     - name: rescheduling
+       arguments:
+         interval: 5m
+         maxEvictionsPerSession: 10
+         strategies:
+         - name: removeDuplicates
+           params:
+             excludeOwnerKinds: ["ReplicaSet"]
+         - name: removePodsViolatingNodeAffinity
+           params:
+             nodeFit: true
+         - name: removePodsViolatingInterPodAntiAffinity
+         - name: removePodsViolatingTopologySpreadConstraint
+           params:
+             includeSoftConstraints: false
+         - name: removePodsHavingTooManyRestarts
+           params:
+             podRestartThreshold: 100
+             includingInitContainers: true
+             maxEvictions: 2
+*/
+
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	"interval":      framework.StringArgument,
+	"metricsPeriod": framework.StringArgument,
+	"strategies":    framework.ListArgument,
+
+	MaxEvictionsPerSessionKey: framework.IntArgument,
+}
+
 var (
 	// Session contains all the data in session object which will be used for all the rescheduling package
 	Session *framework.Session
@@ -59,6 +113,11 @@ func init() {
 
 	// register victim functions for all strategies here
 	VictimFn["lowNodeUtilization"] = victimsFnForLnu
+	VictimFn[RemoveDuplicates] = victimsFnForRemoveDuplicates
+	VictimFn[RemovePodsViolatingNodeAffinity] = victimsFnForNodeAffinity
+	VictimFn[RemovePodsViolatingInterPodAntiAffinity] = victimsFnForInterPodAntiAffinity
+	VictimFn[RemovePodsViolatingTopologySpreadConstraint] = victimsFnForTopologySpreadConstraint
+	VictimFn[RemovePodsHavingTooManyRestarts] = victimsFnForTooManyRestarts
 }
 
 type reschedulingPlugin struct {
@@ -99,12 +158,14 @@ func (rp *reschedulingPlugin) OnSessionOpen(ssn *framework.Session) {
 		return
 	}
 
-	// Get all strategies and register the victim functions for each strategy.
+	// Get all strategies and register the victim functions for each strategy,
+	// the victims of all of them sharing the eviction limits of the session.
+	limiter = newEvictionLimiter(ssn, configs.maxEvictions)
 	victimFns := make([]api.VictimTasksFn, 0)
 	for _, strategy := range configs.strategies {
 		if VictimFn[strategy.Name] != nil {
 			klog.V(4).Infof("strategy: %s\n", strategy.Name)
-			victimFns = append(victimFns, VictimFn[strategy.Name])
+			victimFns = append(victimFns, limitVictims(strategy.Name, VictimFn[strategy.Name]))
 		}
 	}
 	ssn.AddVictimTasksFns(rp.Name(), victimFns)
@@ -112,6 +173,7 @@ func (rp *reschedulingPlugin) OnSessionOpen(ssn *framework.Session) {
 
 func (rp *reschedulingPlugin) OnSessionClose(ssn *framework.Session) {
 	Session = nil
+	limiter = nil
 	for k := range RegisteredStrategyConfigs {
 		delete(RegisteredStrategyConfigs, k)
 	}
@@ -121,6 +183,8 @@ func (rp *reschedulingPlugin) OnSessionClose(ssn *framework.Session) {
 type Configs struct {
 	interval   time.Duration
 	strategies []Strategy
+	// maxEvictions is the maximum number of pods evicted in a session, 0 for no limit
+	maxEvictions int
 }
 
 // Strategy is the struct for rescheduling strategy
@@ -156,6 +220,10 @@ func (rc *Configs) parseArguments(arguments framework.Arguments) {
 		klog.V(4).Infof("Parse rescheduling interval failed. Reset the interval to 5m by default.")
 		rc.interval = DefaultInterval
 	}
+	arguments.GetInt(&rc.maxEvictions, MaxEvictionsPerSessionKey)
+	if rc.maxEvictions < 0 {
+		rc.maxEvictions = 0
+	}
 	if metricsPeriodArg, ok := arguments["metricsPeriod"]; ok {
 		MetricsPeriod = metricsPeriodArg.(string)
 	}
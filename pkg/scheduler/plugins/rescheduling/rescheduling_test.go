@@ -0,0 +1,332 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/shuffle"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+var (
+	lowPriority  int32 = 10
+	highPriority int32 = 100
+)
+
+func buildPod(name, nodeName, groupName string, labels map[string]string, priority *int32) *v1.Pod {
+	return util.BuildPodWithPriority("c1", name, nodeName, v1.PodRunning, api.BuildResourceList("1", "1Gi"), groupName, labels, nil, priority)
+}
+
+func withOwner(pod *v1.Pod, uid string) *v1.Pod {
+	controller := true
+	pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: uid, UID: types.UID("rs-" + uid), Controller: &controller}}
+	return pod
+}
+
+func withRestarts(pod *v1.Pod, restarts int32) *v1.Pod {
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{{Name: "c", RestartCount: restarts}}
+	return pod
+}
+
+func strategy(name string, params map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"name": name, "params": params}
+}
+
+func buildNodes() []*v1.Node {
+	return []*v1.Node{
+		util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{"zone": "a", "kubernetes.io/hostname": "n1"}),
+		util.BuildNode("n2", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{"zone": "b", "kubernetes.io/hostname": "n2"}),
+	}
+}
+
+func buildPodGroups(minAvailable int32) []*schedulingv1beta1.PodGroup {
+	return []*schedulingv1beta1.PodGroup{
+		util.BuildPodGroup("pg1", "c1", "c1", minAvailable, nil, schedulingv1beta1.PodGroupRunning),
+		util.BuildPodGroup("pg2", "c1", "c1", 0, nil, schedulingv1beta1.PodGroupRunning),
+	}
+}
+
+func TestReschedulingStrategies(t *testing.T) {
+	required := func(pod *v1.Pod, zone string) *v1.Pod {
+		pod.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{{
+				MatchExpressions: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{zone}}},
+			}}},
+		}}
+		return pod
+	}
+	antiAffine := func(pod *v1.Pod) *v1.Pod {
+		pod.Spec.Affinity = &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				TopologyKey:   "kubernetes.io/hostname",
+			}},
+		}}
+		return pod
+	}
+	spread := func(pod *v1.Pod) *v1.Pod {
+		pod.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "zone",
+			WhenUnsatisfiable: v1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		}}
+		return pod
+	}
+	web := map[string]string{"app": "web"}
+	db := map[string]string{"app": "db"}
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		arguments framework.Arguments
+		pdbs      []*policyv1.PodDisruptionBudget
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the duplicate pods of an owner on a node are evicted but one",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					withOwner(buildPod("a-1", "n1", "pg1", nil, nil), "a"),
+					withOwner(buildPod("a-2", "n1", "pg1", nil, nil), "a"),
+					withOwner(buildPod("a-3", "n2", "pg1", nil, nil), "a"),
+					withOwner(buildPod("b-1", "n1", "pg2", nil, nil), "b"),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/a-2"},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{strategy(RemoveDuplicates, nil)}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pods of the excluded owner kinds are not considered duplicates",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					withOwner(buildPod("a-1", "n1", "pg1", nil, nil), "a"),
+					withOwner(buildPod("a-2", "n1", "pg1", nil, nil), "a"),
+				},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{
+				strategy(RemoveDuplicates, map[string]interface{}{"excludeOwnerKinds": []interface{}{"ReplicaSet"}}),
+			}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pods whose node does not match their node affinity are evicted",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					required(buildPod("a-1", "n1", "pg1", nil, nil), "b"),
+					required(buildPod("a-2", "n1", "pg1", nil, nil), "a"),
+					required(buildPod("b-1", "n1", "pg2", nil, nil), "c"),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/a-1"},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{strategy(RemovePodsViolatingNodeAffinity, nil)}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pod of lowest priority violating a pod anti-affinity is evicted",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					antiAffine(buildPod("a-1", "n1", "pg1", db, &highPriority)),
+					antiAffine(buildPod("b-1", "n1", "pg2", db, &lowPriority)),
+					antiAffine(buildPod("a-2", "n2", "pg1", db, &lowPriority)),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/b-1"},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{strategy(RemovePodsViolatingInterPodAntiAffinity, nil)}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pods of the zone exceeding the max skew are evicted",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					spread(buildPod("a-1", "n1", "pg1", web, &highPriority)),
+					spread(buildPod("a-2", "n1", "pg1", web, &highPriority)),
+					spread(buildPod("b-1", "n1", "pg2", web, &lowPriority)),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/b-1"},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{strategy(RemovePodsViolatingTopologySpreadConstraint, nil)}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pods having restarted too many times are evicted",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					withRestarts(buildPod("a-1", "n1", "pg1", nil, nil), 5),
+					withRestarts(buildPod("b-1", "n1", "pg2", nil, nil), 2),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/a-1"},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{
+				strategy(RemovePodsHavingTooManyRestarts, map[string]interface{}{"podRestartThreshold": 3}),
+			}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pods are not evicted below the minAvailable of their job",
+				PodGroups: buildPodGroups(2),
+				Pods: []*v1.Pod{
+					withRestarts(buildPod("a-1", "n1", "pg1", nil, nil), 5),
+					withRestarts(buildPod("a-2", "n1", "pg1", nil, nil), 5),
+					withRestarts(buildPod("a-3", "n1", "pg1", nil, nil), 5),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/a-1"},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{
+				strategy(RemovePodsHavingTooManyRestarts, map[string]interface{}{"podRestartThreshold": 3}),
+			}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pods are not evicted beyond their pdb",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					withRestarts(buildPod("a-1", "n1", "pg1", web, nil), 5),
+					withRestarts(buildPod("a-2", "n1", "pg1", web, nil), 5),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/a-1"},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{
+				strategy(RemovePodsHavingTooManyRestarts, map[string]interface{}{"podRestartThreshold": 3}),
+			}},
+			pdbs: []*policyv1.PodDisruptionBudget{{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "c1"},
+				Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: web}},
+				Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+			}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pods are not evicted if their pdb allows no disruption",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					withRestarts(buildPod("a-1", "n1", "pg1", web, nil), 5),
+					withRestarts(buildPod("b-1", "n1", "pg2", nil, nil), 5),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/b-1"},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{
+				strategy(RemovePodsHavingTooManyRestarts, map[string]interface{}{"podRestartThreshold": 3}),
+			}},
+			pdbs: []*policyv1.PodDisruptionBudget{{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "c1"},
+				Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: web}},
+				Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+			}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pods are not evicted beyond the cap of the session",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					withRestarts(buildPod("a-1", "n1", "pg1", nil, nil), 5),
+					withRestarts(buildPod("b-1", "n1", "pg2", nil, nil), 5),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/a-1"},
+			},
+			arguments: framework.Arguments{
+				MaxEvictionsPerSessionKey: 1,
+				"strategies": []interface{}{
+					strategy(RemovePodsHavingTooManyRestarts, map[string]interface{}{"podRestartThreshold": 3}),
+				},
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "the pods are not evicted beyond the cap of the strategy",
+				PodGroups: buildPodGroups(0),
+				Pods: []*v1.Pod{
+					withRestarts(buildPod("a-1", "n1", "pg1", nil, nil), 5),
+					withRestarts(buildPod("b-1", "n1", "pg2", nil, nil), 5),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/a-1"},
+			},
+			arguments: framework.Arguments{"strategies": []interface{}{
+				strategy(RemovePodsHavingTooManyRestarts, map[string]interface{}{"podRestartThreshold": 3, MaxEvictionsParam: 1}),
+			}},
+		},
+	}
+
+	trueValue := true
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			lastRescheduleTime = time.Time{}
+
+			test.Plugins = map[string]framework.PluginBuilder{PluginName: New}
+			test.Nodes = buildNodes()
+			test.Queues = []*schedulingv1beta1.Queue{util.BuildQueue("c1", 1, nil)}
+			tiers := []conf.Tier{{
+				Plugins: []conf.PluginOption{{
+					Name:          PluginName,
+					EnabledVictim: &trueValue,
+					Arguments:     test.arguments,
+				}},
+			}}
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+			// The pdbs go through the informer of the scheduler cache like in the cluster.
+			for _, pdb := range test.pdbs {
+				if _, err := ssn.KubeClient().PolicyV1().PodDisruptionBudgets(pdb.Namespace).Create(context.TODO(), pdb, metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			pdbLister := ssn.InformerFactory().Policy().V1().PodDisruptionBudgets().Lister()
+			if err := wait.PollUntilContextTimeout(context.TODO(), 10*time.Millisecond, wait.ForeverTestTimeout, true, func(ctx context.Context) (bool, error) {
+				pdbs, err := pdbLister.List(labels.Everything())
+				return len(pdbs) == len(test.pdbs), err
+			}); err != nil {
+				t.Fatal(err)
+			}
+			test.Run([]framework.Action{shuffle.New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -16,7 +16,14 @@ limitations under the License.
 
 package rescheduling
 
-import "time"
+import (
+	"sort"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
 
 // lastRescheduleTime records the last execution time.
 var lastRescheduleTime time.Time
@@ -34,3 +41,87 @@ func timeToRun(interval time.Duration) bool {
 	}
 	return false
 }
+
+// strategyParams returns the params of the strategy, nil if it has none
+func strategyParams(strategy string) map[string]interface{} {
+	params, _ := RegisteredStrategyConfigs[strategy].(map[string]interface{})
+	return params
+}
+
+// intParam returns the int value of the param, the default value if it is not set or invalid
+func intParam(params map[string]interface{}, key string, defaultValue int) int {
+	value, found := params[key]
+	if !found {
+		return defaultValue
+	}
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	klog.Warningf("Could not parse param: %v for key %s to int", value, key)
+	return defaultValue
+}
+
+// boolParam returns the bool value of the param, the default value if it is not set or invalid
+func boolParam(params map[string]interface{}, key string, defaultValue bool) bool {
+	value, found := params[key]
+	if !found {
+		return defaultValue
+	}
+	v, ok := value.(bool)
+	if !ok {
+		klog.Warningf("Could not parse param: %v for key %s to bool", value, key)
+		return defaultValue
+	}
+	return v
+}
+
+// stringListParam returns the string list value of the param
+func stringListParam(params map[string]interface{}, key string) []string {
+	value, found := params[key]
+	if !found {
+		return nil
+	}
+	var list []string
+	switch v := value.(type) {
+	case []string:
+		list = v
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+	default:
+		klog.Warningf("Could not parse param: %v for key %s to string list", value, key)
+	}
+	return list
+}
+
+// sortTasks sorts the tasks from the lowest priority, then by name to select the victims deterministically
+func sortTasks(tasks []*api.TaskInfo) {
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority < tasks[j].Priority
+		}
+		if tasks[i].Namespace != tasks[j].Namespace {
+			return tasks[i].Namespace < tasks[j].Namespace
+		}
+		return tasks[i].Name < tasks[j].Name
+	})
+}
+
+// schedulableNodes returns the ready nodes new pods may be scheduled to
+func schedulableNodes() []*api.NodeInfo {
+	nodes := make([]*api.NodeInfo, 0, len(Session.Nodes))
+	for _, node := range Session.Nodes {
+		if node.Node != nil && node.Ready() && !node.Node.Spec.Unschedulable {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
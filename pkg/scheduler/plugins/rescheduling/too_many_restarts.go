@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const defaultPodRestartThreshold = 100
+
+// victimsFnForTooManyRestarts evicts the pods whose containers restarted at least podRestartThreshold times,
+// counting the init containers with the includingInitContainers param.
+var victimsFnForTooManyRestarts = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+	params := strategyParams(RemovePodsHavingTooManyRestarts)
+	threshold := intParam(params, "podRestartThreshold", defaultPodRestartThreshold)
+	includingInitContainers := boolParam(params, "includingInitContainers", false)
+	if threshold <= 0 {
+		klog.Warningf("Invalid podRestartThreshold %d of strategy %s", threshold, RemovePodsHavingTooManyRestarts)
+		return victims
+	}
+
+	for _, task := range tasks {
+		if task.Pod == nil {
+			continue
+		}
+		restarts := restartCount(task.Pod.Status.ContainerStatuses)
+		if includingInitContainers {
+			restarts += restartCount(task.Pod.Status.InitContainerStatuses)
+		}
+		if restarts >= int32(threshold) {
+			victims = append(victims, task)
+		}
+	}
+	sortTasks(victims)
+	klog.V(3).Infof("victims of strategy %s: %v", RemovePodsHavingTooManyRestarts, victims)
+	return victims
+}
+
+func restartCount(statuses []v1.ContainerStatus) int32 {
+	var restarts int32
+	for _, status := range statuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
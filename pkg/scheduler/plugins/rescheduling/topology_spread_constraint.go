@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// spreadConstraint is a topology spread constraint shared by the pods of a namespace.
+type spreadConstraint struct {
+	namespace   string
+	topologyKey string
+	maxSkew     int32
+	selector    labels.Selector
+}
+
+// victimsFnForTopologySpreadConstraint evicts the pods of the topology domains holding more matching pods than
+// the max skew of their hard topology spread constraints allows, and of their soft ones too with the
+// includeSoftConstraints param. The pods are evicted from the largest domain until the skew is respected.
+var victimsFnForTopologySpreadConstraint = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+	includeSoftConstraints := boolParam(strategyParams(RemovePodsViolatingTopologySpreadConstraint), "includeSoftConstraints", false)
+
+	constraints := map[string]*spreadConstraint{}
+	candidates := map[api.TaskID]*api.TaskInfo{}
+	for _, task := range tasks {
+		if task.Pod == nil {
+			continue
+		}
+		candidates[task.UID] = task
+		for _, c := range task.Pod.Spec.TopologySpreadConstraints {
+			if c.WhenUnsatisfiable != v1.DoNotSchedule && !includeSoftConstraints {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+			if err != nil {
+				klog.V(4).Infof("Invalid topology spread label selector of task <%s/%s>: %v", task.Namespace, task.Name, err)
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%d/%s", task.Namespace, c.TopologyKey, c.MaxSkew, selector.String())
+			constraints[key] = &spreadConstraint{
+				namespace:   task.Namespace,
+				topologyKey: c.TopologyKey,
+				maxSkew:     c.MaxSkew,
+				selector:    selector,
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(constraints))
+	for key := range constraints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	evicted := map[api.TaskID]bool{}
+	for _, key := range keys {
+		for _, victim := range balanceDomains(constraints[key], candidates, evicted) {
+			evicted[victim.UID] = true
+			victims = append(victims, victim)
+		}
+	}
+	klog.V(3).Infof("victims of strategy %s: %v", RemovePodsViolatingTopologySpreadConstraint, victims)
+	return victims
+}
+
+// balanceDomains returns the candidates to evict from the largest domains of the constraint until its skew
+// is respected, the pods already evicted not counting in their domain.
+func balanceDomains(c *spreadConstraint, candidates map[api.TaskID]*api.TaskInfo, evicted map[api.TaskID]bool) []*api.TaskInfo {
+	domains := map[string][]*api.TaskInfo{}
+	for _, node := range schedulableNodes() {
+		domain, found := node.Node.Labels[c.topologyKey]
+		if !found {
+			continue
+		}
+		if _, found := domains[domain]; !found {
+			domains[domain] = nil
+		}
+		for _, task := range node.Tasks {
+			if task.Namespace != c.namespace || task.Pod == nil || !api.AllocatedStatus(task.Status) || evicted[task.UID] {
+				continue
+			}
+			if c.selector.Matches(labels.Set(task.Pod.Labels)) {
+				domains[domain] = append(domains[domain], task)
+			}
+		}
+	}
+	if len(domains) < 2 {
+		return nil
+	}
+	for _, pods := range domains {
+		sortTasks(pods)
+	}
+
+	counts := map[string]int{}
+	for domain, pods := range domains {
+		counts[domain] = len(pods)
+	}
+	var victims []*api.TaskInfo
+	for {
+		largest, smallest := "", ""
+		for domain, count := range counts {
+			if len(largest) == 0 || count > counts[largest] || (count == counts[largest] && domain < largest) {
+				largest = domain
+			}
+			if len(smallest) == 0 || count < counts[smallest] || (count == counts[smallest] && domain < smallest) {
+				smallest = domain
+			}
+		}
+		if int32(counts[largest]-counts[smallest]) <= c.maxSkew {
+			return victims
+		}
+		// The pod of lowest priority which may be evicted leaves the largest domain for the smallest one.
+		index := -1
+		for i, task := range domains[largest] {
+			if _, found := candidates[task.UID]; found {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return victims
+		}
+		// The tasks of the nodes are copies of the tasks of the jobs which are evicted.
+		victims = append(victims, candidates[domains[largest][index].UID])
+		domains[largest] = append(domains[largest][:index], domains[largest][index+1:]...)
+		counts[largest]--
+		counts[smallest]++
+	}
+}
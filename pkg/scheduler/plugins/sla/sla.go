@@ -35,6 +35,11 @@ const (
 	JobWaitingTime = "sla-waiting-time"
 )
 
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	JobWaitingTime: framework.StringArgument,
+}
+
 type slaPlugin struct {
 	// Arguments given for sla plugin
 	pluginArguments framework.Arguments
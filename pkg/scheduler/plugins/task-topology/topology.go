@@ -345,6 +345,11 @@ func (p *taskTopologyPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddTaskOrderFn(p.Name(), p.TaskOrderFn)
 
 	ssn.AddNodeOrderFn(p.Name(), p.NodeOrderFn)
+	// The bucket scores depend on where the other tasks of the job are placed.
+	ssn.AddEquivalentFn(p.Name(), func(task *api.TaskInfo) bool {
+		_, hasManager := p.managers[task.Job]
+		return !hasManager
+	})
 
 	ssn.AddEventHandler(&framework.EventHandler{
 		AllocateFunc: p.AllocateFunc,
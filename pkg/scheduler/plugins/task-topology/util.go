@@ -40,6 +40,11 @@ const (
 	TaskOrderAnnotations = "volcano.sh/task-topology-task-order"
 )
 
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	PluginWeight: framework.IntArgument,
+}
+
 // TaskTopology is struct used to save affinity infos of a job read from job plugin or annotations
 type TaskTopology struct {
 	Affinity     [][]string `json:"affinity,omitempty"`
@@ -41,6 +41,12 @@ const (
 	defaultPodEvictNum       = 1
 )
 
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	revocableZoneLabelPrefix + "*": framework.StringArgument,
+	evictPeriodLabel:               framework.StringArgument,
+}
+
 var lastEvictAt time.Time
 
 /*
@@ -17,6 +17,9 @@ limitations under the License.
 package usage
 
 import (
+	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	"volcano.sh/volcano/pkg/scheduler/metrics/source"
@@ -44,25 +47,57 @@ const (
      - name: usage
        enablePredicate: false  # If the value is false, new pod scheduling is not disabled when the node load reaches the threshold. If the value is true or left blank, new pod scheduling is disabled.
        arguments:
+         usage.type: p95 # average, p95 or max of the usage samples in the metrics window, average by default
          usage.weight: 5
          cpu.weight: 1
          memory.weight: 1
+         hotspot.weight: 2 # the weight of the score of the nodes whose usage goes up over the metrics window, 0 by default
+         weights:
+           gpu: 1 # the other weights apply to the resources of the same name reported by the metrics source
          thresholds:
            cpu: 80
            mem: 80
+           gpu: 90 # the other thresholds apply to the resources of the same name reported by the metrics source
 */
 
-const AVG string = "average"
+const (
+	// AVG uses the average usage of the metrics period
+	AVG string = "average"
+	// P95 uses the 95th percentile of the usage samples in the metrics window
+	P95 string = "p95"
+	// MAX uses the maximum of the usage samples in the metrics window
+	MAX string = "max"
+
+	weightSection  = "weights"
+	cpuResource    = "cpu"
+	memoryResource = "memory"
+)
+
+// ArgumentSchema declares the arguments of the plugin.
+var ArgumentSchema = framework.ArgumentSchema{
+	"usage.type":     framework.StringArgument,
+	"usage.weight":   framework.IntArgument,
+	"cpu.weight":     framework.IntArgument,
+	"memory.weight":  framework.IntArgument,
+	"hotspot.weight": framework.IntArgument,
+	weightSection:    framework.MapArgument,
+	thresholdSection: framework.MapArgument,
+}
 
 type usagePlugin struct {
 	pluginArguments framework.Arguments
 	usageWeight     int
 	cpuWeight       int
 	memoryWeight    int
+	// resourceWeights are the weights of the other resources reported by the metrics source by name.
+	resourceWeights map[string]int
+	hotspotWeight   int
 	usageType       string
 	cpuThresholds   float64
 	memThresholds   float64
-	period          string
+	// resourceThresholds are the thresholds of the other resources reported by the metrics source by name.
+	resourceThresholds map[string]float64
+	period             string
 }
 
 // New function returns usagePlugin object
@@ -80,6 +115,24 @@ func New(args framework.Arguments) framework.Plugin {
 	args.GetInt(&plugin.usageWeight, "usage.weight")
 	args.GetInt(&plugin.cpuWeight, "cpu.weight")
 	args.GetInt(&plugin.memoryWeight, "memory.weight")
+	args.GetInt(&plugin.hotspotWeight, "hotspot.weight")
+	if usageType, ok := args["usage.type"].(string); ok {
+		switch usageType {
+		case AVG, P95, MAX:
+			plugin.usageType = usageType
+		default:
+			klog.Errorf("Invalid usage type %q, the usage type is one of %s, %s and %s, use %s", usageType, AVG, P95, MAX, plugin.usageType)
+		}
+	}
+
+	if weightArgs, ok := plugin.pluginArguments[weightSection].(map[interface{}]interface{}); ok {
+		plugin.resourceWeights = make(map[string]int, len(weightArgs))
+		for resourceName, weight := range weightArgs {
+			resource, _ := resourceName.(string)
+			value, _ := weight.(int)
+			plugin.resourceWeights[resource] = value
+		}
+	}
 
 	argsValue, ok := plugin.pluginArguments[thresholdSection]
 	if !ok {
@@ -100,12 +153,63 @@ func New(args framework.Arguments) framework.Plugin {
 			plugin.cpuThresholds = float64(value)
 		case "mem":
 			plugin.memThresholds = float64(value)
+		default:
+			if plugin.resourceThresholds == nil {
+				plugin.resourceThresholds = map[string]float64{}
+			}
+			plugin.resourceThresholds[resource] = float64(value)
 		}
 	}
 
 	return plugin
 }
 
+// usage returns the usage of the resource of the node by the usage type, the average usage of the period
+// is used while the node has no statistics of the resource over the metrics window.
+func (up *usagePlugin) usage(nodeUsage *api.NodeUsage, resource string) (float64, bool) {
+	if stats, found := nodeUsage.UsageStats[resource]; found {
+		switch up.usageType {
+		case P95:
+			return stats.P95, true
+		case MAX:
+			return stats.Max, true
+		}
+	}
+
+	var usage float64
+	var found bool
+	switch resource {
+	case cpuResource:
+		usage, found = nodeUsage.CPUUsageAvg[up.period]
+	case memoryResource:
+		usage, found = nodeUsage.MEMUsageAvg[up.period]
+	default:
+		usage, found = nodeUsage.ResourceUsageAvg[resource][up.period]
+	}
+	return usage, found
+}
+
+// hotspotScore scores the node by how much the usage of the weighted resources goes up over the metrics window,
+// the node whose usage is flat or goes down gets the max score and the node whose usage goes up by 100 gets 0.
+func (up *usagePlugin) hotspotScore(nodeUsage *api.NodeUsage) float64 {
+	weights := map[string]int{cpuResource: up.cpuWeight, memoryResource: up.memoryWeight}
+	for resource, weight := range up.resourceWeights {
+		weights[resource] = weight
+	}
+
+	rise, totalWeight := 0.0, 0
+	for resource, weight := range weights {
+		totalWeight += weight
+		if stats, found := nodeUsage.UsageStats[resource]; found {
+			rise += math.Min(math.Max(stats.Trend, 0), 100) / 100 * float64(weight)
+		}
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+	return (1 - rise/float64(totalWeight)) * float64(k8sFramework.MaxNodeScore*int64(up.hotspotWeight))
+}
+
 func (up *usagePlugin) Name() string {
 	return PluginName
 }
@@ -135,21 +239,37 @@ func (up *usagePlugin) OnSessionOpen(ssn *framework.Session) {
 			return nil
 		}
 
-		klog.V(4).Infof("predicateFn cpuUsageAvg:%v,predicateFn memUsageAvg:%v", up.cpuThresholds, up.memThresholds)
-		if node.ResourceUsage.CPUUsageAvg[up.period] > up.cpuThresholds {
-			klog.V(3).Infof("Node %s cpu usage %f exceeds the threshold %f", node.Name, node.ResourceUsage.CPUUsageAvg[up.period], up.cpuThresholds)
+		klog.V(4).Infof("predicateFn cpuThresholds:%v,predicateFn memThresholds:%v", up.cpuThresholds, up.memThresholds)
+		if cpuUsage, _ := up.usage(node.ResourceUsage, cpuResource); cpuUsage > up.cpuThresholds {
+			klog.V(3).Infof("Node %s cpu %s usage %f exceeds the threshold %f", node.Name, up.usageType, cpuUsage, up.cpuThresholds)
 			usageStatus.Code = api.UnschedulableAndUnresolvable
 			usageStatus.Reason = NodeUsageCPUExtend
 			predicateStatus = append(predicateStatus, usageStatus)
 			return api.NewFitErrWithStatus(task, node, predicateStatus...)
 		}
-		if node.ResourceUsage.MEMUsageAvg[up.period] > up.memThresholds {
-			klog.V(3).Infof("Node %s mem usage %f exceeds the threshold %f", node.Name, node.ResourceUsage.MEMUsageAvg[up.period], up.memThresholds)
+		if memoryUsage, _ := up.usage(node.ResourceUsage, memoryResource); memoryUsage > up.memThresholds {
+			klog.V(3).Infof("Node %s mem %s usage %f exceeds the threshold %f", node.Name, up.usageType, memoryUsage, up.memThresholds)
 			usageStatus.Code = api.UnschedulableAndUnresolvable
 			usageStatus.Reason = NodeUsageMemoryExtend
 			predicateStatus = append(predicateStatus, usageStatus)
 			return api.NewFitErrWithStatus(task, node, predicateStatus...)
 		}
+		resources := make([]string, 0, len(up.resourceThresholds))
+		for resource := range up.resourceThresholds {
+			resources = append(resources, resource)
+		}
+		sort.Strings(resources)
+		for _, resource := range resources {
+			threshold := up.resourceThresholds[resource]
+			usage, found := up.usage(node.ResourceUsage, resource)
+			if found && usage > threshold {
+				klog.V(3).Infof("Node %s %s %s usage %f exceeds the threshold %f", node.Name, resource, up.usageType, usage, threshold)
+				usageStatus.Code = api.UnschedulableAndUnresolvable
+				usageStatus.Reason = fmt.Sprintf("the %s load of the node exceeds the upper limit.", resource)
+				predicateStatus = append(predicateStatus, usageStatus)
+				return api.NewFitErrWithStatus(task, node, predicateStatus...)
+			}
+		}
 
 		klog.V(4).Infof("Usage plugin filter for task %s/%s on node %s pass.", task.Namespace, task.Name, node.Name)
 		return nil
@@ -164,21 +284,37 @@ func (up *usagePlugin) OnSessionOpen(ssn *framework.Session) {
 			return 0, nil
 		}
 
-		cpuUsage, exist := node.ResourceUsage.CPUUsageAvg[up.period]
+		cpuUsage, exist := up.usage(node.ResourceUsage, cpuResource)
 		klog.V(4).Infof("Node %s cpu usage is %f.", node.Name, cpuUsage)
 		if !exist {
 			return 0, nil
 		}
 		cpuScore := (100 - cpuUsage) / 100 * float64(up.cpuWeight)
 
-		memoryUsage, exist := node.ResourceUsage.MEMUsageAvg[up.period]
+		memoryUsage, exist := up.usage(node.ResourceUsage, memoryResource)
 		klog.V(4).Infof("Node %s memory usage is %f.", node.Name, memoryUsage)
 		if !exist {
 			return 0, nil
 		}
 		memoryScore := (100 - memoryUsage) / 100 * float64(up.memoryWeight)
-		score = (cpuScore + memoryScore) / float64(up.cpuWeight+up.memoryWeight)
+
+		weightedScore, totalWeight := cpuScore+memoryScore, up.cpuWeight+up.memoryWeight
+		for resource, weight := range up.resourceWeights {
+			usage, found := up.usage(node.ResourceUsage, resource)
+			if !found {
+				continue
+			}
+			klog.V(4).Infof("Node %s %s usage is %f.", node.Name, resource, usage)
+			weightedScore += (100 - usage) / 100 * float64(weight)
+			totalWeight += weight
+		}
+		if totalWeight > 0 {
+			score = weightedScore / float64(totalWeight)
+		}
 		score *= float64(k8sFramework.MaxNodeScore * int64(up.usageWeight))
+		if up.hotspotWeight > 0 {
+			score += up.hotspotScore(node.ResourceUsage)
+		}
 		klog.V(4).Infof("Node %s score for task %s is %f.", node.Name, task.Name, score)
 		return score, nil
 	}
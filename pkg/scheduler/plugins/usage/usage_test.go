@@ -70,6 +70,7 @@ func TestUsage_predicateFn(t *testing.T) {
 	n3 := util.BuildNode("n3", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
 	n4 := util.BuildNode("n4", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
 	n5 := util.BuildNode("n5", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
+	n6 := util.BuildNode("n6", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
 
 	nodesUsage := make(map[string]*api.NodeUsage)
 	timeNow := time.Now()
@@ -90,6 +91,10 @@ func TestUsage_predicateFn(t *testing.T) {
 	// However, the metric time is in the initial state, and the usage function is invalid.
 	// The node can schedule pods.
 	nodesUsage[n5.Name] = buildNodeUsage(map[string]float64{source.NODE_METRICS_PERIOD: 90}, map[string]float64{source.NODE_METRICS_PERIOD: 81}, time.Time{})
+	// The GPU load of the node reported by the metrics source exceeds the upper limit.
+	// The node cannot be scheduled.
+	nodesUsage[n6.Name] = buildNodeUsage(map[string]float64{source.NODE_METRICS_PERIOD: 60}, map[string]float64{source.NODE_METRICS_PERIOD: 60}, timeNow)
+	nodesUsage[n6.Name].ResourceUsageAvg = map[string]map[string]float64{"gpu": {source.NODE_METRICS_PERIOD: 95}}
 
 	pg1 := util.BuildPodGroup("pg1", "c1", "q1", 0, nil, "")
 
@@ -241,6 +246,35 @@ func TestUsage_predicateFn(t *testing.T) {
 				err: nil,
 			},
 		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "The node cannot be scheduled, because of the GPU load of the node exceeds the upper limit.",
+				PodGroups: []*schedulingv1.PodGroup{pg1},
+				Queues:    []*schedulingv1.Queue{queue1},
+				Pods:      []*v1.Pod{p1, p2},
+				Nodes:     []*v1.Node{n6},
+			},
+			nodesUsageMap: nodesUsage,
+			arguments: framework.Arguments{
+				"usage.weight":  5,
+				"cpu.weight":    1,
+				"memory.weight": 1,
+				"thresholds": map[interface{}]interface{}{
+					"cpu": 80,
+					"mem": 80,
+					"gpu": 90,
+				},
+			},
+			expected: predicateResult{
+				predicateStatus: []*api.Status{
+					{
+						Code:   api.UnschedulableAndUnresolvable,
+						Reason: "the gpu load of the node exceeds the upper limit.",
+					},
+				},
+				err: fmt.Errorf("plugin %s predicates failed, because of %s", PluginName, "the gpu load of the node exceeds the upper limit."),
+			},
+		},
 	}
 
 	for i, test := range tests {
@@ -454,3 +488,135 @@ func TestUsage_nodeOrderFn(t *testing.T) {
 		})
 	}
 }
+
+func TestUsage_usageStats(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{PluginName: New}
+
+	p1 := util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", make(map[string]string), make(map[string]string))
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
+	n2 := util.BuildNode("n2", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
+	n3 := util.BuildNode("n3", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
+
+	nodesUsage := make(map[string]*api.NodeUsage)
+	timeNow := time.Now()
+	// The CPU usage of the node is low on average but spikes in the window.
+	nodesUsage[n1.Name] = buildNodeUsage(map[string]float64{source.NODE_METRICS_PERIOD: 50}, map[string]float64{source.NODE_METRICS_PERIOD: 50}, timeNow)
+	nodesUsage[n1.Name].UsageStats = map[string]*api.UsageStats{
+		"cpu":    {Avg: 50, P95: 85, Max: 95},
+		"memory": {Avg: 50, P95: 50, Max: 50},
+	}
+	// The GPU usage of the node goes up over the window and reaches the threshold at its maximum.
+	nodesUsage[n2.Name] = buildNodeUsage(map[string]float64{source.NODE_METRICS_PERIOD: 50}, map[string]float64{source.NODE_METRICS_PERIOD: 50}, timeNow)
+	nodesUsage[n2.Name].ResourceUsageAvg = map[string]map[string]float64{"gpu": {source.NODE_METRICS_PERIOD: 50}}
+	nodesUsage[n2.Name].UsageStats = map[string]*api.UsageStats{
+		"cpu":    {Avg: 50, P95: 60, Max: 70},
+		"memory": {Avg: 50, P95: 50, Max: 50},
+		"gpu":    {Avg: 50, P95: 70, Max: 92, Trend: 40},
+	}
+	// The node has no statistics yet, the average usage is used.
+	nodesUsage[n3.Name] = buildNodeUsage(map[string]float64{source.NODE_METRICS_PERIOD: 30}, map[string]float64{source.NODE_METRICS_PERIOD: 30}, timeNow)
+
+	pg1 := util.BuildPodGroup("pg1", "c1", "q1", 0, nil, "")
+	queue1 := util.BuildQueue("q1", 1, nil)
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		arguments      framework.Arguments
+		expectedReason map[string]string
+		expectedScore  map[string]float64
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "The average usage of the period is used by default",
+			},
+			arguments: framework.Arguments{
+				"thresholds": map[interface{}]interface{}{"cpu": 80, "mem": 80, "gpu": 90},
+			},
+			expectedReason: map[string]string{"n1": "", "n2": "", "n3": ""},
+			expectedScore:  map[string]float64{"n1": 250, "n2": 250, "n3": 350},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "The p95 usage filters the node with CPU spikes",
+			},
+			arguments: framework.Arguments{
+				"usage.type": P95,
+				"thresholds": map[interface{}]interface{}{"cpu": 80, "mem": 80, "gpu": 90},
+			},
+			expectedReason: map[string]string{"n1": NodeUsageCPUExtend, "n2": "", "n3": ""},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "The max usage filters the node whose GPU usage reaches the threshold",
+			},
+			arguments: framework.Arguments{
+				"usage.type": MAX,
+				"thresholds": map[interface{}]interface{}{"cpu": 100, "mem": 80, "gpu": 90},
+			},
+			expectedReason: map[string]string{"n1": "", "n2": "the gpu load of the node exceeds the upper limit.", "n3": ""},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "The p95 usage of the weighted resources is scored and the node with GPU usage going up is penalized",
+			},
+			arguments: framework.Arguments{
+				"usage.type":     P95,
+				"usage.weight":   1,
+				"hotspot.weight": 1,
+				"weights":        map[interface{}]interface{}{"gpu": 2},
+			},
+			// n1: (15+50)/2 + 100, n2: (40+50+30*2)/4 + (1-40*2/100/4)*100, n3: (70+70)/2 + 100
+			expectedScore: map[string]float64{"n1": 132.5, "n2": 117.5, "n3": 170},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.PodGroups = []*schedulingv1.PodGroup{pg1}
+			test.Queues = []*schedulingv1.Queue{queue1}
+			test.Pods = []*v1.Pod{p1}
+			test.Nodes = []*v1.Node{n1, n2, n3}
+			trueValue := true
+			tiers := []conf.Tier{
+				{
+					Plugins: []conf.PluginOption{
+						{
+							Name:             PluginName,
+							EnabledPredicate: &trueValue,
+							EnabledNodeOrder: &trueValue,
+							Arguments:        test.arguments,
+						},
+					},
+				},
+			}
+			test.Plugins = plugins
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+
+			updateNodeUsage(ssn.Nodes, nodesUsage)
+
+			for _, job := range ssn.Jobs {
+				for _, task := range job.Tasks {
+					for _, node := range ssn.Nodes {
+						if expectedReason, found := test.expectedReason[node.Name]; found {
+							reason := ""
+							if err := ssn.PredicateFn(task, node); err != nil {
+								reason = strings.Join(err.(*api.FitError).Reasons(), ", ")
+							}
+							if reason != expectedReason {
+								t.Errorf("case%d: task %s on node %s expect reason %q, but get %q", i, task.Name, node.Name, expectedReason, reason)
+							}
+						}
+						if expectedScore, found := test.expectedScore[node.Name]; found {
+							score, err := ssn.NodeOrderFn(task, node)
+							if err != nil || math.Abs(expectedScore-score) > eps {
+								t.Errorf("case%d: task %s on node %s expect score %v, but get %v, %v", i, task.Name, node.Name, expectedScore, score, err)
+							}
+						}
+					}
+				}
+			}
+		})
+	}
+}
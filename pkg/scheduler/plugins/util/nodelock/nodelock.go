@@ -63,7 +63,7 @@ func UseClient(client kubernetes.Interface) error {
 	return nil
 }
 
-func updateNodeAnnotations(ctx context.Context, node *v1.Node, updateFunc func(annotations map[string]string)) error {
+func updateNodeAnnotations(ctx context.Context, kubeClient kubernetes.Interface, node *v1.Node, updateFunc func(annotations map[string]string)) error {
 	newNode := node.DeepCopy()
 	updateFunc(newNode.ObjectMeta.Annotations)
 	nodeName := newNode.Name
@@ -87,7 +87,7 @@ func updateNodeAnnotations(ctx context.Context, node *v1.Node, updateFunc func(a
 	return nil
 }
 
-func setNodeLock(nodeName string, lockName string) error {
+func setNodeLock(kubeClient kubernetes.Interface, nodeName string, lockName string) error {
 	ctx := context.Background()
 	node, err := kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
 	if err != nil {
@@ -100,7 +100,7 @@ func setNodeLock(nodeName string, lockName string) error {
 	updateFunc := func(annotations map[string]string) {
 		annotations[lockName] = time.Now().Format(time.RFC3339)
 	}
-	err = updateNodeAnnotations(ctx, node, updateFunc)
+	err = updateNodeAnnotations(ctx, kubeClient, node, updateFunc)
 	if err != nil {
 		return fmt.Errorf("setNodeLock exceeds retry count %d", MaxLockRetry)
 	}
@@ -108,7 +108,12 @@ func setNodeLock(nodeName string, lockName string) error {
 	return nil
 }
 
+// ReleaseNodeLock releases the lock of device 'lockName' on node 'nodeName'
 func ReleaseNodeLock(nodeName string, lockName string) error {
+	return releaseNodeLock(kubeClient, nodeName, lockName)
+}
+
+func releaseNodeLock(kubeClient kubernetes.Interface, nodeName string, lockName string) error {
 	ctx := context.Background()
 	node, err := kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
 	if err != nil {
@@ -121,7 +126,7 @@ func ReleaseNodeLock(nodeName string, lockName string) error {
 	updateFunc := func(annotations map[string]string) {
 		delete(annotations, lockName)
 	}
-	err = updateNodeAnnotations(ctx, node, updateFunc)
+	err = updateNodeAnnotations(ctx, kubeClient, node, updateFunc)
 	if err != nil {
 		return fmt.Errorf("releaseNodeLock exceeds retry count %d", MaxLockRetry)
 	}
@@ -131,13 +136,19 @@ func ReleaseNodeLock(nodeName string, lockName string) error {
 
 // LockNode try lock device 'lockName' on node 'nodeName'
 func LockNode(nodeName string, lockName string) error {
+	return LockNodeWithClient(kubeClient, nodeName, lockName)
+}
+
+// LockNodeWithClient try lock device 'lockName' on node 'nodeName' with the given client instead of the
+// client of the package, e.g. the fake client of a dry run session.
+func LockNodeWithClient(kubeClient kubernetes.Interface, nodeName string, lockName string) error {
 	ctx := context.Background()
 	node, err := kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
 	if _, ok := node.ObjectMeta.Annotations[lockName]; !ok {
-		return setNodeLock(nodeName, lockName)
+		return setNodeLock(kubeClient, nodeName, lockName)
 	}
 	lockTime, err := time.Parse(time.RFC3339, node.ObjectMeta.Annotations[lockName])
 	if err != nil {
@@ -145,12 +156,12 @@ func LockNode(nodeName string, lockName string) error {
 	}
 	if time.Since(lockTime) > time.Minute*5 {
 		klog.V(3).InfoS("Node lock expired", "node", nodeName, "lockTime", lockTime)
-		err = ReleaseNodeLock(nodeName, lockName)
+		err = releaseNodeLock(kubeClient, nodeName, lockName)
 		if err != nil {
 			klog.ErrorS(err, "Failed to release node lock", "node", nodeName)
 			return err
 		}
-		return setNodeLock(nodeName, lockName)
+		return setNodeLock(kubeClient, nodeName, lockName)
 	}
 	return fmt.Errorf("node %s has been locked within 5 minutes", nodeName)
 }
@@ -17,7 +17,9 @@ limitations under the License.
 package scheduler
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,16 +27,27 @@ import (
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/filewatcher"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/trace"
 	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/util/tracing"
+)
+
+const (
+	// CacheSnapshotPath is the debug endpoint serving a snapshot of the scheduler cache.
+	CacheSnapshotPath = "/debug/cache/snapshot"
+	// DecisionTracePath is the debug endpoint serving the decision trace of the latest sessions.
+	DecisionTracePath = "/debug/decisions"
 )
 
 // Scheduler represents a "Volcano Scheduler".
@@ -45,14 +58,32 @@ type Scheduler struct {
 	schedulerConf  string
 	fileWatcher    filewatcher.FileWatcher
 	schedulePeriod time.Duration
-	once           sync.Once
+	// trigger starts the scheduling cycles early on the events of the cache, nil runs them every schedulePeriod only.
+	trigger *scheduleTrigger
+	once    sync.Once
+	// shadowSchedulerConf is the path of the candidate configuration run in a dry run session alongside.
+	shadowSchedulerConf string
+	// shadowFileWatcher watches the directory of the shadow configuration if it differs from the live one.
+	shadowFileWatcher filewatcher.FileWatcher
+	// confMap watches the configuration in a ConfigMap, the file configuration is the fallback while it does not exist.
+	confMap *configMapConfSource
+
+	// sessionMutex serializes the live and the shadow sessions, the actions and plugins keep parts of their
+	// configuration in package variables.
+	sessionMutex sync.Mutex
+	// shadowSessions tracks the shadow sessions running in the background.
+	shadowSessions sync.WaitGroup
 
 	mutex          sync.Mutex
 	actions        []framework.Action
 	plugins        []conf.Tier
 	configurations []conf.Configuration
 	metricsConf    map[string]string
+	shadowConf     *schedulerConf
 	dumper         schedcache.Dumper
+	traceRecorder  *trace.Recorder
+	// podRef refers to the pod of the scheduler, the configuration reload failures are recorded on it.
+	podRef *v1.ObjectReference
 }
 
 // NewScheduler returns a Scheduler
@@ -67,6 +98,15 @@ func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, e
 		}
 	}
 
+	var shadowWatcher filewatcher.FileWatcher
+	if opt.ShadowSchedulerConf != "" && (opt.SchedulerConf == "" || filepath.Dir(opt.ShadowSchedulerConf) != filepath.Dir(opt.SchedulerConf)) {
+		var err error
+		shadowWatcher, err = filewatcher.NewFileWatcher(filepath.Dir(opt.ShadowSchedulerConf))
+		if err != nil {
+			return nil, fmt.Errorf("failed creating filewatcher for %s: %v", opt.ShadowSchedulerConf, err)
+		}
+	}
+
 	cache := schedcache.New(config, opt.SchedulerNames, opt.DefaultQueue, opt.NodeSelector, opt.NodeWorkerThreads, opt.IgnoredCSIProvisioners)
 	scheduler := &Scheduler{
 		schedulerConf:  opt.SchedulerConf,
@@ -74,29 +114,80 @@ func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, e
 		cache:          cache,
 		schedulePeriod: opt.SchedulePeriod,
 		dumper:         schedcache.Dumper{Cache: cache, RootDir: opt.CacheDumpFileDir},
+
+		shadowSchedulerConf: opt.ShadowSchedulerConf,
+		shadowFileWatcher:   shadowWatcher,
+		podRef:              schedulerPodReference(opt),
+	}
+	if opt.SchedulerConfConfigMap != "" {
+		scheduler.confMap = newConfigMapConfSource(opt.SchedulerConfConfigMap, opt.SchedulerConfConfigMapKey, cache.Client())
+	}
+	if opt.EnableEventTrigger {
+		scheduler.trigger = newScheduleTrigger(opt.ScheduleMinPeriod, opt.SchedulePeriod)
+		cache.SetScheduleTrigger(scheduler.trigger.Trigger)
+	}
+	framework.SetEquivalenceCacheEnabled(opt.EnableEquivalenceCache)
+	if opt.DecisionTraceSessions > 0 {
+		scheduler.traceRecorder = trace.NewRecorder(opt.DecisionTraceSessions)
+		framework.SetTraceRecorder(scheduler.traceRecorder)
 	}
 
 	return scheduler, nil
 }
 
+// schedulerPodReference returns the reference of the pod the scheduler runs in, as
+// given by the downward API, falling back to the hostname and the leader election namespace.
+func schedulerPodReference(opt *options.ServerOption) *v1.ObjectReference {
+	name := os.Getenv("POD_NAME")
+	if name == "" {
+		name, _ = os.Hostname()
+	}
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		namespace = opt.LeaderElection.ResourceNamespace
+	}
+	return &v1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: name}
+}
+
 // Run initializes and starts the Scheduler. It loads the configuration,
 // initializes the cache, and begins the scheduling process.
 func (pc *Scheduler) Run(stopCh <-chan struct{}) {
 	pc.loadSchedulerConf()
+	pc.loadShadowSchedulerConf()
 	go pc.watchSchedulerConf(stopCh)
+	if pc.confMap != nil {
+		pc.watchSchedulerConfMap(stopCh)
+	}
 	// Start cache for policy.
 	pc.cache.SetMetricsConf(pc.metricsConf)
 	pc.cache.Run(stopCh)
 	klog.V(2).Infof("Scheduler completes Initialization and start to run")
-	go wait.Until(pc.runOnce, pc.schedulePeriod, stopCh)
+	if pc.trigger != nil {
+		go pc.trigger.run(pc.runOnce, stopCh)
+	} else {
+		go wait.Until(pc.runOnce, pc.schedulePeriod, stopCh)
+	}
 	if options.ServerOpts.EnableCacheDumper {
 		pc.dumper.ListenForSignal(stopCh)
 	}
 	go runSchedulerSocket()
 }
 
+// DebugHandlers returns the debug endpoints of the scheduler keyed by path,
+// the caller is responsible for protecting them with authentication.
+func (pc *Scheduler) DebugHandlers() map[string]http.Handler {
+	handlers := map[string]http.Handler{}
+	if options.ServerOpts.EnableCacheDumper {
+		handlers[CacheSnapshotPath] = &pc.dumper
+	}
+	if pc.traceRecorder != nil {
+		handlers[DecisionTracePath] = pc.traceRecorder
+	}
+	return handlers
+}
+
 // runOnce executes a single scheduling cycle. This function is called periodically
-// as defined by the Scheduler's schedule period.
+// as defined by the Scheduler's schedule period, and on the events of the cache with the event trigger.
 func (pc *Scheduler) runOnce() {
 	klog.V(4).Infof("Start scheduling ...")
 	scheduleStartTime := time.Now()
@@ -106,23 +197,57 @@ func (pc *Scheduler) runOnce() {
 	actions := pc.actions
 	plugins := pc.plugins
 	configurations := pc.configurations
+	shadow := pc.shadowConf
 	pc.mutex.Unlock()
 
+	// The shadow session of the previous cycle may still run. The shadow session is skipped after the live
+	// session had to wait for it, so that a slow shadow configuration delays every other cycle at most.
+	if !pc.sessionMutex.TryLock() {
+		pc.sessionMutex.Lock()
+		shadow = nil
+	}
+
+	var cache schedcache.Cache = pc.cache
+	var liveCache *recordingCache
+	var shadowSnapshot *api.ClusterInfo
+	if shadow != nil {
+		// Both sessions run on the same snapshot. The shadow session runs in the background once the
+		// live session is closed, so that it does not delay the decisions of the live session.
+		snapshot := pc.cache.Snapshot()
+		shadowSnapshot = cloneClusterInfo(snapshot)
+		liveCache = newRecordingCache(pc.cache, snapshot)
+		cache = liveCache
+	}
+
 	// Load ConfigMap to check which action is enabled.
 	conf.EnabledActionMap = make(map[string]bool)
 	for _, action := range actions {
 		conf.EnabledActionMap[action.Name()] = true
 	}
 
-	ssn := framework.OpenSession(pc.cache, plugins, configurations)
+	ctx, span := tracing.Start(context.Background(), "runOnce")
+	ssn := framework.OpenSessionWithContext(ctx, cache, plugins, configurations)
+	span.SetAttributes(tracing.SessionKey.String(string(ssn.UID)))
 	defer func() {
 		framework.CloseSession(ssn)
+		span.End()
 		metrics.UpdateE2eDuration(metrics.Duration(scheduleStartTime))
+		if shadow == nil {
+			pc.sessionMutex.Unlock()
+			return
+		}
+		pc.shadowSessions.Add(1)
+		go func() {
+			defer pc.shadowSessions.Done()
+			defer pc.sessionMutex.Unlock()
+			shadowCache := runShadowSession(pc.cache, shadow, shadowSnapshot)
+			updateShadowMetrics(liveCache, shadowCache)
+		}()
 	}()
 
 	for _, action := range actions {
 		actionStartTime := time.Now()
-		action.Execute(ssn)
+		framework.ExecuteAction(ssn, action)
 		metrics.UpdateActionDuration(action.Name(), metrics.Duration(actionStartTime))
 	}
 }
@@ -143,12 +268,13 @@ func (pc *Scheduler) loadSchedulerConf() {
 		}
 	})
 
-	var config string
+	config := DefaultSchedulerConf
 	if len(pc.schedulerConf) != 0 {
 		confData, err := os.ReadFile(pc.schedulerConf)
 		if err != nil {
 			klog.Errorf("Failed to read the Scheduler config in '%s', using previous configuration: %v",
 				pc.schedulerConf, err)
+			pc.recordConfReloadFailure(metrics.SchedulerConf, pc.schedulerConf, err)
 			return
 		}
 		config = strings.TrimSpace(string(confData))
@@ -156,9 +282,11 @@ func (pc *Scheduler) loadSchedulerConf() {
 
 	actions, plugins, configurations, metricsConf, err := UnmarshalSchedulerConf(config)
 	if err != nil {
-		klog.Errorf("Scheduler config %s is invalid: %v", config, err)
+		klog.Errorf("Scheduler config %s is invalid, using previous configuration: %v", config, err)
+		pc.recordConfReloadFailure(metrics.SchedulerConf, pc.schedulerConf, err)
 		return
 	}
+	metrics.UpdateSchedulerConfReload(metrics.SchedulerConf, true)
 
 	pc.mutex.Lock()
 	pc.actions = actions
@@ -168,6 +296,46 @@ func (pc *Scheduler) loadSchedulerConf() {
 	pc.mutex.Unlock()
 }
 
+// loadShadowSchedulerConf loads the candidate configuration run in the shadow session,
+// the previous one is kept if it can not be loaded.
+func (pc *Scheduler) loadShadowSchedulerConf() {
+	if len(pc.shadowSchedulerConf) == 0 {
+		return
+	}
+
+	confData, err := os.ReadFile(pc.shadowSchedulerConf)
+	if err != nil {
+		klog.Errorf("Failed to read the shadow Scheduler config in '%s', using previous configuration: %v",
+			pc.shadowSchedulerConf, err)
+		pc.recordConfReloadFailure(metrics.ShadowSchedulerConf, pc.shadowSchedulerConf, err)
+		return
+	}
+
+	actions, plugins, configurations, _, err := UnmarshalSchedulerConf(strings.TrimSpace(string(confData)))
+	if err != nil {
+		klog.Errorf("Shadow Scheduler config %s is invalid, using previous configuration: %v", string(confData), err)
+		pc.recordConfReloadFailure(metrics.ShadowSchedulerConf, pc.shadowSchedulerConf, err)
+		return
+	}
+	metrics.UpdateSchedulerConfReload(metrics.ShadowSchedulerConf, true)
+
+	pc.mutex.Lock()
+	pc.shadowConf = &schedulerConf{actions: actions, plugins: plugins, configurations: configurations}
+	pc.mutex.Unlock()
+	klog.V(2).Infof("Successfully loaded shadow Scheduler conf %s", pc.shadowSchedulerConf)
+}
+
+// recordConfReloadFailure surfaces a rejected configuration as a metric and a warning event
+// on the scheduler pod.
+func (pc *Scheduler) recordConfReloadFailure(kind, path string, err error) {
+	metrics.UpdateSchedulerConfReload(kind, false)
+	if pc.podRef == nil {
+		return
+	}
+	pc.cache.EventRecorder().Eventf(pc.podRef, v1.EventTypeWarning, "InvalidSchedulerConf",
+		"Failed to load the %s configuration %s, using previous configuration: %v", kind, path, err)
+}
+
 func (pc *Scheduler) getSchedulerConf() (actions []string, plugins []string) {
 	for _, action := range pc.actions {
 		actions = append(actions, action.Name())
@@ -181,11 +349,20 @@ func (pc *Scheduler) getSchedulerConf() (actions []string, plugins []string) {
 }
 
 func (pc *Scheduler) watchSchedulerConf(stopCh <-chan struct{}) {
-	if pc.fileWatcher == nil {
+	if pc.fileWatcher == nil && pc.shadowFileWatcher == nil {
 		return
 	}
-	eventCh := pc.fileWatcher.Events()
-	errCh := pc.fileWatcher.Errors()
+	// The channels of a missing watcher stay nil and never fire.
+	var eventCh, shadowEventCh <-chan fsnotify.Event
+	var errCh, shadowErrCh <-chan error
+	if pc.fileWatcher != nil {
+		eventCh = pc.fileWatcher.Events()
+		errCh = pc.fileWatcher.Errors()
+	}
+	if pc.shadowFileWatcher != nil {
+		shadowEventCh = pc.shadowFileWatcher.Events()
+		shadowErrCh = pc.shadowFileWatcher.Errors()
+	}
 	for {
 		select {
 		case event, ok := <-eventCh:
@@ -194,14 +371,35 @@ func (pc *Scheduler) watchSchedulerConf(stopCh <-chan struct{}) {
 			}
 			klog.V(4).Infof("watch %s event: %v", pc.schedulerConf, event)
 			if event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create {
-				pc.loadSchedulerConf()
-				pc.cache.SetMetricsConf(pc.metricsConf)
+				if pc.confMap != nil && pc.confMap.Exists() {
+					klog.V(4).Infof("Ignore the Scheduler conf file, the conf of %s is used", pc.confMap)
+				} else {
+					pc.loadSchedulerConf()
+					pc.cache.SetMetricsConf(pc.metricsConf)
+				}
+				// The shadow configuration shares the directory unless it has its own watcher.
+				if pc.shadowFileWatcher == nil {
+					pc.loadShadowSchedulerConf()
+				}
 			}
 		case err, ok := <-errCh:
 			if !ok {
 				return
 			}
 			klog.Infof("watch %s error: %v", pc.schedulerConf, err)
+		case event, ok := <-shadowEventCh:
+			if !ok {
+				return
+			}
+			klog.V(4).Infof("watch %s event: %v", pc.shadowSchedulerConf, event)
+			if event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create {
+				pc.loadShadowSchedulerConf()
+			}
+		case err, ok := <-shadowErrCh:
+			if !ok {
+				return
+			}
+			klog.Infof("watch %s error: %v", pc.shadowSchedulerConf, err)
 		case <-stopCh:
 			return
 		}
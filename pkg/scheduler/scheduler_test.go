@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+)
+
+func TestLoadSchedulerConfKeepsPreviousOnFailure(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	sc := schedcache.NewCustomMockSchedulerCache("volcano", nil, nil, nil, nil, nil, recorder)
+	confFile := filepath.Join(t.TempDir(), "scheduler.conf")
+	pc := &Scheduler{
+		cache:         sc,
+		schedulerConf: confFile,
+		podRef:        &v1.ObjectReference{Kind: "Pod", Namespace: "volcano-system", Name: "volcano-scheduler"},
+	}
+
+	valid := `
+actions: "enqueue, allocate"
+tiers:
+- plugins:
+  - name: gang
+`
+	assert.NoError(t, os.WriteFile(confFile, []byte(valid), 0644))
+	pc.loadSchedulerConf()
+	actions, plugins := pc.getSchedulerConf()
+	assert.Equal(t, []string{"enqueue", "allocate"}, actions)
+	assert.Equal(t, []string{"gang"}, plugins)
+	assert.Equal(t, float64(1), gaugeValue(t, "volcano_scheduler_conf_valid", map[string]string{"conf": "scheduler"}))
+
+	invalid := `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: binpack
+    arguments:
+      binpack.weight: heavy
+`
+	assert.NoError(t, os.WriteFile(confFile, []byte(invalid), 0644))
+	pc.loadSchedulerConf()
+	actions, plugins = pc.getSchedulerConf()
+	assert.Equal(t, []string{"enqueue", "allocate"}, actions)
+	assert.Equal(t, []string{"gang"}, plugins)
+	assert.Equal(t, float64(0), gaugeValue(t, "volcano_scheduler_conf_valid", map[string]string{"conf": "scheduler"}))
+
+	select {
+	case event := <-recorder.Events:
+		assert.True(t, strings.HasPrefix(event, "Warning InvalidSchedulerConf"), event)
+		assert.Contains(t, event, `argument "binpack.weight" must be of type int`)
+	default:
+		t.Fatal("expected an event for the invalid configuration")
+	}
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	fakekube "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	vcclient "volcano.sh/apis/pkg/client/clientset/versioned"
+	fakevcclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/capabilities/dynamicresources"
+	"volcano.sh/volcano/pkg/scheduler/capabilities/volumebinding"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+// schedulerConf is a parsed scheduler configuration.
+type schedulerConf struct {
+	actions        []framework.Action
+	plugins        []conf.Tier
+	configurations []conf.Configuration
+}
+
+// recordingCache hands the same snapshot to the session every time and records
+// the tasks the session binds and evicts before passing them to the cache.
+type recordingCache struct {
+	schedcache.Cache
+	snapshot *api.ClusterInfo
+
+	mutex sync.Mutex
+	// binds records the node every task is bound to.
+	binds map[api.TaskID]string
+	// startedJobs records the jobs with at least one task bound.
+	startedJobs map[api.JobID]bool
+	// evictions is the number of tasks evicted.
+	evictions int
+}
+
+func newRecordingCache(cache schedcache.Cache, snapshot *api.ClusterInfo) *recordingCache {
+	return &recordingCache{
+		Cache:       cache,
+		snapshot:    snapshot,
+		binds:       map[api.TaskID]string{},
+		startedJobs: map[api.JobID]bool{},
+	}
+}
+
+// Snapshot returns the snapshot the cache was created with.
+func (rc *recordingCache) Snapshot() *api.ClusterInfo {
+	return rc.snapshot
+}
+
+// AddBindTask binds the task with the cache and records it.
+func (rc *recordingCache) AddBindTask(task *api.TaskInfo) error {
+	if err := rc.Cache.AddBindTask(task); err != nil {
+		return err
+	}
+	rc.recordBind(task)
+	return nil
+}
+
+// Evict evicts the task with the cache and records it.
+func (rc *recordingCache) Evict(task *api.TaskInfo, reason string) error {
+	if err := rc.Cache.Evict(task, reason); err != nil {
+		return err
+	}
+	rc.recordEviction()
+	return nil
+}
+
+func (rc *recordingCache) recordBind(task *api.TaskInfo) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.binds[task.UID] = task.NodeName
+	rc.startedJobs[task.Job] = true
+}
+
+func (rc *recordingCache) recordEviction() {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.evictions++
+}
+
+// dryRunCache records the decisions of a dry run session without applying them: binds,
+// evictions and status updates are dropped and the clients only reach in-memory fakes.
+// Reads, e.g. of the pod volumes, are still served by the cache.
+type dryRunCache struct {
+	*recordingCache
+
+	kubeClient kubernetes.Interface
+	vcClient   vcclient.Interface
+	recorder   record.EventRecorder
+}
+
+func newDryRunCache(cache schedcache.Cache, snapshot *api.ClusterInfo) *dryRunCache {
+	kubeClient := fakekube.NewSimpleClientset()
+	kubeClient.PrependReactor("*", "*", acceptWrites)
+	vcClient := fakevcclient.NewSimpleClientset()
+	vcClient.PrependReactor("*", "*", acceptWrites)
+
+	return &dryRunCache{
+		recordingCache: newRecordingCache(cache, snapshot),
+		kubeClient:     kubeClient,
+		vcClient:       vcClient,
+		// A FakeRecorder without channel drops all events.
+		recorder: &record.FakeRecorder{},
+	}
+}
+
+// acceptWrites makes the fake clients accept every create and update as is, e.g. of the
+// root queue which does not exist in the fake client.
+func acceptWrites(action k8stesting.Action) (bool, runtime.Object, error) {
+	switch action := action.(type) {
+	case k8stesting.CreateAction:
+		return true, action.GetObject(), nil
+	case k8stesting.UpdateAction:
+		return true, action.GetObject(), nil
+	}
+	return false, nil, nil
+}
+
+// AddBindTask records the bind only.
+func (dc *dryRunCache) AddBindTask(task *api.TaskInfo) error {
+	dc.recordBind(task)
+	return nil
+}
+
+// Evict records the eviction only.
+func (dc *dryRunCache) Evict(task *api.TaskInfo, reason string) error {
+	dc.recordEviction()
+	return nil
+}
+
+// BindPodGroup does nothing in a dry run.
+func (dc *dryRunCache) BindPodGroup(job *api.JobInfo, cluster string) error {
+	return nil
+}
+
+// UpdateJobStatus does nothing in a dry run.
+func (dc *dryRunCache) UpdateJobStatus(job *api.JobInfo, updatePG bool) (*api.JobInfo, error) {
+	return job, nil
+}
+
+// RecordJobStatusEvent does nothing in a dry run.
+func (dc *dryRunCache) RecordJobStatusEvent(job *api.JobInfo, updatePG bool) {}
+
+// UpdateQueueStatus does nothing in a dry run.
+func (dc *dryRunCache) UpdateQueueStatus(queue *api.QueueInfo) error {
+	return nil
+}
+
+// UpdateSchedulerNumaInfo does nothing in a dry run.
+func (dc *dryRunCache) UpdateSchedulerNumaInfo(sets map[string]api.ResNumaSets) error {
+	return nil
+}
+
+// AllocateVolumes does nothing in a dry run, the volumes are not assumed in the cache.
+func (dc *dryRunCache) AllocateVolumes(task *api.TaskInfo, hostname string, podVolumes *volumebinding.PodVolumes) error {
+	return nil
+}
+
+// BindVolumes does nothing in a dry run.
+func (dc *dryRunCache) BindVolumes(task *api.TaskInfo, volumes *volumebinding.PodVolumes) error {
+	return nil
+}
+
+// RevertVolumes does nothing in a dry run.
+func (dc *dryRunCache) RevertVolumes(task *api.TaskInfo, podVolumes *volumebinding.PodVolumes) {}
+
+// AllocateClaims does nothing in a dry run, the resource claims are not assumed in the cache.
+func (dc *dryRunCache) AllocateClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) error {
+	return nil
+}
+
+// BindClaims does nothing in a dry run.
+func (dc *dryRunCache) BindClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) error {
+	return nil
+}
+
+// RevertClaims does nothing in a dry run.
+func (dc *dryRunCache) RevertClaims(task *api.TaskInfo, podClaims *dynamicresources.PodClaims) {}
+
+// Client returns a fake kubernetes clientSet
+func (dc *dryRunCache) Client() kubernetes.Interface {
+	return dc.kubeClient
+}
+
+// VCClient returns a fake volcano clientSet
+func (dc *dryRunCache) VCClient() vcclient.Interface {
+	return dc.vcClient
+}
+
+// EventRecorder returns a recorder dropping all events
+func (dc *dryRunCache) EventRecorder() record.EventRecorder {
+	return dc.recorder
+}
+
+// runShadowSession runs the shadow configuration in a dry run session on the snapshot
+// and returns the cache holding its decisions.
+func runShadowSession(cache schedcache.Cache, shadow *schedulerConf, snapshot *api.ClusterInfo) *dryRunCache {
+	klog.V(4).Infof("Start shadow scheduling ...")
+	defer klog.V(4).Infof("End shadow scheduling ...")
+
+	// The actions check the enabled actions through the global map, it is reset for the live session.
+	conf.EnabledActionMap = make(map[string]bool)
+	for _, action := range shadow.actions {
+		conf.EnabledActionMap[action.Name()] = true
+	}
+
+	dryRun := newDryRunCache(cache, snapshot)
+	ssn := framework.OpenDryRunSession(dryRun, shadow.plugins, shadow.configurations)
+	defer framework.CloseSession(ssn)
+
+	for _, action := range shadow.actions {
+		action.Execute(ssn)
+	}
+	return dryRun
+}
+
+// updateShadowMetrics exports how the decisions of the shadow session differ from the live ones.
+func updateShadowMetrics(live *recordingCache, shadow *dryRunCache) {
+	live.mutex.Lock()
+	defer live.mutex.Unlock()
+	shadow.mutex.Lock()
+	defer shadow.mutex.Unlock()
+
+	metrics.UpdateShadowSessionResult(metrics.LiveSession, len(live.startedJobs), live.evictions)
+	metrics.UpdateShadowSessionResult(metrics.ShadowSession, len(shadow.startedJobs), shadow.evictions)
+	metrics.UpdateShadowPlacementDivergence(placementDivergence(live.binds, shadow.binds))
+}
+
+// placementDivergence returns the ratio of the tasks bound by either session
+// that are not bound to the same node by the other one.
+func placementDivergence(live, shadow map[api.TaskID]string) float64 {
+	total, diverged := 0, 0
+	for task, node := range live {
+		total++
+		if shadow[task] != node {
+			diverged++
+		}
+	}
+	for task := range shadow {
+		if _, found := live[task]; !found {
+			total++
+			diverged++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(diverged) / float64(total)
+}
+
+// cloneClusterInfo deep copies the snapshot so that a session can run on it independently
+// of the session running on the original, including the queues and sharable devices.
+func cloneClusterInfo(ci *api.ClusterInfo) *api.ClusterInfo {
+	res := &api.ClusterInfo{
+		Nodes:          make(map[string]*api.NodeInfo, len(ci.Nodes)),
+		Jobs:           make(map[api.JobID]*api.JobInfo, len(ci.Jobs)),
+		Queues:         make(map[api.QueueID]*api.QueueInfo, len(ci.Queues)),
+		NamespaceInfo:  make(map[api.NamespaceName]*api.NamespaceInfo, len(ci.NamespaceInfo)),
+		RevocableNodes: make(map[string]*api.NodeInfo, len(ci.RevocableNodes)),
+		NodeList:       make([]string, len(ci.NodeList)),
+		CSINodesStatus: make(map[string]*api.CSINodeStatusInfo, len(ci.CSINodesStatus)),
+	}
+
+	// The node index is left nil, the session builds it on the cloned nodes.
+	copy(res.NodeList, ci.NodeList)
+	for name, node := range ci.Nodes {
+		res.Nodes[name] = node.DeepClone()
+	}
+	for name := range ci.RevocableNodes {
+		if node, found := res.Nodes[name]; found {
+			res.RevocableNodes[name] = node
+		}
+	}
+	for uid, job := range ci.Jobs {
+		res.Jobs[uid] = job.Clone()
+	}
+	for uid, queue := range ci.Queues {
+		clone := queue.Clone()
+		// The session updates the status of the queues on close.
+		if queue.Queue != nil {
+			clone.Queue = queue.Queue.DeepCopy()
+		}
+		res.Queues[uid] = clone
+	}
+	for name, ns := range ci.NamespaceInfo {
+		quotaStatus := make(map[string]v1.ResourceQuotaStatus, len(ns.QuotaStatus))
+		for quota, status := range ns.QuotaStatus {
+			quotaStatus[quota] = *status.DeepCopy()
+		}
+		res.NamespaceInfo[name] = &api.NamespaceInfo{Name: ns.Name, QuotaStatus: quotaStatus}
+	}
+	for name, status := range ci.CSINodesStatus {
+		res.CSINodesStatus[name] = status.Clone()
+	}
+	return res
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestPlacementDivergence(t *testing.T) {
+	tests := []struct {
+		name   string
+		live   map[api.TaskID]string
+		shadow map[api.TaskID]string
+		want   float64
+	}{
+		{
+			name: "no binds",
+			want: 0,
+		},
+		{
+			name:   "same placement",
+			live:   map[api.TaskID]string{"t1": "n1", "t2": "n2"},
+			shadow: map[api.TaskID]string{"t1": "n1", "t2": "n2"},
+			want:   0,
+		},
+		{
+			name:   "different node",
+			live:   map[api.TaskID]string{"t1": "n1", "t2": "n2"},
+			shadow: map[api.TaskID]string{"t1": "n1", "t2": "n1"},
+			want:   0.5,
+		},
+		{
+			name:   "bound by one session only",
+			live:   map[api.TaskID]string{"t1": "n1"},
+			shadow: map[api.TaskID]string{"t1": "n1", "t2": "n1", "t3": "n2"},
+			want:   2.0 / 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.InDelta(t, test.want, placementDivergence(test.live, test.shadow), 1e-9)
+		})
+	}
+}
+
+func gaugeValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metric:
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metric
+				}
+			}
+			return m.GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("metric %s%v not found", name, labels)
+	return 0
+}
+
+func TestShadowSession(t *testing.T) {
+	options.Default()
+	liveConf := `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gang
+  - name: predicates
+`
+	tests := []struct {
+		name       string
+		shadowConf string
+		liveJobs   float64
+		shadowJobs float64
+		divergence float64
+	}{
+		{
+			name:       "same configuration",
+			shadowConf: liveConf,
+			liveJobs:   2,
+			shadowJobs: 2,
+			divergence: 0,
+		},
+		{
+			name:       "no allocate action",
+			shadowConf: `actions: "enqueue"`,
+			liveJobs:   2,
+			shadowJobs: 0,
+			divergence: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			binder := util.NewFakeBinder(10)
+			sc := schedcache.NewCustomMockSchedulerCache("volcano", binder, nil, nil, nil, nil, nil)
+			sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+			sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+			sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+			sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+			sc.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+			sc.AddPod(util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", nil, nil))
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			sc.Run(stopCh)
+
+			actions, plugins, configurations, _, err := UnmarshalSchedulerConf(liveConf)
+			assert.NoError(t, err)
+			shadowActions, shadowPlugins, shadowConfigurations, _, err := UnmarshalSchedulerConf(test.shadowConf)
+			assert.NoError(t, err)
+			pc := &Scheduler{
+				cache:          sc,
+				actions:        actions,
+				plugins:        plugins,
+				configurations: configurations,
+				shadowConf:     &schedulerConf{actions: shadowActions, plugins: shadowPlugins, configurations: shadowConfigurations},
+			}
+			pc.runOnce()
+			pc.shadowSessions.Wait()
+
+			// The shadow session runs on a copy of the snapshot, so the live session still binds every task.
+			for i := 0; i < 2; i++ {
+				select {
+				case <-binder.Channel:
+				case <-time.After(time.Second):
+					t.Fatalf("live session did not bind task %d", i)
+				}
+			}
+			assert.Equal(t, map[string]string{"c1/p1": "n1", "c1/p2": "n1"}, binder.Binds())
+
+			assert.Equal(t, test.liveJobs, gaugeValue(t, "volcano_shadow_jobs_started", map[string]string{"session": "live"}))
+			assert.Equal(t, test.shadowJobs, gaugeValue(t, "volcano_shadow_jobs_started", map[string]string{"session": "shadow"}))
+			assert.Equal(t, float64(0), gaugeValue(t, "volcano_shadow_preemptions", map[string]string{"session": "shadow"}))
+			assert.Equal(t, test.divergence, gaugeValue(t, "volcano_shadow_placement_divergence_ratio", nil))
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	vcclient "volcano.sh/apis/pkg/client/clientset/versioned"
+	fakevcclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+)
+
+// fakeCache is a cache.Cache backed by an in-memory SchedulerCache. Every call that
+// would reach the API server (bind, evict, podgroup and queue status updates) is
+// recorded instead, so that the decisions of the session can be reported.
+type fakeCache struct {
+	*cache.SchedulerCache
+
+	vcClient vcclient.Interface
+	// clusterInfo is the ClusterInfo loaded from a dumped snapshot, if any,
+	// it is handed to the session instead of a snapshot of the in-memory cache.
+	clusterInfo *api.ClusterInfo
+
+	mutex sync.Mutex
+	// snapshot is the last snapshot handed to a session, its jobs are shared
+	// with the session and hold the final state once the session is closed.
+	snapshot *api.ClusterInfo
+	// binds records the node every dispatched task is bound to.
+	binds map[api.TaskID]string
+	// evictions records the tasks evicted in the session in order.
+	evictions []*Victim
+	// updatedJobs records the jobs whose status was updated on session close.
+	updatedJobs map[api.JobID]bool
+}
+
+var _ cache.Cache = &fakeCache{}
+
+func newFakeCache(cluster *Cluster) (*fakeCache, error) {
+	// A FakeRecorder without channel drops all events, so the session never blocks on them.
+	sc := cache.NewCustomMockSchedulerCache("simulator", nil, nil, nil, nil, nil, &record.FakeRecorder{})
+	var clusterInfo *api.ClusterInfo
+	if cluster.snapshot != nil {
+		var err error
+		if clusterInfo, err = cluster.snapshot.ClusterInfo(); err != nil {
+			return nil, err
+		}
+	} else if err := cluster.populate(sc); err != nil {
+		return nil, err
+	}
+
+	// The root queue status is updated directly through the volcano client,
+	// so queues must exist there as well.
+	vcClient := fakevcclient.NewSimpleClientset()
+	for _, queue := range cluster.Queues {
+		if err := vcClient.Tracker().Add(queue.DeepCopy()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &fakeCache{
+		SchedulerCache: sc,
+		vcClient:       vcClient,
+		clusterInfo:    clusterInfo,
+		binds:          map[api.TaskID]string{},
+		updatedJobs:    map[api.JobID]bool{},
+	}, nil
+}
+
+// Snapshot returns a snapshot of the in-memory cache and keeps it for the report.
+func (fc *fakeCache) Snapshot() *api.ClusterInfo {
+	snapshot := fc.clusterInfo
+	if snapshot == nil {
+		snapshot = fc.SchedulerCache.Snapshot()
+	}
+
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	fc.snapshot = snapshot
+	return snapshot
+}
+
+// VCClient returns the fake volcano clientSet
+func (fc *fakeCache) VCClient() vcclient.Interface {
+	return fc.vcClient
+}
+
+// AddBindTask records the bind instead of sending it to the API server.
+func (fc *fakeCache) AddBindTask(task *api.TaskInfo) error {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	klog.V(3).Infof("Simulated bind of task <%s/%s> to node <%s>", task.Namespace, task.Name, task.NodeName)
+	fc.binds[task.UID] = task.NodeName
+	return nil
+}
+
+// Evict records the eviction instead of deleting the pod.
+func (fc *fakeCache) Evict(task *api.TaskInfo, reason string) error {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	klog.V(3).Infof("Simulated eviction of task <%s/%s> on node <%s>: %s", task.Namespace, task.Name, task.NodeName, reason)
+	fc.evictions = append(fc.evictions, &Victim{
+		Namespace: task.Namespace,
+		Name:      task.Name,
+		Job:       string(task.Job),
+		Node:      task.NodeName,
+		Reason:    reason,
+	})
+	return nil
+}
+
+// BindPodGroup does nothing as there is no silo cluster to bind to.
+func (fc *fakeCache) BindPodGroup(job *api.JobInfo, cluster string) error {
+	return nil
+}
+
+// UpdateJobStatus records that the job went through the session close.
+func (fc *fakeCache) UpdateJobStatus(job *api.JobInfo, updatePG bool) (*api.JobInfo, error) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	fc.updatedJobs[job.UID] = true
+	return job, nil
+}
+
+// RecordJobStatusEvent does nothing, the report carries the job status instead.
+func (fc *fakeCache) RecordJobStatusEvent(job *api.JobInfo, updatePG bool) {}
+
+// UpdateQueueStatus does nothing, queue status is not part of the report.
+func (fc *fakeCache) UpdateQueueStatus(queue *api.QueueInfo) error {
+	return nil
+}
+
+// UpdateSchedulerNumaInfo does nothing, numa assignment is not part of the report.
+func (fc *fakeCache) UpdateSchedulerNumaInfo(sets map[string]api.ResNumaSets) error {
+	return nil
+}
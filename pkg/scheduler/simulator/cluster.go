@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+)
+
+// Cluster is the serialized cluster state replayed by the simulator.
+// It holds the objects the scheduler cache builds its api.ClusterInfo from:
+// nodes, pods and podgroups make up the NodeInfo and JobInfo,
+// queues the QueueInfo and resource quotas the NamespaceInfo.
+type Cluster struct {
+	Nodes           []*v1.Node                    `json:"nodes,omitempty"`
+	Pods            []*v1.Pod                     `json:"pods,omitempty"`
+	PodGroups       []*vcv1beta1.PodGroup         `json:"podGroups,omitempty"`
+	Queues          []*vcv1beta1.Queue            `json:"queues,omitempty"`
+	PriorityClasses []*schedulingv1.PriorityClass `json:"priorityClasses,omitempty"`
+	ResourceQuotas  []*v1.ResourceQuota           `json:"resourceQuotas,omitempty"`
+
+	// snapshot is set if the cluster was dumped by the scheduler, the session then
+	// runs on the dumped ClusterInfo as is instead of rebuilding it from the objects.
+	snapshot *cache.ClusterSnapshot
+}
+
+// NewClusterFromSnapshot returns a Cluster replaying a snapshot dumped by the scheduler cache.
+func NewClusterFromSnapshot(snapshot *cache.ClusterSnapshot) *Cluster {
+	return &Cluster{
+		Queues:   snapshot.Queues,
+		snapshot: snapshot,
+	}
+}
+
+// LoadCluster reads a Cluster from a json or yaml file, the file is either a Cluster
+// or a ClusterSnapshot dumped by the scheduler cache.
+func LoadCluster(path string) (*Cluster, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster file %s: %v", path, err)
+	}
+
+	typeMeta := &metav1.TypeMeta{}
+	if err := yaml.Unmarshal(data, typeMeta); err != nil {
+		return nil, fmt.Errorf("failed to decode cluster file %s: %v", path, err)
+	}
+	if typeMeta.Kind == cache.ClusterSnapshotKind {
+		jsonData, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode cluster file %s: %v", path, err)
+		}
+		snapshot, err := cache.ReadClusterSnapshot(bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cluster file %s: %v", path, err)
+		}
+		return NewClusterFromSnapshot(snapshot), nil
+	}
+
+	cluster := &Cluster{}
+	if err := yaml.Unmarshal(data, cluster); err != nil {
+		return nil, fmt.Errorf("failed to decode cluster file %s: %v", path, err)
+	}
+	return cluster, nil
+}
+
+// populate adds all objects of the cluster to the scheduler cache through the
+// same handlers the informers use, so the derived accounting (idle, used,
+// releasing, task status index...) is exactly what a live cache would hold.
+func (c *Cluster) populate(sc *cache.SchedulerCache) error {
+	for _, pc := range c.PriorityClasses {
+		sc.AddPriorityClass(pc)
+	}
+	for _, queue := range c.Queues {
+		sc.AddQueueV1beta1(queue)
+	}
+	for _, rq := range c.ResourceQuotas {
+		sc.AddResourceQuota(rq)
+	}
+	for _, node := range c.Nodes {
+		if err := sc.AddOrUpdateNode(node); err != nil {
+			return fmt.Errorf("failed to add node %s: %v", node.Name, err)
+		}
+	}
+	for _, pg := range c.PodGroups {
+		sc.AddPodGroupV1beta1(pg)
+	}
+	for _, pod := range c.Pods {
+		sc.AddPod(pod)
+	}
+	return nil
+}
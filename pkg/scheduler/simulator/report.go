@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// Report is the outcome of one simulated scheduling session.
+type Report struct {
+	// Actions are the actions executed in order.
+	Actions []string `json:"actions"`
+	// Jobs holds the decisions for every job in the snapshot.
+	Jobs []*JobReport `json:"jobs"`
+	// Victims are the tasks evicted by preempt or reclaim, in eviction order.
+	Victims []*Victim `json:"victims,omitempty"`
+}
+
+// JobReport describes the scheduling decisions for a job.
+type JobReport struct {
+	Namespace    string                   `json:"namespace"`
+	Name         string                   `json:"name"`
+	Queue        string                   `json:"queue"`
+	Phase        scheduling.PodGroupPhase `json:"phase"`
+	MinAvailable int32                    `json:"minAvailable"`
+	// Reason explains why the job stayed pending, it is empty if the job is ready.
+	Reason string        `json:"reason,omitempty"`
+	Tasks  []*TaskReport `json:"tasks"`
+}
+
+// TaskReport describes the scheduling decision for a task.
+type TaskReport struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Node is the node the task is bound, pipelined or running on.
+	Node string `json:"node,omitempty"`
+	// Reason and Message are the scheduling reason of the task, as they would be set
+	// on the PodScheduled condition of the pod.
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Victim is a task evicted during the session.
+type Victim struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Job       string `json:"job"`
+	Node      string `json:"node"`
+	Reason    string `json:"reason"`
+}
+
+// newReport builds the report from the state recorded by the fake cache once the session is closed.
+func newReport(fc *fakeCache, actions []string) *Report {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+
+	report := &Report{
+		Actions: actions,
+		Jobs:    []*JobReport{},
+		Victims: fc.evictions,
+	}
+	if fc.snapshot == nil {
+		return report
+	}
+
+	for _, job := range fc.snapshot.Jobs {
+		report.Jobs = append(report.Jobs, newJobReport(job, fc.updatedJobs[job.UID], fc.binds))
+	}
+	sort.Slice(report.Jobs, func(i, j int) bool {
+		if report.Jobs[i].Namespace != report.Jobs[j].Namespace {
+			return report.Jobs[i].Namespace < report.Jobs[j].Namespace
+		}
+		return report.Jobs[i].Name < report.Jobs[j].Name
+	})
+
+	return report
+}
+
+func newJobReport(job *api.JobInfo, scheduled bool, binds map[api.TaskID]string) *JobReport {
+	jr := &JobReport{
+		Namespace:    job.Namespace,
+		Name:         job.Name,
+		Queue:        string(job.Queue),
+		Phase:        job.PodGroup.Status.Phase,
+		MinAvailable: job.MinAvailable,
+		Tasks:        []*TaskReport{},
+	}
+
+	if !scheduled {
+		// The job was dropped when opening the session because it is invalid,
+		// the reason is kept in its unschedulable condition.
+		for _, c := range job.PodGroup.Status.Conditions {
+			if c.Type == scheduling.PodGroupUnschedulableType && c.Status == v1.ConditionTrue {
+				jr.Reason = fmt.Sprintf("%s: %s", c.Reason, c.Message)
+			}
+		}
+	} else if jr.Phase == scheduling.PodGroupPending || jr.Phase == scheduling.PodGroupInqueue ||
+		jr.Phase == scheduling.PodGroupUnknown {
+		jr.Reason = fmt.Sprintf("%v/%v tasks in gang unschedulable: %v",
+			len(job.TaskStatusIndex[api.Pending]), len(job.Tasks), job.FitError())
+	}
+
+	for _, task := range job.Tasks {
+		tr := &TaskReport{
+			Name:   task.Name,
+			Status: task.Status.String(),
+			Node:   task.NodeName,
+		}
+		if node, found := binds[task.UID]; found {
+			tr.Node = node
+		}
+		if task.Status == api.Allocated || task.Status == api.Pending || task.Status == api.Pipelined {
+			tr.Reason, tr.Message, _ = job.TaskSchedulingReason(task.UID)
+		}
+		jr.Tasks = append(jr.Tasks, tr)
+	}
+	sort.Slice(jr.Tasks, func(i, j int) bool {
+		return jr.Tasks[i].Name < jr.Tasks[j].Name
+	})
+
+	return jr
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// Simulator runs one scheduling session offline: the cluster state is loaded into
+// an in-memory cache and the configured actions and plugins are executed against it
+// without touching a live cluster.
+type Simulator struct {
+	cluster        *Cluster
+	actions        []framework.Action
+	tiers          []conf.Tier
+	configurations []conf.Configuration
+}
+
+// New returns a Simulator for the given cluster state and scheduler configuration,
+// an empty configuration falls back to the default scheduler configuration.
+func New(cluster *Cluster, schedulerConf string) (*Simulator, error) {
+	if len(schedulerConf) == 0 {
+		schedulerConf = scheduler.DefaultSchedulerConf
+	}
+
+	actions, tiers, configurations, _, err := scheduler.UnmarshalSchedulerConf(schedulerConf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Simulator{
+		cluster:        cluster,
+		actions:        actions,
+		tiers:          tiers,
+		configurations: configurations,
+	}, nil
+}
+
+// Run executes the configured actions in one session and reports the decisions.
+func (s *Simulator) Run() (*Report, error) {
+	fc, err := newFakeCache(s.cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	conf.EnabledActionMap = make(map[string]bool)
+	actionNames := make([]string, 0, len(s.actions))
+	for _, action := range s.actions {
+		conf.EnabledActionMap[action.Name()] = true
+		actionNames = append(actionNames, action.Name())
+	}
+
+	startTime := time.Now()
+	ssn := framework.OpenSession(fc, s.tiers, s.configurations)
+	for _, action := range s.actions {
+		ssn.StartAction(action.Name())
+		action.Execute(ssn)
+	}
+	framework.CloseSession(ssn)
+	klog.V(3).Infof("Simulated session with actions %v in %v", actionNames, time.Since(startTime))
+
+	return newReport(fc, actionNames), nil
+}
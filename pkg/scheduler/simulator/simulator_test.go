@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	kschedulingv1 "k8s.io/api/scheduling/v1"
+	"sigs.k8s.io/yaml"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/util"
+
+	_ "volcano.sh/volcano/pkg/scheduler/actions"
+	_ "volcano.sh/volcano/pkg/scheduler/plugins"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+const preemptConf = `
+actions: "preempt"
+tiers:
+- plugins:
+  - name: conformance
+  - name: gang
+  - name: priority
+  - name: proportion
+`
+
+func TestSimulate(t *testing.T) {
+	tests := []struct {
+		name          string
+		cluster       *Cluster
+		conf          string
+		expectTasks   map[string]string // ns/task -> status@node
+		expectPending map[string]string // ns/job -> substring of reason
+		expectVictims []string
+	}{
+		{
+			name: "job fits and is bound, gang larger than the cluster stays pending",
+			cluster: &Cluster{
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+				},
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue),
+					util.BuildPodGroup("pg2", "c1", "q1", 2, nil, schedulingv1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil),
+					util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("2", "1G"), "pg2", nil, nil),
+					util.BuildPod("c1", "p3", "", v1.PodPending, api.BuildResourceList("2", "1G"), "pg2", nil, nil),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+			},
+			expectTasks: map[string]string{
+				"c1/p1": "Binding@n1",
+				"c1/p2": "Pending@",
+				"c1/p3": "Pending@",
+			},
+			expectPending: map[string]string{
+				"c1/pg2": "2/2 tasks in gang unschedulable",
+			},
+		},
+		{
+			name: "high priority job preempts a low priority job in the same queue",
+			conf: preemptConf,
+			cluster: &Cluster{
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("12", "12G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+				},
+				PriorityClasses: []*kschedulingv1.PriorityClass{
+					util.BuildPriorityClass("low-priority", 100),
+					util.BuildPriorityClass("high-priority", 1000),
+				},
+				PodGroups: []*schedulingv1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 0, map[string]int32{}, schedulingv1.PodGroupInqueue, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, map[string]int32{"": 1}, schedulingv1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("3", "3G"), "pg1", map[string]string{schedulingv1.PodPreemptable: "true"}, nil),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("3", "3G"), "pg2", nil, nil),
+				},
+				Queues: []*schedulingv1.Queue{
+					util.BuildQueue("q1", 1, api.BuildResourceList("4", "4G")),
+				},
+			},
+			expectTasks: map[string]string{
+				"c1/preemptor1": "Pipelined@n1",
+			},
+			expectVictims: []string{"c1/preemptee1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sim, err := New(test.cluster, test.conf)
+			assert.NoError(t, err)
+			report, err := sim.Run()
+			assert.NoError(t, err)
+
+			tasks := map[string]string{}
+			reasons := map[string]string{}
+			for _, job := range report.Jobs {
+				reasons[job.Namespace+"/"+job.Name] = job.Reason
+				for _, task := range job.Tasks {
+					tasks[job.Namespace+"/"+task.Name] = task.Status + "@" + task.Node
+				}
+			}
+			for key, want := range test.expectTasks {
+				assert.Equal(t, want, tasks[key], "task %s", key)
+			}
+			for key, want := range test.expectPending {
+				assert.True(t, strings.Contains(reasons[key], want), "job %s reason %q should contain %q", key, reasons[key], want)
+			}
+
+			victims := []string{}
+			for _, v := range report.Victims {
+				victims = append(victims, v.Namespace+"/"+v.Name)
+			}
+			assert.ElementsMatch(t, test.expectVictims, victims)
+		})
+	}
+}
+
+func TestLoadCluster(t *testing.T) {
+	cluster := &Cluster{
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("2", "4Gi"), nil),
+		},
+		Queues: []*schedulingv1.Queue{
+			util.BuildQueue("q1", 1, nil),
+		},
+	}
+	data, err := yaml.Marshal(cluster)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "cluster.yaml")
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+
+	loaded, err := LoadCluster(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(loaded.Nodes))
+	assert.Equal(t, "n1", loaded.Nodes[0].Name)
+	assert.Equal(t, 1, len(loaded.Queues))
+}
+
+func TestSimulateClusterSnapshot(t *testing.T) {
+	cluster := &Cluster{
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+		},
+		PodGroups: []*schedulingv1.PodGroup{
+			util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil),
+		},
+		Queues: []*schedulingv1.Queue{
+			util.BuildQueue("q1", 1, nil),
+		},
+	}
+	fc, err := newFakeCache(cluster)
+	assert.NoError(t, err)
+	snapshot, err := cache.NewClusterSnapshot(fc.SchedulerCache.Snapshot())
+	assert.NoError(t, err)
+	data, err := json.Marshal(snapshot)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+	loaded, err := LoadCluster(path)
+	assert.NoError(t, err)
+	assert.NotNil(t, loaded.snapshot)
+
+	sim, err := New(loaded, "")
+	assert.NoError(t, err)
+	report, err := sim.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(report.Jobs))
+	assert.Equal(t, "Binding", report.Jobs[0].Tasks[0].Status)
+	assert.Equal(t, "n1", report.Jobs[0].Tasks[0].Node)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+// scheduleTrigger starts the scheduling cycles early on the events of the cache. The events are debounced:
+// a cycle starts at most once per minPeriod, the events meanwhile are coalesced into it, and at least once per period.
+type scheduleTrigger struct {
+	minPeriod time.Duration
+	period    time.Duration
+
+	mutex sync.Mutex
+	// reasons are the reasons of the events since the latest cycle started.
+	reasons map[string]struct{}
+	// pending holds a signal while an event waits for a cycle.
+	pending chan struct{}
+}
+
+func newScheduleTrigger(minPeriod, period time.Duration) *scheduleTrigger {
+	return &scheduleTrigger{
+		minPeriod: minPeriod,
+		period:    period,
+		reasons:   map[string]struct{}{},
+		pending:   make(chan struct{}, 1),
+	}
+}
+
+// Trigger requests a scheduling cycle for the given reason, it never blocks.
+func (t *scheduleTrigger) Trigger(reason string) {
+	t.mutex.Lock()
+	t.reasons[reason] = struct{}{}
+	t.mutex.Unlock()
+
+	select {
+	case t.pending <- struct{}{}:
+	default:
+	}
+}
+
+// popReasons returns the sorted reasons of the events since the latest cycle started and forgets them.
+func (t *scheduleTrigger) popReasons() []string {
+	select {
+	case <-t.pending:
+	default:
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	reasons := make([]string, 0, len(t.reasons))
+	for reason := range t.reasons {
+		reasons = append(reasons, reason)
+	}
+	t.reasons = map[string]struct{}{}
+	sort.Strings(reasons)
+	return reasons
+}
+
+// run calls runOnce on the triggers until stopCh is closed. Like wait.Until, the period
+// is measured from the end of the previous cycle, the minPeriod from its start.
+func (t *scheduleTrigger) run(runOnce func(), stopCh <-chan struct{}) {
+	var lastStart time.Time
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-timer.C:
+		case <-t.pending:
+			if wait := t.minPeriod - time.Since(lastStart); wait > 0 {
+				select {
+				case <-stopCh:
+					return
+				case <-time.After(wait):
+				}
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		reasons := t.popReasons()
+		if len(reasons) == 0 {
+			reasons = []string{metrics.TriggerPeriod}
+		}
+		for _, reason := range reasons {
+			metrics.RegisterScheduleTrigger(reason)
+		}
+		klog.V(4).Infof("Scheduling cycle triggered by %v", reasons)
+
+		lastStart = time.Now()
+		runOnce()
+		timer.Reset(t.period)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestScheduleTriggerRun(t *testing.T) {
+	trigger := newScheduleTrigger(200*time.Millisecond, time.Hour)
+	var cycles int32
+	started := make(chan struct{}, 10)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go trigger.run(func() {
+		atomic.AddInt32(&cycles, 1)
+		started <- struct{}{}
+	}, stopCh)
+
+	waitForCycle := func(desc string, timeout time.Duration) time.Time {
+		select {
+		case <-started:
+			return time.Now()
+		case <-time.After(timeout):
+			t.Fatalf("timed out waiting for %s", desc)
+		}
+		return time.Time{}
+	}
+
+	// The first cycle starts right away, like with wait.Until.
+	first := waitForCycle("the first cycle", time.Second)
+
+	// A burst of events is coalesced into one cycle, no sooner than the minimum period.
+	for i := 0; i < 10; i++ {
+		trigger.Trigger(metrics.TriggerPodGroupAdded)
+	}
+	trigger.Trigger(metrics.TriggerNodeAdded)
+	second := waitForCycle("the triggered cycle", time.Second)
+	assert.GreaterOrEqual(t, second.Sub(first), 200*time.Millisecond)
+
+	select {
+	case <-started:
+		t.Fatalf("unexpected cycle without event")
+	case <-time.After(300 * time.Millisecond):
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&cycles))
+	assert.Empty(t, trigger.popReasons())
+}
+
+func TestScheduleTriggerPeriod(t *testing.T) {
+	trigger := newScheduleTrigger(10*time.Millisecond, 50*time.Millisecond)
+	var cycles int32
+	stopCh := make(chan struct{})
+	go trigger.run(func() { atomic.AddInt32(&cycles, 1) }, stopCh)
+
+	// Without event the cycles still run every period.
+	time.Sleep(300 * time.Millisecond)
+	close(stopCh)
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&cycles), int32(3))
+}
+
+func TestScheduleTriggerCacheEvents(t *testing.T) {
+	trigger := newScheduleTrigger(time.Second, time.Second)
+	cache := schedcache.NewCustomMockSchedulerCache("volcano", nil, nil, nil, nil, nil, nil)
+	cache.SetScheduleTrigger(trigger.Trigger)
+
+	queue := util.BuildQueue("q1", 1, nil)
+	cache.AddQueueV1beta1(queue)
+	assert.Equal(t, []string{metrics.TriggerQueueChanged}, trigger.popReasons())
+
+	// The status updates of the queue by the scheduler do not trigger a cycle.
+	newQueue := queue.DeepCopy()
+	newQueue.ResourceVersion = "2"
+	newQueue.Status.Running = 1
+	cache.UpdateQueueV1beta1(queue, newQueue)
+	assert.Empty(t, trigger.popReasons())
+
+	cache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("2", "4Gi"), nil))
+	cache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	assert.Equal(t, []string{metrics.TriggerNodeAdded, metrics.TriggerPodGroupAdded}, trigger.popReasons())
+
+	// Updating a node does not trigger a cycle.
+	cache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), nil))
+	assert.Empty(t, trigger.popReasons())
+
+	pod := util.BuildPod("ns1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+	cache.AddPod(pod)
+	assert.Empty(t, trigger.popReasons())
+	cache.DeletePod(pod)
+	assert.Equal(t, []string{metrics.TriggerPodDeleted}, trigger.popReasons())
+}
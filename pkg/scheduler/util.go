@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"gopkg.in/yaml.v2"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
@@ -50,7 +51,11 @@ func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []
 
 	schedulerConf := &conf.SchedulerConfiguration{}
 
-	if err := yaml.Unmarshal([]byte(confStr), schedulerConf); err != nil {
+	// Unknown fields, e.g. a misspelled enableXxx of a plugin, are rejected.
+	if err := yaml.UnmarshalStrict([]byte(confStr), schedulerConf); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if err := validatePlugins(schedulerConf.Tiers); err != nil {
 		return nil, nil, nil, nil, err
 	}
 	// Set default settings for each plugin if not set
@@ -87,6 +92,53 @@ func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []
 	return actions, schedulerConf.Tiers, schedulerConf.Configurations, schedulerConf.MetricsConfiguration, nil
 }
 
+// movedArgument is an argument moved to another plugin.
+type movedArgument struct {
+	plugin   string
+	argument string
+}
+
+// movedArguments are the arguments by plugin which no longer take effect, the configurations enabling them
+// are rejected unless they enable the argument they moved to as well.
+var movedArguments = map[string]map[string]movedArgument{
+	"predicates": {
+		"predicate.GPUSharingEnable": {plugin: "deviceshare", argument: "deviceshare.GPUSharingEnable"},
+		"predicate.GPUNumberEnable":  {plugin: "deviceshare", argument: "deviceshare.GPUNumberEnable"},
+	},
+}
+
+// validatePlugins checks that every plugin is registered and that its arguments match
+// the argument schema of the plugin.
+func validatePlugins(tiers []conf.Tier) error {
+	var errs []error
+	configured := map[string]framework.Arguments{}
+	for _, tier := range tiers {
+		for _, plugin := range tier.Plugins {
+			configured[plugin.Name] = plugin.Arguments
+		}
+	}
+	for i, tier := range tiers {
+		for _, plugin := range tier.Plugins {
+			if _, found := framework.GetPluginBuilder(plugin.Name); !found {
+				errs = append(errs, fmt.Errorf("tier %d: unknown plugin %q", i, plugin.Name))
+				continue
+			}
+			if err := framework.ValidatePluginArguments(plugin.Name, plugin.Arguments); err != nil {
+				errs = append(errs, fmt.Errorf("tier %d: plugin %q: %v", i, plugin.Name, err))
+			}
+			for key, moved := range movedArguments[plugin.Name] {
+				enabled, _ := plugin.Arguments[key].(bool)
+				movedEnabled, _ := configured[moved.plugin][moved.argument].(bool)
+				if enabled && !movedEnabled {
+					errs = append(errs, fmt.Errorf("tier %d: plugin %q: argument %q moved to the plugin %q as %q",
+						i, plugin.Name, key, moved.plugin, moved.argument))
+				}
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 func runSchedulerSocket() {
 	fs := flag.CommandLine
 	startKlogLevel := fs.Lookup("v").Value.String()
@@ -170,6 +170,21 @@ func GetNodeList(nodes map[string]*api.NodeInfo, nodeList []string) []*api.NodeI
 	return result
 }
 
+// IntersectNodes returns the nodes that are also in others, in the order of nodes
+func IntersectNodes(nodes []*api.NodeInfo, others []*api.NodeInfo) []*api.NodeInfo {
+	names := make(map[string]struct{}, len(others))
+	for _, ni := range others {
+		names[ni.Name] = struct{}{}
+	}
+	result := make([]*api.NodeInfo, 0, len(nodes))
+	for _, ni := range nodes {
+		if _, found := names[ni.Name]; found {
+			result = append(result, ni)
+		}
+	}
+	return result
+}
+
 // ValidateVictims returns an error if the resources of the victims can't satisfy the preemptor
 func ValidateVictims(preemptor *api.TaskInfo, node *api.NodeInfo, victims []*api.TaskInfo) error {
 	// Victims should not be judged to be empty here.
@@ -17,6 +17,8 @@ limitations under the License.
 package scheduler
 
 import (
+	"os"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -214,3 +216,152 @@ tiers:
 			expectedConfigurations, configurations)
 	}
 }
+
+func TestUnmarshalSchedulerConfValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		configuration string
+		errMsg        string
+	}{
+		{
+			name: "valid plugin arguments",
+			configuration: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: binpack
+    arguments:
+      binpack.weight: 10
+      binpack.resources: nvidia.com/gpu
+      binpack.resources.nvidia.com/gpu: 2
+  - name: overcommit
+    arguments:
+      overcommit-factor: 2
+`,
+		},
+		{
+			name: "unknown plugin",
+			configuration: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gangg
+`,
+			errMsg: `tier 0: unknown plugin "gangg"`,
+		},
+		{
+			name: "misspelled enable option",
+			configuration: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gang
+    enableJobReadyy: false
+`,
+			errMsg: "field enableJobReadyy not found",
+		},
+		{
+			name: "unknown plugin argument",
+			configuration: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gang
+- plugins:
+  - name: nodeorder
+    arguments:
+      leastrequested.weigth: 1
+`,
+			errMsg: `tier 1: plugin "nodeorder": unknown argument "leastrequested.weigth"`,
+		},
+		{
+			name: "plugin argument type mismatch",
+			configuration: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: predicates
+    arguments:
+      predicate.GPUSharingEnable: "true"
+`,
+			errMsg: `tier 0: plugin "predicates": argument "predicate.GPUSharingEnable" must be of type bool, got string`,
+		},
+		{
+			name: "moved plugin argument without its plugin",
+			configuration: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: predicates
+    arguments:
+      predicate.GPUNumberEnable: true
+`,
+			errMsg: `tier 0: plugin "predicates": argument "predicate.GPUNumberEnable" moved to the plugin "deviceshare" as "deviceshare.GPUNumberEnable"`,
+		},
+		{
+			name: "moved plugin argument with its plugin without the argument",
+			configuration: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: predicates
+    arguments:
+      predicate.GPUSharingEnable: true
+- plugins:
+  - name: deviceshare
+    arguments:
+      deviceshare.GPUNumberEnable: true
+`,
+			errMsg: `tier 0: plugin "predicates": argument "predicate.GPUSharingEnable" moved to the plugin "deviceshare" as "deviceshare.GPUSharingEnable"`,
+		},
+		{
+			name: "moved plugin argument with its plugin",
+			configuration: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: predicates
+    arguments:
+      predicate.GPUSharingEnable: true
+- plugins:
+  - name: deviceshare
+    arguments:
+      deviceshare.GPUSharingEnable: true
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, _, _, err := UnmarshalSchedulerConf(test.configuration)
+			if test.errMsg == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.errMsg) {
+				t.Errorf("expected error containing %q, got %v", test.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestShippedSchedulerConfsAreValid(t *testing.T) {
+	confs := []string{
+		"../../installer/helm/chart/volcano/config/volcano-scheduler.conf",
+		"../../installer/helm/chart/volcano/config/volcano-scheduler-ci.conf",
+	}
+	for _, path := range confs {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		if _, _, _, _, err := UnmarshalSchedulerConf(string(data)); err != nil {
+			t.Errorf("%s is invalid: %v", path, err)
+		}
+	}
+	if _, _, _, _, err := UnmarshalSchedulerConf(DefaultSchedulerConf); err != nil {
+		t.Errorf("default scheduler conf is invalid: %v", err)
+	}
+}
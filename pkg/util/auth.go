@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/authenticatorfactory"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+var delegatedAuthRetryBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   1.5,
+	Jitter:   0.2,
+	Steps:    5,
+}
+
+// delegatedAuthHandler authenticates and authorizes requests against the kube-apiserver
+// with TokenReview and SubjectAccessReview before handing them to the wrapped handler.
+type delegatedAuthHandler struct {
+	authenticator authenticator.Request
+	authorizer    authorizer.Authorizer
+	handler       http.Handler
+}
+
+// WithDelegatedAuth wraps the handler so that only the callers allowed to access the request
+// path as a non-resource URL, e.g. `nonResourceURLs: ["/debug/*"], verbs: ["get"]`, reach it.
+func WithDelegatedAuth(client kubernetes.Interface, handler http.Handler) (http.Handler, error) {
+	authn, _, err := authenticatorfactory.DelegatingAuthenticatorConfig{
+		TokenAccessReviewClient:  client.AuthenticationV1(),
+		TokenAccessReviewTimeout: 10 * time.Second,
+		WebhookRetryBackoff:      &delegatedAuthRetryBackoff,
+		CacheTTL:                 10 * time.Second,
+	}.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create delegating authenticator: %v", err)
+	}
+
+	authz, err := authorizerfactory.DelegatingAuthorizerConfig{
+		SubjectAccessReviewClient: client.AuthorizationV1(),
+		AllowCacheTTL:             10 * time.Second,
+		DenyCacheTTL:              10 * time.Second,
+		WebhookRetryBackoff:       &delegatedAuthRetryBackoff,
+	}.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create delegating authorizer: %v", err)
+	}
+
+	return &delegatedAuthHandler{authenticator: authn, authorizer: authz, handler: handler}, nil
+}
+
+func (h *delegatedAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp, ok, err := h.authenticator.AuthenticateRequest(r)
+	if err != nil || !ok {
+		klog.V(4).Infof("Unauthenticated request to %s: %v", r.URL.Path, err)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	attrs := authorizer.AttributesRecord{
+		User:            resp.User,
+		Verb:            strings.ToLower(r.Method),
+		Path:            r.URL.Path,
+		ResourceRequest: false,
+	}
+	decision, reason, err := h.authorizer.Authorize(r.Context(), attrs)
+	if err != nil {
+		klog.Errorf("Failed to authorize user <%s> to %s %s: %v", resp.User.GetName(), attrs.Verb, attrs.Path, err)
+		http.Error(w, "Authorization failed", http.StatusInternalServerError)
+		return
+	}
+	if decision != authorizer.DecisionAllow {
+		klog.V(4).Infof("User <%s> is forbidden to %s %s: %s", resp.User.GetName(), attrs.Verb, attrs.Path, reason)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ExporterNone disables the tracing.
+	ExporterNone = "none"
+	// ExporterOTLP exports the spans to an OpenTelemetry collector over OTLP/gRPC.
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes the spans to the standard output.
+	ExporterStdout = "stdout"
+	// ExporterFile writes the spans to a file, e.g. to inspect them offline.
+	ExporterFile = "file"
+
+	// TraceContextAnnotation holds the W3C traceparent of the span binding the pod, the components
+	// handling the pod afterwards join the trace of its scheduling with it.
+	TraceContextAnnotation = "volcano.sh/trace-context"
+
+	instrumentationName = "volcano.sh/volcano"
+	traceParentHeader   = "traceparent"
+)
+
+// The attributes of the spans.
+const (
+	SessionKey = attribute.Key("volcano.session")
+	ActionKey  = attribute.Key("volcano.action")
+	PluginKey  = attribute.Key("volcano.plugin")
+	JobKey     = attribute.Key("volcano.job")
+	QueueKey   = attribute.Key("volcano.queue")
+	TaskKey    = attribute.Key("volcano.task")
+	NodeKey    = attribute.Key("volcano.node")
+	// OperationsKey is the number of the operations of a committed statement.
+	OperationsKey = attribute.Key("volcano.operations")
+)
+
+var propagator = propagation.TraceContext{}
+
+// Options is the configuration of the span export.
+type Options struct {
+	// Exporter is one of none, otlp, stdout and file.
+	Exporter string
+	// Endpoint is the host:port of the collector the otlp exporter sends the spans to.
+	Endpoint string
+	// Insecure disables the TLS of the connection to the collector.
+	Insecure bool
+	// File is the path of the file the file exporter appends the spans to.
+	File string
+	// SamplingRatio is the ratio of the sampled traces, the child spans follow their parent.
+	SamplingRatio float64
+	// ServiceName is the service.name of the spans.
+	ServiceName string
+}
+
+// Init sets the global tracer provider up with the exporter of the options and returns the function
+// flushing the pending spans and stopping the export. Nothing is exported with the none exporter.
+func Init(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var file io.Closer
+	var err error
+
+	switch opts.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		grpcOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+		if opts.Insecure {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, grpcOpts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		f, openErr := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if openErr != nil {
+			return nil, fmt.Errorf("failed to open the trace file %s: %v", opts.File, openErr)
+		}
+		file = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", opts.Exporter)
+	}
+	if err != nil {
+		if file != nil {
+			file.Close()
+		}
+		return nil, fmt.Errorf("failed to create the %s tracing exporter: %v", opts.Exporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SamplingRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", opts.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+// Tracer returns the tracer of volcano, its spans are dropped until Init sets an exporter up.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts a span with the attributes as a child of the span of ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// JobAttributes returns the attributes of the job, given as namespace/name, and of its queue.
+func JobAttributes(job, queue string) []attribute.KeyValue {
+	return []attribute.KeyValue{JobKey.String(job), QueueKey.String(queue)}
+}
+
+// TraceParent returns the W3C traceparent of the span of ctx, it is empty if ctx holds no span.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get(traceParentHeader)
+}
+
+// ContextWithTraceParent returns ctx with the span of the traceparent, the spans started from it are its children.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier{traceParentHeader: traceParent})
+}
+
+// InjectAnnotation sets the trace context of ctx into the annotations and returns whether it was set,
+// it is only set for the sampled spans.
+func InjectAnnotation(ctx context.Context, annotations map[string]string) bool {
+	if !trace.SpanContextFromContext(ctx).IsSampled() {
+		return false
+	}
+	annotations[TraceContextAnnotation] = TraceParent(ctx)
+	return true
+}
+
+// ContextFromAnnotations returns ctx with the trace context of the annotations, the spans started
+// from it join the trace the object was scheduled in. ctx is returned as is without the annotation.
+func ContextFromAnnotations(ctx context.Context, annotations map[string]string) context.Context {
+	return ContextWithTraceParent(ctx, annotations[TraceContextAnnotation])
+}
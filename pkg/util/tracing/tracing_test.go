@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestAnnotation(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "bind")
+	defer span.End()
+
+	annotations := map[string]string{}
+	assert.True(t, InjectAnnotation(ctx, annotations))
+	assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", annotations[TraceContextAnnotation])
+
+	joined := trace.SpanContextFromContext(ContextFromAnnotations(context.Background(), annotations))
+	assert.True(t, joined.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), joined.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), joined.SpanID())
+
+	notSampled := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()))
+	ctx, dropped := notSampled.Tracer("test").Start(context.Background(), "bind")
+	defer dropped.End()
+	annotations = map[string]string{}
+	assert.False(t, InjectAnnotation(ctx, annotations), "the context of the spans not sampled is not propagated")
+	assert.Empty(t, annotations)
+	assert.Equal(t, context.Background(), ContextFromAnnotations(context.Background(), annotations))
+	assert.Empty(t, TraceParent(context.Background()), "no traceparent without a span")
+}
+
+func TestInit(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	_, err := Init(context.Background(), Options{Exporter: "jaeger"})
+	assert.EqualError(t, err, `unknown tracing exporter "jaeger"`)
+
+	file := filepath.Join(t.TempDir(), "spans.json")
+	shutdown, err := Init(context.Background(), Options{Exporter: ExporterFile, File: file, SamplingRatio: 1, ServiceName: "volcano-scheduler"})
+	assert.NoError(t, err)
+	_, span := Start(context.Background(), "runOnce", SessionKey.String("s1"))
+	span.End()
+	assert.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"runOnce"`)
+	assert.Contains(t, string(data), `"volcano.session"`)
+	assert.Contains(t, string(data), `"volcano-scheduler"`)
+}